@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"Fix-Go-Fiber-Backend/pkg/config"
+	"Fix-Go-Fiber-Backend/pkg/database"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print the statements that would run without executing them")
+	steps := flag.Int("steps", 1, "number of migrations to roll back with the down command")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: migrate [-dry-run] [-steps N] up|down|status")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// Connect to database
+	db, err := database.NewDatabaseConnection(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Failed to get database connection:", err)
+	}
+
+	migrator, err := database.NewMigrator(sqlDB, cfg.Database.Driver)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	migrator.DryRun = *dryRun || cfg.Database.MigrateDryRun
+
+	ctx := context.Background()
+	switch flag.Arg(0) {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx, *steps)
+	case "status":
+		err = printStatus(ctx, migrator)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal("Migration failed:", err)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *database.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		state := "pending"
+		if status.Applied != nil {
+			state = "applied " + status.Applied.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d_%-40s %s\n", status.Migration.Version, status.Migration.Name, state)
+	}
+	return nil
+}
@@ -1,17 +1,29 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
 
 	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
 	"Fix-Go-Fiber-Backend/internal/delivery/http/route"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/repository"
 	"Fix-Go-Fiber-Backend/internal/usecase"
 	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/breach"
 	"Fix-Go-Fiber-Backend/pkg/config"
 	"Fix-Go-Fiber-Backend/pkg/database"
 	"Fix-Go-Fiber-Backend/pkg/jwt"
 	"Fix-Go-Fiber-Backend/pkg/logger"
+	"Fix-Go-Fiber-Backend/pkg/mailer"
+	"Fix-Go-Fiber-Backend/pkg/oidc"
+	"Fix-Go-Fiber-Backend/pkg/oidc/mockidp"
 	"Fix-Go-Fiber-Backend/pkg/validator"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,6 +36,12 @@ func main() {
 		log.Fatal("Failed to load config:", err)
 	}
 
+	// The first admin can also be given on the command line; flags win over BOOTSTRAP_ADMIN_*
+	flag.StringVar(&cfg.Bootstrap.AdminUsername, "admin-username", cfg.Bootstrap.AdminUsername, "username of the initial super admin, used only while no admin exists")
+	flag.StringVar(&cfg.Bootstrap.AdminEmail, "admin-email", cfg.Bootstrap.AdminEmail, "email of the initial super admin")
+	flag.StringVar(&cfg.Bootstrap.AdminPassword, "admin-password", cfg.Bootstrap.AdminPassword, "password of the initial super admin, to be changed on first login")
+	flag.Parse()
+
 	// Setup logger
 	appLogger := logger.NewLogrus(cfg)
 	appLogger.Info("Starting application...")
@@ -40,26 +58,219 @@ func main() {
 	}
 
 	// Initialize utilities
-	bcryptHelper := bcrypt.NewBcryptHelper(12)
-	bcryptUtil := bcrypt.NewBcryptUtil(12)
-	jwtUtil := jwt.NewJWTUtil(cfg)
+	if err := bcrypt.ValidateCost(cfg.Auth.BcryptCost); err != nil {
+		appLogger.Fatal("Invalid AUTH_BCRYPT_COST:", err)
+	}
+	bcryptHelper := bcrypt.NewBcryptHelper(cfg.Auth.BcryptCost)
+	bcryptUtil := bcrypt.NewBcryptUtil(cfg.Auth.BcryptCost)
+	// RS256 and EdDSA keys are kept in the database and rotated in the background
+	jwtUtil, err := jwt.NewJWTUtil(cfg, repository.NewJWTKeyStore(repository.NewJWTSigningKeyRepository(db)))
+	if err != nil {
+		appLogger.Fatal("Failed to set up JWT signing:", err)
+	}
+	go jwtUtil.RunKeyRotation(context.Background())
 	customValidator := validator.NewCustomValidator()
+
+	mailSender, err := mailer.NewMailer(cfg)
+	if err != nil {
+		appLogger.Fatal("Failed to set up mailer:", err)
+	}
+	passwordResetExpire, err := time.ParseDuration(cfg.Auth.PasswordResetExpire)
+	if err != nil {
+		appLogger.Fatal("Invalid AUTH_PASSWORD_RESET_EXPIRE:", err)
+	}
+	passwordResetResendInterval, err := time.ParseDuration(cfg.Auth.PasswordResetResendInterval)
+	if err != nil {
+		appLogger.Fatal("Invalid AUTH_PASSWORD_RESET_RESEND_INTERVAL:", err)
+	}
+	emailVerificationExpire, err := time.ParseDuration(cfg.Auth.EmailVerificationExpire)
+	if err != nil {
+		appLogger.Fatal("Invalid AUTH_EMAIL_VERIFICATION_EXPIRE:", err)
+	}
+	verificationResendInterval, err := time.ParseDuration(cfg.Auth.VerificationResendInterval)
+	if err != nil {
+		appLogger.Fatal("Invalid AUTH_VERIFICATION_RESEND_INTERVAL:", err)
+	}
+	loginPolicy := usecase.LoginAttemptPolicy{
+		MaxAttempts:   cfg.Auth.LoginMaxAttempts,
+		IPMaxAttempts: cfg.Auth.LoginIPMaxAttempts,
+	}
+	if loginPolicy.DelayStep, err = time.ParseDuration(cfg.Auth.LoginDelayStep); err != nil {
+		appLogger.Fatal("Invalid AUTH_LOGIN_DELAY_STEP:", err)
+	}
+	if loginPolicy.Lockout, err = time.ParseDuration(cfg.Auth.LoginLockout); err != nil {
+		appLogger.Fatal("Invalid AUTH_LOGIN_LOCKOUT:", err)
+	}
+	if loginPolicy.MaxLockout, err = time.ParseDuration(cfg.Auth.LoginMaxLockout); err != nil {
+		appLogger.Fatal("Invalid AUTH_LOGIN_MAX_LOCKOUT:", err)
+	}
+	if loginPolicy.Window, err = time.ParseDuration(cfg.Auth.LoginAttemptWindow); err != nil {
+		appLogger.Fatal("Invalid AUTH_LOGIN_ATTEMPT_WINDOW:", err)
+	}
+	roleCacheTTL, err := time.ParseDuration(cfg.Auth.RoleCacheTTL)
+	if err != nil {
+		appLogger.Fatal("Invalid AUTH_ROLE_CACHE_TTL:", err)
+	}
+	sessionCleanupInterval, err := time.ParseDuration(cfg.Auth.SessionCleanupInterval)
+	if err != nil || sessionCleanupInterval < 0 {
+		appLogger.Fatal("Invalid AUTH_SESSION_CLEANUP_INTERVAL:", cfg.Auth.SessionCleanupInterval)
+	}
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength:  cfg.Auth.PasswordMinLength,
+		MinClasses: cfg.Auth.PasswordMinClasses,
+		History:    cfg.Auth.PasswordHistory,
+	}
+	if passwordPolicy.AdminMaxAge, err = time.ParseDuration(cfg.Auth.PasswordAdminMaxAge); err != nil {
+		appLogger.Fatal("Invalid AUTH_PASSWORD_ADMIN_MAX_AGE:", err)
+	}
+	if cfg.Auth.PasswordBreachCheck {
+		if passwordPolicy.Breached, err = breach.Load(cfg.Auth.PasswordBreachedList); err != nil {
+			appLogger.Fatal("Failed to load AUTH_PASSWORD_BREACHED_LIST:", err)
+		}
+		appLogger.Infof("Breached password check uses %d bundled hashes and a %d byte list file", passwordPolicy.Breached.Len(), passwordPolicy.Breached.FileSize())
+	}
+	mfaPolicy := usecase.MFAPolicy{Issuer: cfg.Auth.MFAIssuer}
+	if mfaPolicy.Issuer == "" {
+		mfaPolicy.Issuer = cfg.App.Name
+	}
+	for _, role := range strings.Split(cfg.Auth.MFARequiredRoles, ",") {
+		if role = strings.TrimSpace(role); role == "" {
+			continue
+		}
+		if !entity.AdminRole(role).IsValid() {
+			appLogger.Fatal("Invalid AUTH_MFA_REQUIRED_ROLES: unknown admin role ", role)
+		}
+		mfaPolicy.RequiredRoles = append(mfaPolicy.RequiredRoles, entity.AdminRole(role))
+	}
+	// The mock identity provider signs anyone in, so it only runs in development
+	if cfg.OIDC.MockEnabled {
+		if !cfg.IsDevelopment() {
+			appLogger.Fatal("OIDC_MOCK_ENABLED is only allowed with APP_ENV=development")
+		}
+		if cfg.OIDC.Issuer == "" {
+			cfg.OIDC.Issuer = "http://" + cfg.OIDC.MockAddr
+		}
+		if cfg.OIDC.ClientID == "" {
+			cfg.OIDC.ClientID, cfg.OIDC.ClientSecret = "mock-client", "mock-secret"
+		}
+		provider, err := mockidp.New(cfg.OIDC.Issuer, cfg.OIDC.ClientID, cfg.OIDC.ClientSecret)
+		if err != nil {
+			appLogger.Fatal("Failed to create mock OIDC provider:", err)
+		}
+		listener, err := net.Listen("tcp", cfg.OIDC.MockAddr)
+		if err != nil {
+			appLogger.Fatal("Failed to start mock OIDC provider:", err)
+		}
+		go http.Serve(listener, provider)
+		appLogger.Warnf("Mock OIDC provider running at %s; it signs in anyone without a password", cfg.OIDC.Issuer)
+		cfg.OIDC.Enabled = true
+	}
+	var oidcPolicy usecase.OIDCPolicy
+	if cfg.OIDC.Enabled {
+		if cfg.OIDC.Issuer == "" || cfg.OIDC.ClientID == "" {
+			appLogger.Fatal("OIDC_ENABLED needs OIDC_ISSUER and OIDC_CLIENT_ID")
+		}
+		if cfg.OIDC.RedirectURL == "" {
+			cfg.OIDC.RedirectURL = strings.TrimSuffix(cfg.App.BaseURL, "/") + "/api/v1/auth/oidc/callback"
+		}
+		oidcPolicy = usecase.OIDCPolicy{Issuer: cfg.OIDC.Issuer, NIMClaim: cfg.OIDC.NIMClaim}
+		if oidcPolicy.StateExpire, err = time.ParseDuration(cfg.OIDC.StateExpire); err != nil {
+			appLogger.Fatal("Invalid OIDC_STATE_EXPIRE:", err)
+		}
+	}
 	standardValidator := customValidator.GetValidator() // Get standard validator for mahasiswa handler
 
 	// Initialize repositories
 	mahasiswaRepo := repository.NewMahasiswaRepository(db)
 	adminRepo := repository.NewAdminUserRepository(db)
 	pekerjaanAlumniRepo := repository.NewPekerjaanAlumniRepository(db)
+	statusHistoryRepo := repository.NewMahasiswaStatusHistoryRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	passwordResetRepo := repository.NewPasswordResetTokenRepository(db)
+	emailVerificationRepo := repository.NewEmailVerificationTokenRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	loginLockoutRepo := repository.NewLoginLockoutRepository(db)
+	recoveryCodeRepo := repository.NewAdminRecoveryCodeRepository(db)
+	graduationRequestRepo := repository.NewGraduationRequestRepository(db)
+	externalIdentityRepo := repository.NewExternalIdentityRepository(db)
+	oidcLoginStateRepo := repository.NewOIDCLoginStateRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	impersonationLogRepo := repository.NewImpersonationLogRepository(db)
+	passwordHistoryRepo := repository.NewPasswordHistoryRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	// Revoked access tokens are kept in memory unless JWT_REVOCATION_STORE=database
+	revocationStore := repository.NewMemoryTokenRevocationStore()
+	if cfg.JWT.RevocationStore == "database" {
+		revocationStore = repository.NewTokenRevocationStore(db)
+	}
 
 	// Initialize use cases
-	mahasiswaUsecase := usecase.NewMahasiswaUsecase(mahasiswaRepo, bcryptHelper)
-	pekerjaanUsecase := usecase.NewPekerjaanAlumniUsecase(pekerjaanAlumniRepo, mahasiswaRepo)
-	authService := usecase.NewAuthService(mahasiswaRepo, adminRepo, jwtUtil, bcryptUtil)
+	tokenSigner := usecase.NewTokenSigner(jwtUtil)
+	passwordPolicyService := usecase.NewPasswordPolicyUsecase(passwordHistoryRepo, bcryptUtil, passwordPolicy)
+	emailService := usecase.NewEmailService(mailSender, cfg.App.BaseURL)
+	verificationService := usecase.NewEmailVerificationUsecase(mahasiswaRepo, emailVerificationRepo, unitOfWork, emailService, emailVerificationExpire, verificationResendInterval)
+	mahasiswaUsecase := usecase.NewMahasiswaUsecase(mahasiswaRepo, bcryptHelper, passwordPolicyService, verificationService)
+	pekerjaanUsecase := usecase.NewPekerjaanAlumniUsecase(pekerjaanAlumniRepo, mahasiswaRepo, unitOfWork)
+	lifecycleService := usecase.NewMahasiswaLifecycleUsecase(mahasiswaRepo, statusHistoryRepo, unitOfWork)
+	graduationService := usecase.NewGraduationUsecase(graduationRequestRepo, mahasiswaRepo, lifecycleService, emailService, unitOfWork)
+	loginAttemptService := usecase.NewLoginAttemptUsecase(loginAttemptRepo, loginLockoutRepo, unitOfWork, loginPolicy)
+	mfaService := usecase.NewMFAUsecase(adminRepo, recoveryCodeRepo, refreshTokenRepo, revocationStore, loginAttemptService, unitOfWork, bcryptUtil, mfaPolicy)
+	authService := usecase.NewAuthService(mahasiswaRepo, adminRepo, refreshTokenRepo, sessionRepo, revocationStore, verificationService, loginAttemptService, mfaService, passwordPolicyService, unitOfWork, tokenSigner, bcryptUtil, cfg.Auth.RequireEmailVerification, roleCacheTTL)
+	adminUserService := usecase.NewAdminUserUsecase(adminRepo, refreshTokenRepo, revocationStore, unitOfWork, bcryptUtil, passwordPolicyService)
+	bootstrapService := usecase.NewBootstrapUsecase(adminRepo, unitOfWork, bcryptUtil, passwordPolicyService, cfg.IsProduction())
+	apiKeyService := usecase.NewAPIKeyUsecase(apiKeyRepo, adminRepo)
+	impersonationService := usecase.NewImpersonationUsecase(mahasiswaRepo, impersonationLogRepo, tokenSigner, jwtUtil.ImpersonationExpire())
+	sessionService := usecase.NewSessionUsecase(sessionRepo, refreshTokenRepo)
+	if sessionCleanupInterval > 0 {
+		go sessionService.RunCleanup(context.Background(), sessionCleanupInterval)
+	}
+	passwordService := usecase.NewPasswordUsecase(mahasiswaRepo, adminRepo, passwordResetRepo, refreshTokenRepo, revocationStore, unitOfWork, bcryptUtil, passwordPolicyService, emailService, passwordResetExpire, passwordResetResendInterval)
+
+	// Create the first admin, or refuse to start in production while an admin has a default password
+	setupToken, err := bootstrapService.Bootstrap(context.Background(), &dto.InitialAdmin{
+		Username: cfg.Bootstrap.AdminUsername,
+		Email:    cfg.Bootstrap.AdminEmail,
+		Password: cfg.Bootstrap.AdminPassword,
+	})
+	if err != nil {
+		appLogger.Fatal("Failed to bootstrap admin:", err)
+	}
+	if setupToken != "" {
+		appLogger.Warnf("No admin exists. Create the first super admin with POST /api/v1/auth/setup and setup_token %s (valid until restart)", setupToken)
+	}
 
 	// Initialize handlers
 	mahasiswaHandler := handler.NewMahasiswaHandler(mahasiswaUsecase, standardValidator)
+	mahasiswaStatusHandler := handler.NewMahasiswaStatusHandler(lifecycleService, standardValidator)
+	graduationHandler := handler.NewGraduationHandler(graduationService, standardValidator)
 	pekerjaanHandler := handler.NewPekerjaanAlumniHandler(pekerjaanUsecase, standardValidator)
+	adminUserHandler := handler.NewAdminUserHandler(adminUserService, standardValidator)
+	mfaHandler := handler.NewMFAHandler(mfaService, standardValidator)
+	loginAttemptHandler := handler.NewLoginAttemptHandler(loginAttemptService, standardValidator)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService, standardValidator)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationService, standardValidator)
+	sessionHandler := handler.NewSessionHandler(sessionService, standardValidator)
 	authHandler := handler.NewAuthHandler(authService, customValidator)
+	passwordHandler := handler.NewPasswordHandler(passwordService, customValidator)
+	verificationHandler := handler.NewEmailVerificationHandler(verificationService, customValidator)
+	setupHandler := handler.NewSetupHandler(bootstrapService, customValidator)
+	jwksHandler := handler.NewJWKSHandler(jwtUtil)
+
+	// SSO login is served only when an OpenID Connect provider is configured
+	var oidcHandler *handler.OIDCHandler
+	if cfg.OIDC.Enabled {
+		oidcClient := oidc.NewClient(oidc.Config{
+			Issuer:       cfg.OIDC.Issuer,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       strings.Fields(cfg.OIDC.Scopes),
+		})
+		oidcService := usecase.NewOIDCUsecase(oidcClient, externalIdentityRepo, oidcLoginStateRepo, mahasiswaRepo, adminRepo, authService, oidcPolicy)
+		oidcHandler = handler.NewOIDCHandler(oidcService, customValidator, strings.HasPrefix(cfg.App.BaseURL, "https://"))
+	}
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -78,7 +289,7 @@ func main() {
 	})
 
 	// Setup routes
-	route.SetupRoutes(app, cfg, authHandler, mahasiswaHandler, pekerjaanHandler, jwtUtil)
+	route.SetupRoutes(app, cfg, authHandler, passwordHandler, verificationHandler, setupHandler, oidcHandler, mahasiswaHandler, mahasiswaStatusHandler, graduationHandler, pekerjaanHandler, adminUserHandler, mfaHandler, loginAttemptHandler, apiKeyHandler, impersonationHandler, sessionHandler, jwksHandler, authService, apiKeyService)
 
 	// Start server
 	address := ":" + cfg.App.Port
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminUserHandler struct {
+	adminService service.AdminUserService
+	validator    *validator.Validate
+}
+
+func NewAdminUserHandler(adminService service.AdminUserService, validator *validator.Validate) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminService: adminService,
+		validator:    validator,
+	}
+}
+
+// Create handles POST /admins
+func (h *AdminUserHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateAdminRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	admin, err := h.adminService.CreateAdmin(c.UserContext(), &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Admin berhasil dibuat",
+		Data:    admin.ToResponse(),
+	})
+}
+
+// GetAll handles GET /admins
+func (h *AdminUserHandler) GetAll(c *fiber.Ctx) error {
+	var query dto.PaginationQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	offset := query.GetOffset()
+	admins, total, err := h.adminService.ListAdmins(c.UserContext(), query.Limit, offset)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	responses := make([]*entity.AdminUserResponse, len(admins))
+	for i, a := range admins {
+		responses[i] = a.ToResponse()
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data admin berhasil diambil",
+		Data:    responses,
+		Meta:    query.GetMeta(total),
+	})
+}
+
+// GetByID handles GET /admins/:id
+func (h *AdminUserHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	admin, err := h.adminService.GetAdmin(c.UserContext(), uint(id))
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Admin ditemukan",
+		Data:    admin.ToResponse(),
+	})
+}
+
+// Update handles PUT /admins/:id
+func (h *AdminUserHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	var req dto.UpdateAdminRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	admin, err := h.adminService.UpdateAdmin(c.UserContext(), uint(id), &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Admin berhasil diperbarui",
+		Data:    admin.ToResponse(),
+	})
+}
+
+// ChangeRole handles PUT /admins/:id/role
+func (h *AdminUserHandler) ChangeRole(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	var req dto.ChangeAdminRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	admin, err := h.adminService.ChangeRole(c.UserContext(), uint(id), &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Role admin berhasil diubah",
+		Data:    admin.ToResponse(),
+	})
+}
+
+// Activate handles POST /admins/:id/activate
+func (h *AdminUserHandler) Activate(c *fiber.Ctx) error {
+	return h.setActive(c, true, "Admin berhasil diaktifkan")
+}
+
+// Deactivate handles POST /admins/:id/deactivate
+func (h *AdminUserHandler) Deactivate(c *fiber.Ctx) error {
+	return h.setActive(c, false, "Admin berhasil dinonaktifkan")
+}
+
+func (h *AdminUserHandler) setActive(c *fiber.Ctx, active bool, message string) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	admin, err := h.adminService.SetActive(c.UserContext(), uint(id), active)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    admin.ToResponse(),
+	})
+}
+
+// Delete handles DELETE /admins/:id
+func (h *AdminUserHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	if err := h.adminService.DeleteAdmin(c.UserContext(), uint(id)); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Admin berhasil dihapus",
+	})
+}
+
+// ChangeOwnPassword handles PUT /admins/me/password
+func (h *AdminUserHandler) ChangeOwnPassword(c *fiber.Ctx) error {
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.adminService.ChangePassword(c.UserContext(), claims.UserID, &req); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Password berhasil diubah, silakan login kembali",
+	})
+}
+
+func (h *AdminUserHandler) fail(c *fiber.Ctx, err error) error {
+	return c.Status(adminErrorCode(err)).JSON(dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+func adminErrorCode(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrAdminNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrAdminUsernameTaken), errors.Is(err, usecase.ErrAdminEmailTaken),
+		errors.Is(err, usecase.ErrLastSuperAdmin):
+		return fiber.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidCurrentPassword), errors.Is(err, usecase.ErrPasswordUnchanged),
+		errors.Is(err, usecase.ErrDefaultAdminPassword), errors.Is(err, usecase.ErrWeakPassword):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
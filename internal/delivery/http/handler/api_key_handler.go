@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	validator     *validator.Validate
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService, validator *validator.Validate) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		validator:     validator,
+	}
+}
+
+// Create handles POST /api-keys
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	key, err := h.apiKeyService.Create(c.UserContext(), &req, claims)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi",
+		Data:    key,
+	})
+}
+
+// GetAll handles GET /api-keys
+func (h *APIKeyHandler) GetAll(c *fiber.Ctx) error {
+	var query dto.PaginationQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	offset := query.GetOffset()
+	keys, total, err := h.apiKeyService.List(c.UserContext(), query.Limit, offset)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data API key berhasil diambil",
+		Data:    keys,
+		Meta:    query.GetMeta(total),
+	})
+}
+
+// GetByID handles GET /api-keys/:id
+func (h *APIKeyHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	key, err := h.apiKeyService.Get(c.UserContext(), uint(id))
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "API key ditemukan",
+		Data:    key,
+	})
+}
+
+// Rotate handles POST /api-keys/:id/rotate
+func (h *APIKeyHandler) Rotate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	key, err := h.apiKeyService.Rotate(c.UserContext(), uint(id))
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "API key berhasil diganti, key lama tidak berlaku lagi",
+		Data:    key,
+	})
+}
+
+// Revoke handles DELETE /api-keys/:id
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	if err := h.apiKeyService.Revoke(c.UserContext(), uint(id)); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "API key berhasil dicabut",
+	})
+}
+
+func (h *APIKeyHandler) fail(c *fiber.Ctx, err error) error {
+	return c.Status(apiKeyErrorCode(err)).JSON(dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+func apiKeyErrorCode(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrAPIKeyNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrAPIKeyRevoked), errors.Is(err, usecase.ErrAPIKeyExpired):
+		return fiber.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidAPIKeyScope), errors.Is(err, usecase.ErrInvalidAPIKeyExpiry):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
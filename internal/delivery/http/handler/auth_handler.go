@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
 	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
 	"Fix-Go-Fiber-Backend/pkg/utils"
 	"Fix-Go-Fiber-Backend/pkg/validator"
 
@@ -21,6 +26,32 @@ func NewAuthHandler(authService service.AuthService, validator *validator.Custom
 	}
 }
 
+// Login handles login of any account by email, NIM or username
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req dto.LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	response, err := h.authService.Login(c.UserContext(), &req)
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	// An admin with MFA on enters the TOTP code next
+	if response.MFAChallenge != nil {
+		return c.JSON(utils.SuccessResponse("MFA verification required", response.MFAChallenge))
+	}
+
+	return c.JSON(utils.SuccessResponse("Login successful", response))
+}
+
 // LoginMahasiswa handles mahasiswa login
 func (h *AuthHandler) LoginMahasiswa(c *fiber.Ctx) error {
 	var req dto.MahasiswaLoginRequest
@@ -32,9 +63,11 @@ func (h *AuthHandler) LoginMahasiswa(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
 	}
 
-	response, err := h.authService.LoginMahasiswa(&req)
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	response, err := h.authService.LoginMahasiswa(c.UserContext(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+		return loginError(c, err)
 	}
 
 	return c.JSON(utils.SuccessResponse("Login successful", response))
@@ -51,9 +84,11 @@ func (h *AuthHandler) LoginAlumni(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
 	}
 
-	response, err := h.authService.LoginAlumni(&req)
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	response, err := h.authService.LoginAlumni(c.UserContext(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+		return loginError(c, err)
 	}
 
 	return c.JSON(utils.SuccessResponse("Login successful", response))
@@ -70,7 +105,7 @@ func (h *AuthHandler) RegisterMahasiswa(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
 	}
 
-	response, err := h.authService.RegisterMahasiswa(&req)
+	response, err := h.authService.RegisterMahasiswa(c.UserContext(), &req)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
 	}
@@ -78,9 +113,9 @@ func (h *AuthHandler) RegisterMahasiswa(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(utils.SuccessResponse("Registration successful", response))
 }
 
-// GraduateMahasiswa handles marking mahasiswa as graduated (alumni)
-func (h *AuthHandler) GraduateMahasiswa(c *fiber.Ctx) error {
-	var req dto.GraduateMahasiswaRequest
+// LoginAdmin handles admin login
+func (h *AuthHandler) LoginAdmin(c *fiber.Ctx) error {
+	var req dto.AdminLoginRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
 	}
@@ -89,17 +124,24 @@ func (h *AuthHandler) GraduateMahasiswa(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
 	}
 
-	response, err := h.authService.GraduateMahasiswa(&req)
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	response, err := h.authService.LoginAdmin(c.UserContext(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		return loginError(c, err)
+	}
+
+	// The password was right, the TOTP code comes next
+	if response.MFAChallenge != nil {
+		return c.JSON(utils.SuccessResponse("MFA verification required", response.MFAChallenge))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(utils.SuccessResponse("Graduation successful", response))
+	return c.JSON(utils.SuccessResponse("Login successful", response))
 }
 
-// LoginAdmin handles admin login
-func (h *AuthHandler) LoginAdmin(c *fiber.Ctx) error {
-	var req dto.AdminLoginRequest
+// VerifyAdminMFA completes an admin login with a TOTP or recovery code
+func (h *AuthHandler) VerifyAdminMFA(c *fiber.Ctx) error {
+	var req dto.AdminMFAVerifyRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
 	}
@@ -108,14 +150,68 @@ func (h *AuthHandler) LoginAdmin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
 	}
 
-	response, err := h.authService.LoginAdmin(&req)
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	response, err := h.authService.VerifyAdminMFA(c.UserContext(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+		return loginError(c, err)
 	}
 
 	return c.JSON(utils.SuccessResponse("Login successful", response))
 }
 
+// RefreshToken handles exchanging a refresh token for a new token pair
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	response, err := h.authService.RefreshToken(c.UserContext(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(utils.SuccessResponse("Token refreshed successfully", response))
+}
+
+// Logout revokes the current access token and, if given, its refresh token
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req dto.LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+		}
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.authService.Logout(c.UserContext(), claims, &req); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(utils.SuccessResponse("Logout successful", nil))
+}
+
+// LogoutAll revokes every token of the current user on all devices
+func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.authService.LogoutAll(c.UserContext(), claims); err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(utils.SuccessResponse("Logged out from all devices", nil))
+}
+
 // GetProfile returns current user profile based on token
 func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
 	role := c.Locals("role").(string)
@@ -132,5 +228,33 @@ func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
 		profile["username"] = username
 	}
 
+	claims := c.Locals("user").(*service.JWTClaims)
+	if claims.AdminRole != "" {
+		profile["admin_role"] = claims.AdminRole
+	}
+	profile["permissions"] = claims.Permissions
+	if claims.Impersonator != nil {
+		profile["impersonator"] = claims.Impersonator
+	}
+
 	return c.JSON(utils.SuccessResponse("Profile retrieved successfully", profile))
-}
\ No newline at end of file
+}
+
+// loginError answers a failed login. Unknown accounts and wrong passwords get the same
+// answer, and so do blocked attempts whether or not the account exists.
+func loginError(c *fiber.Ctx, err error) error {
+	var locked *usecase.LoginLockedError
+	switch {
+	case errors.As(err, &locked):
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(locked.RetryAfterSeconds()))
+		return c.Status(fiber.StatusTooManyRequests).JSON(utils.ErrorResponse(locked.Error()))
+	case errors.Is(err, usecase.ErrInvalidCredentials), errors.Is(err, usecase.ErrInvalidMFACode),
+		errors.Is(err, usecase.ErrInvalidMFAChallenge):
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+	case errors.Is(err, usecase.ErrEmailNotVerified), errors.Is(err, usecase.ErrAdminInactive):
+		// Only reached with the right password
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(err.Error()))
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Login failed"))
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+	"Fix-Go-Fiber-Backend/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailVerificationHandler struct {
+	verificationService service.EmailVerificationService
+	validator           *validator.CustomValidator
+}
+
+func NewEmailVerificationHandler(verificationService service.EmailVerificationService, validator *validator.CustomValidator) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		verificationService: verificationService,
+		validator:           validator,
+	}
+}
+
+// VerifyEmail marks an email as verified using the token from the verification link
+func (h *EmailVerificationHandler) VerifyEmail(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Verification token is required"))
+	}
+
+	if err := h.verificationService.VerifyEmail(c.UserContext(), token); err != nil {
+		if errors.Is(err, usecase.ErrInvalidVerificationToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to verify email"))
+	}
+
+	return c.JSON(utils.SuccessResponse("Email verified successfully", nil))
+}
+
+// ResendVerification mails a new verification link to an unverified account
+func (h *EmailVerificationHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dto.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	if err := h.verificationService.ResendVerification(c.UserContext(), &req); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to resend verification email"))
+	}
+
+	return c.JSON(utils.SuccessResponse("If the account is awaiting verification, a new verification email has been sent", nil))
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GraduationHandler struct {
+	graduationService service.GraduationService
+	validator         *validator.Validate
+}
+
+func NewGraduationHandler(graduationService service.GraduationService, validator *validator.Validate) *GraduationHandler {
+	return &GraduationHandler{
+		graduationService: graduationService,
+		validator:         validator,
+	}
+}
+
+// Submit handles POST /graduation-requests
+func (h *GraduationHandler) Submit(c *fiber.Ctx) error {
+	var req dto.SubmitGraduationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	request, err := h.graduationService.Submit(c.UserContext(), claims.UserID, &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Pengajuan kelulusan berhasil dikirim dan menunggu review",
+		Data:    request,
+	})
+}
+
+// GetOwn handles GET /graduation-requests/me
+func (h *GraduationHandler) GetOwn(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*service.JWTClaims)
+	requests, err := h.graduationService.GetOwn(c.UserContext(), claims.UserID)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Pengajuan kelulusan berhasil diambil",
+		Data:    requests,
+	})
+}
+
+// GetAll handles GET /graduation-requests
+func (h *GraduationHandler) GetAll(c *fiber.Ctx) error {
+	var query dto.GraduationRequestQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	pagination := query.Pagination()
+	offset := pagination.GetOffset()
+	requests, total, err := h.graduationService.List(c.UserContext(), entity.GraduationRequestStatus(query.Status), pagination.Limit, offset)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data pengajuan kelulusan berhasil diambil",
+		Data:    requests,
+		Meta:    pagination.GetMeta(total),
+	})
+}
+
+// GetByID handles GET /graduation-requests/:id
+func (h *GraduationHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	request, err := h.graduationService.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Pengajuan kelulusan berhasil diambil",
+		Data:    request,
+	})
+}
+
+// Approve handles POST /graduation-requests/:id/approve
+func (h *GraduationHandler) Approve(c *fiber.Ctx) error {
+	return h.review(c, h.graduationService.Approve, "Pengajuan kelulusan disetujui, mahasiswa sekarang alumni")
+}
+
+// Reject handles POST /graduation-requests/:id/reject
+func (h *GraduationHandler) Reject(c *fiber.Ctx) error {
+	return h.review(c, h.graduationService.Reject, "Pengajuan kelulusan ditolak")
+}
+
+type reviewFunc func(ctx context.Context, id uint, req *dto.ReviewGraduationRequest, reviewer *service.JWTClaims) (*entity.GraduationRequest, error)
+
+func (h *GraduationHandler) review(c *fiber.Ctx, review reviewFunc, message string) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	var req dto.ReviewGraduationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	request, err := review(c.UserContext(), uint(id), &req, claims)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    request,
+	})
+}
+
+func (h *GraduationHandler) fail(c *fiber.Ctx, err error) error {
+	return c.Status(graduationErrorCode(err)).JSON(dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+func graduationErrorCode(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrGraduationRequestNotFound), errors.Is(err, usecase.ErrMahasiswaNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrGraduationRequestPending), errors.Is(err, usecase.ErrGraduationRequestReviewed),
+		errors.Is(err, usecase.ErrNotEligibleForGraduation), errors.Is(err, entity.ErrInvalidStatusTransition):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
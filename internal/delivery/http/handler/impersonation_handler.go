@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImpersonationHandler struct {
+	impersonationService service.ImpersonationService
+	validator            *validator.Validate
+}
+
+func NewImpersonationHandler(impersonationService service.ImpersonationService, validator *validator.Validate) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+		validator:            validator,
+	}
+}
+
+// Impersonate handles POST /mahasiswa/:id/impersonate
+func (h *ImpersonationHandler) Impersonate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	response, err := h.impersonationService.Impersonate(c.UserContext(), uint(id), &req, claims)
+	if err != nil {
+		code := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrMahasiswaNotFound):
+			code = fiber.StatusNotFound
+		case errors.Is(err, usecase.ErrNestedImpersonation):
+			code = fiber.StatusForbidden
+		}
+		return c.Status(code).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Token impersonation berhasil dibuat",
+		Data:    response,
+	})
+}
+
+// GetLogs handles GET /impersonations
+func (h *ImpersonationHandler) GetLogs(c *fiber.Ctx) error {
+	var query dto.ImpersonationLogQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	filter := repository.ImpersonationLogFilter{
+		AdminID:     query.AdminID,
+		MahasiswaID: query.MahasiswaID,
+	}
+
+	pagination := query.Pagination()
+	offset := pagination.GetOffset()
+	logs, total, err := h.impersonationService.ListLogs(c.UserContext(), filter, pagination.Limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data impersonation berhasil diambil",
+		Data:    logs,
+		Meta:    pagination.GetMeta(total),
+	})
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"Fix-Go-Fiber-Backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSHandler struct {
+	jwtUtil *jwt.JWTUtil
+}
+
+func NewJWKSHandler(jwtUtil *jwt.JWTUtil) *JWKSHandler {
+	return &JWKSHandler{
+		jwtUtil: jwtUtil,
+	}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json. It is a plain JWK Set, not an APIResponse,
+// so standard JWT libraries can read it.
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	// Keys change once per rotation; a verifier meeting an unknown kid should fetch again
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.jwtUtil.JWKS())
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LoginAttemptHandler struct {
+	attemptService service.LoginAttemptService
+	validator      *validator.Validate
+}
+
+func NewLoginAttemptHandler(attemptService service.LoginAttemptService, validator *validator.Validate) *LoginAttemptHandler {
+	return &LoginAttemptHandler{
+		attemptService: attemptService,
+		validator:      validator,
+	}
+}
+
+// GetLockouts handles GET /login-lockouts
+func (h *LoginAttemptHandler) GetLockouts(c *fiber.Ctx) error {
+	var query dto.PaginationQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	offset := query.GetOffset()
+	lockouts, total, err := h.attemptService.ListLockouts(c.UserContext(), query.Limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data lockout berhasil diambil",
+		Data:    lockouts,
+		Meta:    query.GetMeta(total),
+	})
+}
+
+// ClearLockout handles DELETE /login-lockouts/:id
+func (h *LoginAttemptHandler) ClearLockout(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	if err := h.attemptService.ClearLockout(c.UserContext(), uint(id)); err != nil {
+		code := fiber.StatusInternalServerError
+		if errors.Is(err, usecase.ErrLockoutNotFound) {
+			code = fiber.StatusNotFound
+		}
+		return c.Status(code).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Lockout berhasil dihapus",
+	})
+}
+
+// GetAttempts handles GET /login-attempts
+func (h *LoginAttemptHandler) GetAttempts(c *fiber.Ctx) error {
+	var query dto.LoginAttemptQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	filter := repository.LoginAttemptFilter{
+		UserType:   query.UserType,
+		Identifier: strings.ToLower(strings.TrimSpace(query.Identifier)),
+		IPAddress:  query.IPAddress,
+		Outcome:    query.Outcome,
+	}
+
+	pagination := query.Pagination()
+	offset := pagination.GetOffset()
+	attempts, total, err := h.attemptService.ListAttempts(c.UserContext(), filter, pagination.Limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data percobaan login berhasil diambil",
+		Data:    attempts,
+		Meta:    pagination.GetMeta(total),
+	})
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
 	"Fix-Go-Fiber-Backend/internal/usecase"
 
 	"github.com/gofiber/fiber/v2"
@@ -49,7 +52,7 @@ func (h *MahasiswaHandler) Create(c *fiber.Ctx) error {
 		Password: req.Password,
 	}
 
-	if err := h.mahasiswaUsecase.Create(c.Context(), mahasiswa); err != nil {
+	if err := h.mahasiswaUsecase.Create(c.UserContext(), mahasiswa); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
 			Success: false,
 			Message: err.Error(),
@@ -73,9 +76,10 @@ func (h *MahasiswaHandler) GetByID(c *fiber.Ctx) error {
 		})
 	}
 
-	mahasiswa, err := h.mahasiswaUsecase.GetByID(c.Context(), uint(id))
+	claims := c.Locals("user").(*service.JWTClaims)
+	mahasiswa, err := h.mahasiswaUsecase.GetByID(c.UserContext(), uint(id), claims)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.APIResponse{
+		return c.Status(accessErrorCode(err, fiber.StatusNotFound)).JSON(dto.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
@@ -110,9 +114,9 @@ func (h *MahasiswaHandler) GetAll(c *fiber.Ctx) error {
 	var err error
 
 	if query.Search != "" {
-		mahasiswas, total, err = h.mahasiswaUsecase.Search(c.Context(), query.Search, query.Limit, query.GetOffset())
+		mahasiswas, total, err = h.mahasiswaUsecase.Search(c.UserContext(), query.Search, query.Limit, query.GetOffset())
 	} else {
-		mahasiswas, total, err = h.mahasiswaUsecase.GetAll(c.Context(), query.Limit, query.GetOffset())
+		mahasiswas, total, err = h.mahasiswaUsecase.GetAll(c.UserContext(), query.Limit, query.GetOffset())
 	}
 
 	if err != nil {
@@ -168,15 +172,16 @@ func (h *MahasiswaHandler) Update(c *fiber.Ctx) error {
 		NoTelepon: req.NoTelepon,
 	}
 
-	if err := h.mahasiswaUsecase.Update(c.Context(), uint(id), mahasiswa); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.mahasiswaUsecase.Update(c.UserContext(), uint(id), mahasiswa, claims); err != nil {
+		return c.Status(accessErrorCode(err, fiber.StatusBadRequest)).JSON(dto.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
 	}
 
 	// Get updated mahasiswa
-	updatedMahasiswa, err := h.mahasiswaUsecase.GetByID(c.Context(), uint(id))
+	updatedMahasiswa, err := h.mahasiswaUsecase.GetByID(c.UserContext(), uint(id), claims)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
 			Success: false,
@@ -201,7 +206,7 @@ func (h *MahasiswaHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.mahasiswaUsecase.Delete(c.Context(), uint(id)); err != nil {
+	if err := h.mahasiswaUsecase.Delete(c.UserContext(), uint(id)); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
 			Success: false,
 			Message: err.Error(),
@@ -212,4 +217,12 @@ func (h *MahasiswaHandler) Delete(c *fiber.Ctx) error {
 		Success: true,
 		Message: "Mahasiswa berhasil dihapus",
 	})
-}
\ No newline at end of file
+}
+
+// accessErrorCode is 403 for an error of the policy and code otherwise
+func accessErrorCode(err error, code int) int {
+	if errors.Is(err, policy.ErrForbidden) {
+		return fiber.StatusForbidden
+	}
+	return code
+}
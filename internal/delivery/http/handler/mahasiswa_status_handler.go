@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MahasiswaStatusHandler struct {
+	lifecycleService service.MahasiswaLifecycleService
+	validator        *validator.Validate
+}
+
+func NewMahasiswaStatusHandler(lifecycleService service.MahasiswaLifecycleService, validator *validator.Validate) *MahasiswaStatusHandler {
+	return &MahasiswaStatusHandler{
+		lifecycleService: lifecycleService,
+		validator:        validator,
+	}
+}
+
+// ChangeStatus handles POST /mahasiswa/:id/status
+func (h *MahasiswaStatusHandler) ChangeStatus(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	var req dto.ChangeStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	actor, _ := c.Locals("user").(*service.JWTClaims)
+	mahasiswa, history, err := h.lifecycleService.ChangeStatus(c.UserContext(), uint(id), &req, actor)
+	if err != nil {
+		return c.Status(statusErrorCode(err)).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Status mahasiswa berhasil diubah",
+		Data: fiber.Map{
+			"mahasiswa":  mahasiswa.ToResponse(),
+			"transition": history,
+		},
+	})
+}
+
+// GetStatusHistory handles GET /mahasiswa/:id/status
+func (h *MahasiswaStatusHandler) GetStatusHistory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	mahasiswa, histories, err := h.lifecycleService.GetStatusHistory(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(statusErrorCode(err)).JSON(dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Riwayat status mahasiswa berhasil diambil",
+		Data: fiber.Map{
+			"mahasiswa_id": mahasiswa.ID,
+			"status":       mahasiswa.Status,
+			"history":      histories,
+		},
+	})
+}
+
+func statusErrorCode(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrMahasiswaNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidStatusTransition):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MFAHandler struct {
+	mfaService service.MFAService
+	validator  *validator.Validate
+}
+
+func NewMFAHandler(mfaService service.MFAService, validator *validator.Validate) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+		validator:  validator,
+	}
+}
+
+// GetStatus handles GET /admins/me/mfa
+func (h *MFAHandler) GetStatus(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*service.JWTClaims)
+	status, err := h.mfaService.Status(c.UserContext(), claims.UserID)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Status MFA berhasil diambil",
+		Data:    status,
+	})
+}
+
+// Enroll handles POST /admins/me/mfa/enroll
+func (h *MFAHandler) Enroll(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*service.JWTClaims)
+	enrollment, err := h.mfaService.Enroll(c.UserContext(), claims.UserID)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tambahkan secret ke aplikasi authenticator, lalu konfirmasi dengan kode",
+		Data:    enrollment,
+	})
+}
+
+// Confirm handles POST /admins/me/mfa/confirm
+func (h *MFAHandler) Confirm(c *fiber.Ctx) error {
+	var req dto.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	req.IPAddress = c.IP()
+	claims := c.Locals("user").(*service.JWTClaims)
+	codes, err := h.mfaService.Confirm(c.UserContext(), claims.UserID, &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "MFA aktif, simpan recovery code lalu login kembali",
+		Data:    codes,
+	})
+}
+
+// RegenerateRecoveryCodes handles POST /admins/me/mfa/recovery-codes
+func (h *MFAHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	var req dto.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	req.IPAddress = c.IP()
+	claims := c.Locals("user").(*service.JWTClaims)
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.UserContext(), claims.UserID, &req)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Recovery code baru dibuat, recovery code lama tidak berlaku lagi",
+		Data:    codes,
+	})
+}
+
+// Disable handles POST /admins/me/mfa/disable
+func (h *MFAHandler) Disable(c *fiber.Ctx) error {
+	var req dto.DisableMFARequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	req.IPAddress = c.IP()
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.mfaService.Disable(c.UserContext(), claims.UserID, &req); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "MFA berhasil dinonaktifkan",
+	})
+}
+
+// Reset handles DELETE /admins/:id/mfa
+func (h *MFAHandler) Reset(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	if err := h.mfaService.Reset(c.UserContext(), uint(id)); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "MFA admin berhasil direset",
+	})
+}
+
+func (h *MFAHandler) fail(c *fiber.Ctx, err error) error {
+	// Wrong codes count as failed logins of the admin and lock out alike
+	var locked *usecase.LoginLockedError
+	if errors.As(err, &locked) {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(locked.RetryAfterSeconds()))
+		return c.Status(fiber.StatusTooManyRequests).JSON(dto.APIResponse{
+			Success: false,
+			Message: locked.Error(),
+		})
+	}
+
+	return c.Status(mfaErrorCode(err)).JSON(dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+func mfaErrorCode(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrMFAAlreadyEnabled), errors.Is(err, usecase.ErrMFARequiredForRole):
+		return fiber.StatusConflict
+	case errors.Is(err, usecase.ErrMFANotEnrolled), errors.Is(err, usecase.ErrInvalidMFACode):
+		return fiber.StatusBadRequest
+	default:
+		return adminErrorCode(err)
+	}
+}
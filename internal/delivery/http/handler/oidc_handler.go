@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+	"Fix-Go-Fiber-Backend/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// oidcStateCookie ties an SSO login to the browser that started it
+const oidcStateCookie = "oidc_state"
+
+type OIDCHandler struct {
+	oidcService service.OIDCService
+	validator   *validator.CustomValidator
+	// secureCookies marks the state cookie Secure, when the API is served over HTTPS
+	secureCookies bool
+}
+
+func NewOIDCHandler(oidcService service.OIDCService, validator *validator.CustomValidator, secureCookies bool) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:   oidcService,
+		validator:     validator,
+		secureCookies: secureCookies,
+	}
+}
+
+// Login redirects the browser to the identity provider
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	var query dto.OIDCLoginQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid query parameters"))
+	}
+
+	if err := h.validator.Validate(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	redirect, err := h.oidcService.Start(c.UserContext(), &query)
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(utils.ErrorResponse("SSO login is unavailable"))
+	}
+
+	h.setStateCookie(c, redirect.State, redirect.ExpiresAt)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Redirect(redirect.AuthURL, fiber.StatusFound)
+}
+
+// Callback completes the login the identity provider redirected back from
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	var query dto.OIDCCallbackQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid query parameters"))
+	}
+
+	if err := h.validator.Validate(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	query.StateCookie = c.Cookies(oidcStateCookie)
+	query.IPAddress = c.IP()
+	query.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	response, err := h.oidcService.Callback(c.UserContext(), &query)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOIDCLoginExpired), errors.Is(err, usecase.ErrOIDCWrongBrowser):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		case errors.Is(err, usecase.ErrOIDCRefused):
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+		case errors.Is(err, usecase.ErrOIDCNotLinked), errors.Is(err, usecase.ErrOIDCLinkConflict):
+			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(err.Error()))
+		default:
+			return loginError(c, err)
+		}
+	}
+
+	// The state is used up
+	h.setStateCookie(c, "", time.Unix(0, 0))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	// An admin with MFA on enters the TOTP code next
+	if response.MFAChallenge != nil {
+		return c.JSON(utils.SuccessResponse("MFA verification required", response.MFAChallenge))
+	}
+
+	return c.JSON(utils.SuccessResponse("Login successful", response))
+}
+
+// setStateCookie sets the state cookie, or removes it with an expiry in the past. Lax lets
+// the browser send it along with the provider's redirect back to the callback.
+func (h *OIDCHandler) setStateCookie(c *fiber.Ctx, state string, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/",
+		Expires:  expires,
+		Secure:   h.secureCookies,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+	"Fix-Go-Fiber-Backend/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PasswordHandler struct {
+	passwordService service.PasswordService
+	validator       *validator.CustomValidator
+}
+
+func NewPasswordHandler(passwordService service.PasswordService, validator *validator.CustomValidator) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+		validator:       validator,
+	}
+}
+
+// ForgotPassword mails a reset token if an account with the email exists
+func (h *PasswordHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	if err := h.passwordService.ForgotPassword(c.UserContext(), &req); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to process password reset request"))
+	}
+
+	// Same answer whether or not the email is registered
+	return c.JSON(utils.SuccessResponse("If the email is registered, a password reset token has been sent", nil))
+}
+
+// ResetPassword sets a new password using a reset token
+func (h *PasswordHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	if err := h.passwordService.ResetPassword(c.UserContext(), &req); err != nil {
+		if errors.Is(err, usecase.ErrInvalidResetToken) || errors.Is(err, usecase.ErrWeakPassword) {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to reset password"))
+	}
+
+	return c.JSON(utils.SuccessResponse("Password has been reset, please log in again", nil))
+}
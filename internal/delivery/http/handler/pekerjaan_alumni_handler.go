@@ -4,6 +4,8 @@ import (
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"errors"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
@@ -41,26 +43,11 @@ func (h *PekerjaanAlumniHandler) CreatePekerjaan(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get user claims from JWT
+	// Alumni may only create pekerjaan for themselves, checked by the usecase
 	claims := c.Locals("user").(*service.JWTClaims)
-	
-	// If user is mahasiswa/alumni, they can only create pekerjaan for themselves
-	if claims.Role == "mahasiswa" {
-		if req.MahasiswaID != nil && *req.MahasiswaID != claims.UserID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Access denied: You can only create pekerjaan for yourself",
-			})
-		}
-		// If MahasiswaID is nil and NIM is provided, we need to validate the NIM belongs to this user
-		if req.MahasiswaID == nil && req.NIM != "" {
-			// This will be validated in the usecase layer
-		}
-	}
-
-	pekerjaan, err := h.pekerjaanService.CreatePekerjaan(c.Context(), &req)
+	pekerjaan, err := h.pekerjaanService.CreatePekerjaan(c.UserContext(), &req, claims)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(pekerjaanErrorCode(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to create pekerjaan",
 			"error":   err.Error(),
@@ -93,7 +80,7 @@ func (h *PekerjaanAlumniHandler) GetAllPekerjaan(c *fiber.Ctx) error {
 	offset := (page - 1) * limit
 	query := c.Query("search")
 
-	pekerjaan, total, err := h.pekerjaanService.GetAllPekerjaan(c.Context(), query, limit, offset)
+	pekerjaan, total, err := h.pekerjaanService.GetAllPekerjaan(c.UserContext(), query, limit, offset)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -131,22 +118,10 @@ func (h *PekerjaanAlumniHandler) GetPekerjaanByMahasiswaID(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get user claims from JWT
 	claims := c.Locals("user").(*service.JWTClaims)
-	
-	// If user is mahasiswa, check if they can only access their own pekerjaan
-	if claims.Role == "mahasiswa" {
-		if claims.UserID != uint(mahasiswaID) {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Access denied: You can only view your own pekerjaan",
-			})
-		}
-	}
-
-	pekerjaan, err := h.pekerjaanService.GetPekerjaanByMahasiswaID(c.Context(), uint(mahasiswaID))
+	pekerjaan, err := h.pekerjaanService.GetPekerjaanByMahasiswaID(c.UserContext(), uint(mahasiswaID), claims)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(pekerjaanErrorCode(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to get pekerjaan",
 			"error":   err.Error(),
@@ -176,28 +151,16 @@ func (h *PekerjaanAlumniHandler) GetPekerjaanByID(c *fiber.Ctx) error {
 		})
 	}
 
-	pekerjaan, err := h.pekerjaanService.GetPekerjaanByID(c.Context(), uint(id))
+	claims := c.Locals("user").(*service.JWTClaims)
+	pekerjaan, err := h.pekerjaanService.GetPekerjaanByID(c.UserContext(), uint(id), claims)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		return c.Status(pekerjaanErrorCode(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
 			"success": false,
-			"message": "Pekerjaan not found",
+			"message": "Failed to get pekerjaan",
 			"error":   err.Error(),
 		})
 	}
 
-	// Get user claims from JWT
-	claims := c.Locals("user").(*service.JWTClaims)
-	
-	// If user is alumni, check if they can only access their own pekerjaan
-	if claims.Role == "alumni" {
-		if claims.UserID != pekerjaan.MahasiswaID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Access denied: You can only view your own pekerjaan",
-			})
-		}
-	}
-
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Pekerjaan retrieved successfully",
@@ -233,32 +196,10 @@ func (h *PekerjaanAlumniHandler) UpdatePekerjaan(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check if pekerjaan exists and get owner info
-	existingPekerjaan, err := h.pekerjaanService.GetPekerjaanByID(c.Context(), uint(id))
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Pekerjaan not found",
-			"error":   err.Error(),
-		})
-	}
-
-	// Get user claims from JWT
 	claims := c.Locals("user").(*service.JWTClaims)
-	
-	// If user is alumni, check if they can only update their own pekerjaan
-	if claims.Role == "alumni" {
-		if claims.UserID != existingPekerjaan.MahasiswaID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Access denied: You can only update your own pekerjaan",
-			})
-		}
-	}
-
-	pekerjaan, err := h.pekerjaanService.UpdatePekerjaan(c.Context(), uint(id), &req)
+	pekerjaan, err := h.pekerjaanService.UpdatePekerjaan(c.UserContext(), uint(id), &req, claims)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(pekerjaanErrorCode(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to update pekerjaan",
 			"error":   err.Error(),
@@ -283,32 +224,10 @@ func (h *PekerjaanAlumniHandler) DeletePekerjaan(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check if pekerjaan exists and get owner info
-	existingPekerjaan, err := h.pekerjaanService.GetPekerjaanByID(c.Context(), uint(id))
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Pekerjaan not found",
-			"error":   err.Error(),
-		})
-	}
-
-	// Get user claims from JWT
 	claims := c.Locals("user").(*service.JWTClaims)
-	
-	// If user is alumni, check if they can only delete their own pekerjaan
-	if claims.Role == "alumni" {
-		if claims.UserID != existingPekerjaan.MahasiswaID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Access denied: You can only delete your own pekerjaan",
-			})
-		}
-	}
-
-	err = h.pekerjaanService.DeletePekerjaan(c.Context(), uint(id))
+	err = h.pekerjaanService.DeletePekerjaan(c.UserContext(), uint(id), claims)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(pekerjaanErrorCode(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to delete pekerjaan",
 			"error":   err.Error(),
@@ -319,4 +238,12 @@ func (h *PekerjaanAlumniHandler) DeletePekerjaan(c *fiber.Ctx) error {
 		"success": true,
 		"message": "Pekerjaan soft deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+// pekerjaanErrorCode is 404 for a missing pekerjaan, 403 for an error of the policy and code otherwise
+func pekerjaanErrorCode(err error, code int) int {
+	if errors.Is(err, usecase.ErrPekerjaanNotFound) {
+		return fiber.StatusNotFound
+	}
+	return accessErrorCode(err, code)
+}
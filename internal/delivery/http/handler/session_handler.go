@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionHandler struct {
+	sessionService service.SessionService
+	validator      *validator.Validate
+}
+
+func NewSessionHandler(sessionService service.SessionService, validator *validator.Validate) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		validator:      validator,
+	}
+}
+
+// GetMine handles GET /auth/sessions
+func (h *SessionHandler) GetMine(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*service.JWTClaims)
+	sessions, err := h.sessionService.ListSessions(c.UserContext(), claims)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data sesi berhasil diambil",
+		Data:    sessions,
+	})
+}
+
+// EndMine handles DELETE /auth/sessions/:id
+func (h *SessionHandler) EndMine(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	claims := c.Locals("user").(*service.JWTClaims)
+	if err := h.sessionService.EndSession(c.UserContext(), uint(id), claims); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Sesi berhasil diakhiri",
+	})
+}
+
+// GetAll handles GET /sessions
+func (h *SessionHandler) GetAll(c *fiber.Ctx) error {
+	var query dto.SessionQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.validator.Struct(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Data:    err.Error(),
+		})
+	}
+
+	sessions, err := h.sessionService.ListUserSessions(c.UserContext(), query.UserType, query.UserID)
+	if err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Data sesi berhasil diambil",
+		Data:    sessions,
+	})
+}
+
+// End handles DELETE /sessions/:id
+func (h *SessionHandler) End(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid ID",
+		})
+	}
+
+	if err := h.sessionService.EndUserSession(c.UserContext(), uint(id)); err != nil {
+		return h.fail(c, err)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Sesi berhasil diakhiri",
+	})
+}
+
+func (h *SessionHandler) fail(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrSessionNotFound):
+		code = fiber.StatusNotFound
+	case errors.Is(err, policy.ErrForbidden):
+		code = fiber.StatusForbidden
+	}
+	return c.Status(code).JSON(dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
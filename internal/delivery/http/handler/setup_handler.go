@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+	"Fix-Go-Fiber-Backend/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetupHandler struct {
+	bootstrapService service.BootstrapService
+	validator        *validator.CustomValidator
+}
+
+func NewSetupHandler(bootstrapService service.BootstrapService, validator *validator.CustomValidator) *SetupHandler {
+	return &SetupHandler{
+		bootstrapService: bootstrapService,
+		validator:        validator,
+	}
+}
+
+// SetupAdmin creates the first super admin with the setup token printed at startup
+func (h *SetupHandler) SetupAdmin(c *fiber.Ctx) error {
+	var req dto.SetupAdminRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ValidationErrorResponse(err))
+	}
+
+	admin, err := h.bootstrapService.SetupAdmin(c.UserContext(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrSetupUnavailable):
+			return c.Status(fiber.StatusConflict).JSON(utils.ErrorResponse(err.Error()))
+		case errors.Is(err, usecase.ErrInvalidSetupToken):
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse(err.Error()))
+		case errors.Is(err, usecase.ErrDefaultAdminPassword), errors.Is(err, usecase.ErrWeakPassword):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to create admin"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.SuccessResponse("Super admin created, please log in", admin.ToResponse()))
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyHeader carries the API key of an integration
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyValidator validates an API key and returns claims granting its scopes
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*service.JWTClaims, error)
+}
+
+// APIKeyOrPermission creates a middleware that accepts an API key whose scopes include all
+// of the permissions, and otherwise a token as RequirePermission does
+func APIKeyOrPermission(apiKeyValidator APIKeyValidator, tokenValidator TokenValidator, permissions ...entity.Permission) fiber.Handler {
+	requireToken := RequirePermission(tokenValidator, permissions...)
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(APIKeyHeader)
+		if key == "" {
+			return requireToken(c)
+		}
+
+		claims, err := apiKeyValidator.ValidateAPIKey(c.UserContext(), key)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse("Invalid or expired API key"))
+		}
+
+		for _, permission := range permissions {
+			if !claims.HasPermission(permission) {
+				return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse("Insufficient API key scope"))
+			}
+		}
+
+		storeClaims(c, claims)
+		return c.Next()
+	}
+}
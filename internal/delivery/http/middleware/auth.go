@@ -1,35 +1,51 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
-	"Fix-Go-Fiber-Backend/pkg/jwt"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
 	"Fix-Go-Fiber-Backend/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-// RoleBasedAuth creates a middleware that checks for specific roles
-func RoleBasedAuth(jwtUtil *jwt.JWTUtil, allowedRoles ...string) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		// Extract token from Authorization header
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse("Authorization header required"))
-		}
+// TokenRefreshHeader is set on responses to a token whose role is out of date, see
+// service.JWTClaims.RoleChanged. The request was handled with the current role.
+const TokenRefreshHeader = "X-Token-Refresh-Required"
 
-		// Check if header starts with Bearer
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse("Invalid authorization format"))
-		}
+// TokenValidator validates a bearer token. A SignatureValidator only checks signature and expiry;
+// the auth service also rejects revoked tokens, ended sessions and disabled accounts, and
+// records the last activity of the token's session.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*service.JWTClaims, error)
+}
+
+// Restriction limits what a token may be used for, see service.JWTClaims. Every middleware
+// refuses restricted tokens except AllowRestricted, whose routes form the allowlist.
+type Restriction uint8
+
+const (
+	// RestrictionPasswordChange is carried by tokens of admins that must change their password
+	RestrictionPasswordChange Restriction = 1 << iota
+	// RestrictionMFAEnrollment is carried by tokens of admins whose role requires MFA before they enrolled
+	RestrictionMFAEnrollment
+)
+
+// RoleBasedAuth creates a middleware that checks for specific roles
+func RoleBasedAuth(tokenValidator TokenValidator, allowedRoles ...string) fiber.Handler {
+	return AllowRestricted(tokenValidator, 0, allowedRoles...)
+}
 
-		token := tokenParts[1]
-		
-		// Validate token
-		claims, err := jwtUtil.ValidateToken(token)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorResponse("Invalid or expired token"))
+// AllowRestricted creates a middleware like RoleBasedAuth that also accepts tokens whose
+// restrictions are all in allowed. It is meant for the few routes that lift a restriction or
+// end the session: the own password change, MFA enrollment and confirmation, and logout.
+func AllowRestricted(tokenValidator TokenValidator, allowed Restriction, allowedRoles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, authErr := authenticate(c, tokenValidator, allowed)
+		if authErr != nil {
+			return c.Status(authErr.Code).JSON(utils.ErrorResponse(authErr.Message))
 		}
 
 		// Check if user role is allowed
@@ -45,43 +61,120 @@ func RoleBasedAuth(jwtUtil *jwt.JWTUtil, allowedRoles ...string) fiber.Handler {
 			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse("Insufficient permissions"))
 		}
 
-		// Store user info in context
-		c.Locals("user_id", claims.UserID)
-		c.Locals("email", claims.Email)
-		c.Locals("role", claims.Role)
-		c.Locals("username", claims.Username)
-		c.Locals("user", claims) // Store complete claims as "user"
-		c.Locals("claims", claims)
+		storeClaims(c, claims)
+		return c.Next()
+	}
+}
 
+// RequirePermission creates a middleware that requires a valid token granting all of the permissions
+func RequirePermission(tokenValidator TokenValidator, permissions ...entity.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, authErr := authenticate(c, tokenValidator, 0)
+		if authErr != nil {
+			return c.Status(authErr.Code).JSON(utils.ErrorResponse(authErr.Message))
+		}
+
+		for _, permission := range permissions {
+			if !claims.HasPermission(permission) {
+				return c.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse("Insufficient permissions"))
+			}
+		}
+
+		storeClaims(c, claims)
 		return c.Next()
 	}
 }
 
+// authenticate validates the bearer token of the request and refuses tokens with a
+// restriction not in allowed. On failure it returns the status and message to answer with.
+func authenticate(c *fiber.Ctx, tokenValidator TokenValidator, allowed Restriction) (*service.JWTClaims, *fiber.Error) {
+	// Extract token from Authorization header
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Authorization header required")
+	}
+
+	// Check if header starts with Bearer
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid authorization format")
+	}
+
+	// Validate token
+	claims, err := tokenValidator.ValidateToken(c.UserContext(), tokenParts[1])
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired token")
+	}
+
+	// Also sent when the current role is refused, so the client knows to get a new token
+	if claims.RoleChanged {
+		c.Set(TokenRefreshHeader, "true")
+	}
+
+	// A forced password change comes first, so a token with both restrictions is limited to it
+	switch {
+	case claims.PasswordChangeRequired:
+		if allowed&RestrictionPasswordChange == 0 {
+			return nil, fiber.NewError(fiber.StatusForbidden, "Password change required")
+		}
+	case claims.MFAEnrollmentRequired:
+		if allowed&RestrictionMFAEnrollment == 0 {
+			return nil, fiber.NewError(fiber.StatusForbidden, "MFA enrollment required")
+		}
+	}
+
+	return claims, nil
+}
+
+// storeClaims stores user info in context
+func storeClaims(c *fiber.Ctx, claims *service.JWTClaims) {
+	c.Locals("user_id", claims.UserID)
+	c.Locals("email", claims.Email)
+	c.Locals("role", claims.Role)
+	c.Locals("username", claims.Username)
+	c.Locals("user", claims) // Store complete claims as "user"
+	c.Locals("claims", claims)
+}
+
 // RequireAuth creates a middleware that just requires valid authentication
-func RequireAuth(jwtUtil *jwt.JWTUtil) fiber.Handler {
-	return RoleBasedAuth(jwtUtil, "mahasiswa", "alumni", "admin")
+func RequireAuth(tokenValidator TokenValidator) fiber.Handler {
+	return RoleBasedAuth(tokenValidator, "mahasiswa", "alumni", "admin")
 }
 
 // AdminOnly creates a middleware that only allows admin access
-func AdminOnly(jwtUtil *jwt.JWTUtil) fiber.Handler {
-	return RoleBasedAuth(jwtUtil, "admin")
+func AdminOnly(tokenValidator TokenValidator) fiber.Handler {
+	return RoleBasedAuth(tokenValidator, "admin")
 }
 
 // AlumniOrAdmin creates a middleware that allows alumni or admin access
-func AlumniOrAdmin(jwtUtil *jwt.JWTUtil) fiber.Handler {
-	return RoleBasedAuth(jwtUtil, "alumni", "admin")
+func AlumniOrAdmin(tokenValidator TokenValidator) fiber.Handler {
+	return RoleBasedAuth(tokenValidator, "alumni", "admin")
 }
 
-// GetUserFromContext extracts user claims from fiber context
-func GetUserFromContext(c *fiber.Ctx) *jwt.Claims {
-	claims, ok := c.Locals("claims").(*jwt.Claims)
+// GetUserFromContext returns the claims an auth middleware stored, nil on unauthenticated requests
+func GetUserFromContext(c *fiber.Ctx) *service.JWTClaims {
+	claims, ok := c.Locals("claims").(*service.JWTClaims)
 	if !ok {
 		return nil
 	}
 	return claims
 }
 
+// SignatureValidator only checks the signature and expiry of a token, like *usecase.TokenSigner
+type SignatureValidator interface {
+	ValidateToken(token string) (*service.JWTClaims, error)
+}
+
 // Legacy JWT middleware for backward compatibility
-func JWTMiddleware(jwtManager *jwt.JWTUtil) fiber.Handler {
-	return RequireAuth(jwtManager)
+func JWTMiddleware(jwtManager SignatureValidator) fiber.Handler {
+	return RequireAuth(jwtTokenValidator{jwtManager})
+}
+
+// jwtTokenValidator only checks the signature and expiry of a token
+type jwtTokenValidator struct {
+	jwtUtil SignatureValidator
+}
+
+func (v jwtTokenValidator) ValidateToken(_ context.Context, token string) (*service.JWTClaims, error) {
+	return v.jwtUtil.ValidateToken(token)
 }
\ No newline at end of file
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeTokenValidator maps bearer tokens to claims
+type fakeTokenValidator map[string]*service.JWTClaims
+
+func (v fakeTokenValidator) ValidateToken(_ context.Context, token string) (*service.JWTClaims, error) {
+	claims, ok := v[token]
+	if !ok {
+		return nil, errors.New("unknown token")
+	}
+	return claims, nil
+}
+
+func TestRestrictedTokens(t *testing.T) {
+	admin := func(passwordChange, mfaEnrollment bool) *service.JWTClaims {
+		return &service.JWTClaims{
+			UserID:                 1,
+			Role:                   "admin",
+			AdminRole:              string(entity.AdminRoleSuperAdmin),
+			Permissions:            entity.AdminRoleSuperAdmin.Permissions(),
+			PasswordChangeRequired: passwordChange,
+			MFAEnrollmentRequired:  mfaEnrollment,
+		}
+	}
+	tokens := fakeTokenValidator{
+		"full":     admin(false, false),
+		"password": admin(true, false),
+		"mfa":      admin(false, true),
+		"both":     admin(true, true),
+	}
+
+	middlewares := map[string]fiber.Handler{
+		"RoleBasedAuth":     RoleBasedAuth(tokens, "admin"),
+		"RequireAuth":       RequireAuth(tokens),
+		"AdminOnly":         AdminOnly(tokens),
+		"AlumniOrAdmin":     AlumniOrAdmin(tokens),
+		"RequirePermission": RequirePermission(tokens, entity.PermissionAdminManage),
+		"AllowPassword":     AllowRestricted(tokens, RestrictionPasswordChange, "admin"),
+		"AllowMFA":          AllowRestricted(tokens, RestrictionMFAEnrollment, "admin"),
+		"AllowBoth":         AllowRestricted(tokens, RestrictionPasswordChange|RestrictionMFAEnrollment, "admin"),
+	}
+
+	tests := []struct {
+		middleware string
+		token      string
+		want       int
+	}{
+		{"RoleBasedAuth", "full", fiber.StatusOK},
+		{"RoleBasedAuth", "password", fiber.StatusForbidden},
+		{"RoleBasedAuth", "mfa", fiber.StatusForbidden},
+		{"RequireAuth", "full", fiber.StatusOK},
+		{"RequireAuth", "password", fiber.StatusForbidden},
+		{"RequireAuth", "mfa", fiber.StatusForbidden},
+		{"AdminOnly", "full", fiber.StatusOK},
+		{"AdminOnly", "password", fiber.StatusForbidden},
+		{"AdminOnly", "mfa", fiber.StatusForbidden},
+		{"AlumniOrAdmin", "mfa", fiber.StatusForbidden},
+		{"RequirePermission", "full", fiber.StatusOK},
+		{"RequirePermission", "password", fiber.StatusForbidden},
+		{"RequirePermission", "mfa", fiber.StatusForbidden},
+		{"AllowPassword", "full", fiber.StatusOK},
+		{"AllowPassword", "password", fiber.StatusOK},
+		{"AllowPassword", "mfa", fiber.StatusForbidden},
+		{"AllowPassword", "both", fiber.StatusOK},
+		{"AllowMFA", "full", fiber.StatusOK},
+		{"AllowMFA", "password", fiber.StatusForbidden},
+		{"AllowMFA", "mfa", fiber.StatusOK},
+		{"AllowMFA", "both", fiber.StatusForbidden},
+		{"AllowBoth", "both", fiber.StatusOK},
+		{"AllowBoth", "unknown", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		app := fiber.New()
+		app.Get("/", middlewares[tt.middleware], func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s with token %q = %d, want %d", tt.middleware, tt.token, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestGetUserFromContext(t *testing.T) {
+	claims := &service.JWTClaims{UserID: 7, Role: "mahasiswa"}
+	tokens := fakeTokenValidator{"valid": claims}
+
+	var got *service.JWTClaims
+	app := fiber.New()
+	app.Get("/anonymous", func(c *fiber.Ctx) error {
+		if user := GetUserFromContext(c); user != nil {
+			t.Errorf("GetUserFromContext() on an unauthenticated request = %+v, want nil", user)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/", RequireAuth(tokens), func(c *fiber.Ctx) error {
+		got = GetUserFromContext(c)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for _, path := range []string{"/anonymous", "/"} {
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer valid")
+		if _, err := app.Test(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got != claims {
+		t.Errorf("GetUserFromContext() = %+v, want the stored claims %+v", got, claims)
+	}
+}
@@ -12,5 +12,7 @@ func NewCORSMiddleware(cfg *config.Config) fiber.Handler {
 		AllowMethods:     cfg.CORS.AllowedMethods,
 		AllowHeaders:     cfg.CORS.AllowedHeaders,
 		AllowCredentials: cfg.CORS.AllowCredentials,
+		// Let browser clients see when to get a new token
+		ExposeHeaders: TokenRefreshHeader,
 	})
 }
\ No newline at end of file
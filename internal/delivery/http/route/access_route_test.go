@@ -0,0 +1,251 @@
+package route_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/route"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+	"Fix-Go-Fiber-Backend/pkg/mailer"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Records every access test starts with: two active mahasiswa, an alumni with one pekerjaan
+// and the super admin who issued the API key
+const (
+	ownerID  = 1 // active mahasiswa, the target of the /mahasiswa/:id routes
+	otherID  = 2 // another active mahasiswa
+	alumniID = 3 // alumni owning pekerjaan 1, the target of the /pekerjaan routes
+	adminID  = 1 // super admin, also the id of the other admin callers
+)
+
+// accessApp is the mahasiswa and pekerjaan API on a fresh database
+type accessApp struct {
+	app    *fiber.App
+	apiKey string
+}
+
+func newAccessApp(t *testing.T) *accessApp {
+	t.Helper()
+	ctx := context.Background()
+	db := dbtest.New(t)
+
+	mahasiswaRepo := repository.NewMahasiswaRepository(db)
+	adminRepo := repository.NewAdminUserRepository(db)
+	pekerjaanRepo := repository.NewPekerjaanAlumniRepository(db)
+	uow := repository.NewUnitOfWork(db)
+
+	tahunLulus := 2024
+	for _, m := range []*entity.Mahasiswa{
+		{NIM: "2201001", Nama: "Owner", Email: "owner@example.com", Status: entity.StatusMahasiswaActive},
+		{NIM: "2201002", Nama: "Other", Email: "other@example.com", Status: entity.StatusMahasiswaActive},
+		{NIM: "2001003", Nama: "Alumni", Email: "alumni@example.com", Status: entity.StatusMahasiswaGraduated, TahunLulus: &tahunLulus},
+	} {
+		m.Jurusan, m.Angkatan, m.Password = "Teknik Informatika", 2022, "hash"
+		if err := mahasiswaRepo.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := pekerjaanRepo.Create(ctx, &entity.PekerjaanAlumni{
+		MahasiswaID:  alumniID,
+		NamaCompany:  "PT Contoh",
+		Posisi:       "Engineer",
+		TanggalMulai: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		Status:       entity.StatusAktif,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := &entity.AdminUser{Username: "root", Email: "root@example.com", Password: "hash", Role: entity.AdminRoleSuperAdmin, IsActive: true}
+	if err := adminRepo.Create(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+	apiKeyService := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db), adminRepo)
+	scopes := make([]string, 0, len(entity.APIKeyScopes))
+	for _, scope := range entity.APIKeyScopes {
+		scopes = append(scopes, string(scope))
+	}
+	key, err := apiKeyService.Create(ctx, &dto.CreateAPIKeyRequest{Name: "integration", Scopes: scopes}, callerClaims("super_admin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bcryptUtil := bcrypt.NewBcryptUtil(4)
+	passwords := usecase.NewPasswordPolicyUsecase(repository.NewPasswordHistoryRepository(db), bcryptUtil, usecase.PasswordPolicy{MinLength: 8})
+	emails := usecase.NewEmailService(mailer.NewFileMailer(filepath.Join(t.TempDir(), "mail.txt"), "noreply@example.com"), "http://localhost")
+	verification := usecase.NewEmailVerificationUsecase(mahasiswaRepo, repository.NewEmailVerificationTokenRepository(db), uow, emails, time.Hour, time.Minute)
+	mahasiswaUsecase := usecase.NewMahasiswaUsecase(mahasiswaRepo, bcrypt.NewBcryptHelper(4), passwords, verification)
+	lifecycle := usecase.NewMahasiswaLifecycleUsecase(mahasiswaRepo, repository.NewMahasiswaStatusHistoryRepository(db), uow)
+	pekerjaanService := usecase.NewPekerjaanAlumniUsecase(pekerjaanRepo, mahasiswaRepo, uow)
+
+	tokens := fakeTokenValidator{}
+	for _, caller := range accessCallers {
+		if claims := callerClaims(caller); claims != nil {
+			tokens[caller] = claims
+		}
+	}
+
+	v := validator.New()
+	app := fiber.New()
+	api := app.Group("/api/v1")
+	route.SetupMahasiswaRoutes(api, handler.NewMahasiswaHandler(mahasiswaUsecase, v), handler.NewMahasiswaStatusHandler(lifecycle, v), tokens, apiKeyService)
+	route.SetupPekerjaanAlumniRoutes(api, handler.NewPekerjaanAlumniHandler(pekerjaanService, v), tokens, apiKeyService)
+
+	return &accessApp{app: app, apiKey: key.Key}
+}
+
+// accessCallers are the callers of the access matrix; each is also the bearer token or marker it sends
+var accessCallers = []string{"anonymous", "mahasiswa-owner", "mahasiswa-other", "alumni", "moderator", "admin", "super_admin", "api_key"}
+
+// callerClaims returns the token claims of a caller, nil for anonymous and api_key
+func callerClaims(caller string) *service.JWTClaims {
+	admin := func(role entity.AdminRole) *service.JWTClaims {
+		return &service.JWTClaims{UserID: adminID, Role: "admin", AdminRole: string(role), Permissions: role.Permissions()}
+	}
+
+	switch caller {
+	case "mahasiswa-owner":
+		return &service.JWTClaims{UserID: ownerID, Role: "mahasiswa", Permissions: entity.RolePermissions("mahasiswa")}
+	case "mahasiswa-other":
+		return &service.JWTClaims{UserID: otherID, Role: "mahasiswa", Permissions: entity.RolePermissions("mahasiswa")}
+	case "alumni":
+		return &service.JWTClaims{UserID: alumniID, Role: "alumni", Permissions: entity.RolePermissions("alumni")}
+	case "moderator":
+		return admin(entity.AdminRoleModerator)
+	case "admin":
+		return admin(entity.AdminRoleAdmin)
+	case "super_admin":
+		return admin(entity.AdminRoleSuperAdmin)
+	default:
+		return nil
+	}
+}
+
+// do sends a request as the caller and returns the status
+func (a *accessApp) do(t *testing.T, caller, method, path, body string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	switch caller {
+	case "anonymous":
+	case "api_key":
+		req.Header.Set(middleware.APIKeyHeader, a.apiKey)
+	default:
+		req.Header.Set("Authorization", "Bearer "+caller)
+	}
+
+	resp, err := a.app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		b, _ := io.ReadAll(resp.Body)
+		t.Errorf("%s %s as %s: %d %s", method, path, caller, resp.StatusCode, b)
+	}
+	return resp.StatusCode
+}
+
+func TestMahasiswaAndPekerjaanAccess(t *testing.T) {
+	const (
+		ok        = fiber.StatusOK
+		created   = fiber.StatusCreated
+		denied    = fiber.StatusForbidden
+		anonymous = fiber.StatusUnauthorized
+	)
+
+	newMahasiswa := `{"nim":"2201009","nama":"Baru","email":"baru@example.com","password":"Kopi-hangat7","jurusan":"Teknik Informatika","angkatan":2022}`
+	newPekerjaan := fmt.Sprintf(`{"mahasiswa_id":%d,"nama_company":"PT Lain","posisi":"Analyst","tanggal_mulai":"2025-01-02"}`, alumniID)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		// want is the status for each caller, in accessCallers order: anonymous,
+		// mahasiswa-owner, mahasiswa-other, alumni, moderator, admin, super_admin, api_key.
+		// Routes that take no API key answer an API key caller like an anonymous one.
+		want [8]int
+	}{
+		{fiber.MethodPost, "/api/v1/mahasiswa", newMahasiswa,
+			[8]int{anonymous, denied, denied, denied, denied, created, created, anonymous}},
+		{fiber.MethodGet, "/api/v1/mahasiswa", "",
+			[8]int{anonymous, denied, denied, denied, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/api/v1/mahasiswa/1", "",
+			[8]int{anonymous, ok, denied, denied, ok, ok, ok, ok}},
+		{fiber.MethodPut, "/api/v1/mahasiswa/1", `{"nama":"Owner Baru"}`,
+			[8]int{anonymous, ok, denied, denied, denied, ok, ok, anonymous}},
+		{fiber.MethodDelete, "/api/v1/mahasiswa/1", "",
+			[8]int{anonymous, denied, denied, denied, denied, ok, ok, anonymous}},
+		{fiber.MethodGet, "/api/v1/mahasiswa/1/status", "",
+			[8]int{anonymous, denied, denied, denied, ok, ok, ok, ok}},
+		{fiber.MethodPost, "/api/v1/mahasiswa/1/status", `{"transition":"suspend","reason":"cuti"}`,
+			[8]int{anonymous, denied, denied, denied, denied, ok, ok, anonymous}},
+
+		{fiber.MethodGet, "/api/v1/pekerjaan", "",
+			[8]int{anonymous, denied, denied, denied, ok, ok, ok, ok}},
+		{fiber.MethodPost, "/api/v1/pekerjaan", newPekerjaan,
+			[8]int{anonymous, denied, denied, created, denied, created, created, anonymous}},
+		{fiber.MethodGet, "/api/v1/pekerjaan/1", "",
+			[8]int{anonymous, denied, denied, ok, ok, ok, ok, ok}},
+		{fiber.MethodPut, "/api/v1/pekerjaan/1", `{"posisi":"Senior Engineer"}`,
+			[8]int{anonymous, denied, denied, ok, ok, ok, ok, anonymous}},
+		{fiber.MethodDelete, "/api/v1/pekerjaan/1", "",
+			[8]int{anonymous, denied, denied, ok, ok, ok, ok, anonymous}},
+		{fiber.MethodGet, "/api/v1/pekerjaan/mahasiswa/3", "",
+			[8]int{anonymous, denied, denied, ok, ok, ok, ok, ok}},
+	}
+
+	for _, tt := range tests {
+		for i, caller := range accessCallers {
+			// Every request starts from the same records, so writes do not leak into later rows
+			app := newAccessApp(t)
+			if got := app.do(t, caller, tt.method, tt.path, tt.body); got != tt.want[i] {
+				t.Errorf("%s %s as %s = %d, want %d", tt.method, tt.path, caller, got, tt.want[i])
+			}
+		}
+	}
+}
+
+// TestCreatePekerjaanByNIMHidesUnknownNIMs checks that a caller who may not create pekerjaan
+// for a NIM learns nothing about whether it exists
+func TestCreatePekerjaanByNIMHidesUnknownNIMs(t *testing.T) {
+	body := func(nim string) string {
+		return fmt.Sprintf(`{"nim":%q,"nama_company":"PT Lain","posisi":"Analyst","tanggal_mulai":"2025-01-02"}`, nim)
+	}
+
+	tests := []struct {
+		caller string
+		nim    string
+		want   int
+	}{
+		{"alumni", "2001003", fiber.StatusCreated},
+		{"alumni", "2201001", fiber.StatusForbidden},
+		{"alumni", "9999999", fiber.StatusForbidden},
+		{"admin", "2001003", fiber.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		app := newAccessApp(t)
+		if got := app.do(t, tt.caller, fiber.MethodPost, "/api/v1/pekerjaan", body(tt.nim)); got != tt.want {
+			t.Errorf("create pekerjaan for NIM %s as %s = %d, want %d", tt.nim, tt.caller, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupAdminUserRoutes(app fiber.Router, handler *handler.AdminUserHandler, mfaHandler *handler.MFAHandler, tokenValidator middleware.TokenValidator) {
+	admins := app.Group("/admins")
+
+	// Self-service routes for any admin. Changing the password and enrolling in MFA are also
+	// open to tokens limited to exactly that.
+	self := middleware.AdminOnly(tokenValidator)
+	passwordChange := middleware.AllowRestricted(tokenValidator, middleware.RestrictionPasswordChange, "admin")
+	mfaEnrollment := middleware.AllowRestricted(tokenValidator, middleware.RestrictionMFAEnrollment, "admin")
+	admins.Put("/me/password", passwordChange, handler.ChangeOwnPassword)
+	admins.Get("/me/mfa", self, mfaHandler.GetStatus)
+	admins.Post("/me/mfa/enroll", mfaEnrollment, mfaHandler.Enroll)
+	admins.Post("/me/mfa/confirm", mfaEnrollment, mfaHandler.Confirm)
+	admins.Post("/me/mfa/recovery-codes", self, mfaHandler.RegenerateRecoveryCodes)
+	admins.Post("/me/mfa/disable", self, mfaHandler.Disable)
+
+	// Super admin routes
+	manage := middleware.RequirePermission(tokenValidator, entity.PermissionAdminManage)
+	admins.Get("/", manage, handler.GetAll)
+	admins.Post("/", manage, handler.Create)
+	admins.Get("/:id", manage, handler.GetByID)
+	admins.Put("/:id", manage, handler.Update)
+	admins.Delete("/:id", manage, handler.Delete)
+	admins.Put("/:id/role", manage, handler.ChangeRole)
+	admins.Post("/:id/activate", manage, handler.Activate)
+	admins.Post("/:id/deactivate", manage, handler.Deactivate)
+	admins.Delete("/:id/mfa", manage, mfaHandler.Reset)
+}
@@ -0,0 +1,20 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupAPIKeyRoutes(app fiber.Router, handler *handler.APIKeyHandler, tokenValidator middleware.TokenValidator) {
+	apiKeys := app.Group("/api-keys", middleware.RequirePermission(tokenValidator, entity.PermissionAPIKeyManage))
+
+	// API keys of integrations, managed by super admins
+	apiKeys.Post("/", handler.Create)
+	apiKeys.Get("/", handler.GetAll)
+	apiKeys.Get("/:id", handler.GetByID)
+	apiKeys.Post("/:id/rotate", handler.Rotate)
+	apiKeys.Delete("/:id", handler.Revoke)
+}
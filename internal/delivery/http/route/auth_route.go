@@ -3,23 +3,50 @@ package route
 import (
 	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
 	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
-	"Fix-Go-Fiber-Backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupAuthRoutes(app fiber.Router, authHandler *handler.AuthHandler, jwtUtil *jwt.JWTUtil) {
+func SetupAuthRoutes(app fiber.Router, authHandler *handler.AuthHandler, passwordHandler *handler.PasswordHandler, verificationHandler *handler.EmailVerificationHandler, setupHandler *handler.SetupHandler, oidcHandler *handler.OIDCHandler, tokenValidator middleware.TokenValidator) {
 	auth := app.Group("/auth")
 
 	// Public auth routes - Registration
 	auth.Post("/mahasiswa/register", authHandler.RegisterMahasiswa)
-	auth.Post("/mahasiswa/graduate", authHandler.GraduateMahasiswa)
 
-	// Public auth routes - Login
+	// Public auth routes - First admin setup, only while the one-time setup token is pending
+	auth.Post("/setup", setupHandler.SetupAdmin)
+
+	// Public auth routes - Login. The role-specific routes are kept as aliases of /login
+	auth.Post("/login", authHandler.Login)
 	auth.Post("/mahasiswa/login", authHandler.LoginMahasiswa)
 	auth.Post("/alumni/login", authHandler.LoginAlumni)
 	auth.Post("/admin/login", authHandler.LoginAdmin)
+	auth.Post("/admin/mfa/verify", authHandler.VerifyAdminMFA)
+
+	// Public auth routes - SSO login, only when an OpenID Connect provider is configured
+	if oidcHandler != nil {
+		auth.Get("/oidc/login", oidcHandler.Login)
+		auth.Get("/oidc/callback", oidcHandler.Callback)
+	}
+
+	// Public auth routes - Token refresh
+	auth.Post("/refresh", authHandler.RefreshToken)
+
+	// Public auth routes - Password reset
+	auth.Post("/password/forgot", passwordHandler.ForgotPassword)
+	auth.Post("/password/reset", passwordHandler.ResetPassword)
+
+	// Public auth routes - Email verification
+	auth.Get("/verify-email", verificationHandler.VerifyEmail)
+	auth.Post("/verify-email/resend", verificationHandler.ResendVerification)
+
+	// Protected routes acting on the caller's own account need a valid token only
 
 	// Protected profile route
-	auth.Get("/profile", middleware.RequireAuth(jwtUtil), authHandler.GetProfile)
+	auth.Get("/profile", middleware.RequireAuth(tokenValidator), authHandler.GetProfile)
+
+	// Protected logout routes, also open to tokens limited to a password change or MFA enrollment
+	logout := middleware.AllowRestricted(tokenValidator, middleware.RestrictionPasswordChange|middleware.RestrictionMFAEnrollment, "mahasiswa", "alumni", "admin")
+	auth.Post("/logout", logout, authHandler.Logout)
+	auth.Post("/logout-all", logout, authHandler.LogoutAll)
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupGraduationRoutes(api fiber.Router, graduationHandler *handler.GraduationHandler, tokenValidator middleware.TokenValidator) {
+	graduation := api.Group("/graduation-requests")
+
+	// Mahasiswa submit a request; their own requests stay visible after graduating
+	graduation.Post("/", middleware.RequirePermission(tokenValidator, entity.PermissionGraduationSubmit), graduationHandler.Submit)
+	graduation.Get("/me", middleware.RoleBasedAuth(tokenValidator, "mahasiswa", "alumni"), graduationHandler.GetOwn)
+
+	// Admin review queue; only an approval graduates the mahasiswa
+	graduation.Get("/", middleware.RequirePermission(tokenValidator, entity.PermissionGraduationReview), graduationHandler.GetAll)
+	graduation.Get("/:id", middleware.RequirePermission(tokenValidator, entity.PermissionGraduationReview), graduationHandler.GetByID)
+	graduation.Post("/:id/approve", middleware.RequirePermission(tokenValidator, entity.PermissionGraduationReview), graduationHandler.Approve)
+	graduation.Post("/:id/reject", middleware.RequirePermission(tokenValidator, entity.PermissionGraduationReview), graduationHandler.Reject)
+}
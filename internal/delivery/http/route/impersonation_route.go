@@ -0,0 +1,17 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupImpersonationRoutes(app fiber.Router, handler *handler.ImpersonationHandler, tokenValidator middleware.TokenValidator) {
+	// Admins get a short-lived token acting as the mahasiswa or alumni
+	app.Post("/mahasiswa/:id/impersonate", middleware.RequirePermission(tokenValidator, entity.PermissionMahasiswaImpersonate), handler.Impersonate)
+
+	// Audit log of impersonations, for super admins
+	app.Get("/impersonations", middleware.RequirePermission(tokenValidator, entity.PermissionAdminManage), handler.GetLogs)
+}
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupLoginAttemptRoutes(app fiber.Router, handler *handler.LoginAttemptHandler, tokenValidator middleware.TokenValidator) {
+	manage := middleware.RequirePermission(tokenValidator, entity.PermissionAdminManage)
+
+	// Lockouts in force and the audit log of login attempts, for super admins
+	app.Get("/login-lockouts", manage, handler.GetLockouts)
+	app.Delete("/login-lockouts/:id", manage, handler.ClearLockout)
+	app.Get("/login-attempts", manage, handler.GetAttempts)
+}
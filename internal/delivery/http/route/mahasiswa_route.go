@@ -3,22 +3,26 @@ package route
 import (
 	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
 	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
-	"Fix-Go-Fiber-Backend/pkg/jwt"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupMahasiswaRoutes(app fiber.Router, handler *handler.MahasiswaHandler, jwtUtil *jwt.JWTUtil) {
+func SetupMahasiswaRoutes(app fiber.Router, handler *handler.MahasiswaHandler, statusHandler *handler.MahasiswaStatusHandler, tokenValidator middleware.TokenValidator, apiKeyValidator middleware.APIKeyValidator) {
 	mahasiswa := app.Group("/mahasiswa")
 
-	// Public routes
-	mahasiswa.Post("/", handler.Create)
+	// The read routes also accept an API key with the permission as scope
 
-	// Admin only routes
-	mahasiswa.Get("/", middleware.AdminOnly(jwtUtil), handler.GetAll)
-	mahasiswa.Delete("/:id", middleware.AdminOnly(jwtUtil), handler.Delete)
+	// Admin routes (self-registration goes through /auth/mahasiswa/register)
+	mahasiswa.Post("/", middleware.RequirePermission(tokenValidator, entity.PermissionMahasiswaCreate), handler.Create)
+	mahasiswa.Get("/", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionMahasiswaReadAll), handler.GetAll)
+	mahasiswa.Delete("/:id", middleware.RequirePermission(tokenValidator, entity.PermissionMahasiswaDelete), handler.Delete)
+
+	// Status lifecycle
+	mahasiswa.Post("/:id/status", middleware.RequirePermission(tokenValidator, entity.PermissionMahasiswaStatusChange), statusHandler.ChangeStatus)
+	mahasiswa.Get("/:id/status", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionMahasiswaStatusRead), statusHandler.GetStatusHistory)
 
 	// Admin or own record routes (mahasiswa can view/update their own record)
-	mahasiswa.Get("/:id", middleware.RoleBasedAuth(jwtUtil, "mahasiswa", "alumni", "admin"), handler.GetByID)
-	mahasiswa.Put("/:id", middleware.RoleBasedAuth(jwtUtil, "mahasiswa", "alumni", "admin"), handler.Update)
-}
\ No newline at end of file
+	mahasiswa.Get("/:id", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionMahasiswaRead), handler.GetByID)
+	mahasiswa.Put("/:id", middleware.RequirePermission(tokenValidator, entity.PermissionMahasiswaUpdate), handler.Update)
+}
@@ -3,7 +3,7 @@ package route
 import (
 	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
 	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
-	"Fix-Go-Fiber-Backend/pkg/jwt"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,20 +11,21 @@ import (
 func SetupPekerjaanAlumniRoutes(
 	api fiber.Router,
 	pekerjaanHandler *handler.PekerjaanAlumniHandler,
-	jwtUtil *jwt.JWTUtil,
+	tokenValidator middleware.TokenValidator,
+	apiKeyValidator middleware.APIKeyValidator,
 ) {
 	// Pekerjaan Alumni routes
 	pekerjaan := api.Group("/pekerjaan")
 	
-	// Admin only routes - Full CRUD access to all pekerjaan data
-	pekerjaan.Get("/", middleware.RequireAuth(jwtUtil), middleware.AdminOnly(jwtUtil), pekerjaanHandler.GetAllPekerjaan)
+	// Admin routes - Full access to all pekerjaan data. The read routes also accept an API key with the permission as scope
+	pekerjaan.Get("/", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionPekerjaanReadAll), pekerjaanHandler.GetAllPekerjaan)
 	
 	// Alumni and Admin routes - Alumni can manage their own pekerjaan, Admin can manage any
-	pekerjaan.Post("/", middleware.RequireAuth(jwtUtil), middleware.AlumniOrAdmin(jwtUtil), pekerjaanHandler.CreatePekerjaan)
-	pekerjaan.Get("/:id", middleware.RequireAuth(jwtUtil), middleware.AlumniOrAdmin(jwtUtil), pekerjaanHandler.GetPekerjaanByID)
-	pekerjaan.Put("/:id", middleware.RequireAuth(jwtUtil), middleware.AlumniOrAdmin(jwtUtil), pekerjaanHandler.UpdatePekerjaan)
-	pekerjaan.Delete("/:id", middleware.RequireAuth(jwtUtil), middleware.AlumniOrAdmin(jwtUtil), pekerjaanHandler.DeletePekerjaan)
+	pekerjaan.Post("/", middleware.RequirePermission(tokenValidator, entity.PermissionPekerjaanCreate), pekerjaanHandler.CreatePekerjaan)
+	pekerjaan.Get("/:id", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionPekerjaanRead), pekerjaanHandler.GetPekerjaanByID)
+	pekerjaan.Put("/:id", middleware.RequirePermission(tokenValidator, entity.PermissionPekerjaanUpdate), pekerjaanHandler.UpdatePekerjaan)
+	pekerjaan.Delete("/:id", middleware.RequirePermission(tokenValidator, entity.PermissionPekerjaanDelete), pekerjaanHandler.DeletePekerjaan)
 	
-	// Get pekerjaan by mahasiswa ID - Mahasiswa/Alumni can get their own, Admin can get any
-	pekerjaan.Get("/mahasiswa/:mahasiswa_id", middleware.RequireAuth(jwtUtil), middleware.AlumniOrAdmin(jwtUtil), pekerjaanHandler.GetPekerjaanByMahasiswaID)
+	// Get pekerjaan by mahasiswa ID - Alumni can get their own, Admin can get any
+	pekerjaan.Get("/mahasiswa/:mahasiswa_id", middleware.APIKeyOrPermission(apiKeyValidator, tokenValidator, entity.PermissionPekerjaanRead), pekerjaanHandler.GetPekerjaanByMahasiswaID)
 }
\ No newline at end of file
@@ -4,7 +4,6 @@ import (
 	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
 	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
 	"Fix-Go-Fiber-Backend/pkg/config"
-	"Fix-Go-Fiber-Backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
 	fiberMiddleware "github.com/gofiber/fiber/v2/middleware/logger"
@@ -15,9 +14,23 @@ func SetupRoutes(
 	app *fiber.App,
 	cfg *config.Config,
 	authHandler *handler.AuthHandler,
+	passwordHandler *handler.PasswordHandler,
+	verificationHandler *handler.EmailVerificationHandler,
+	setupHandler *handler.SetupHandler,
+	oidcHandler *handler.OIDCHandler,
 	mahasiswaHandler *handler.MahasiswaHandler,
+	mahasiswaStatusHandler *handler.MahasiswaStatusHandler,
+	graduationHandler *handler.GraduationHandler,
 	pekerjaanHandler *handler.PekerjaanAlumniHandler,
-	jwtUtil *jwt.JWTUtil,
+	adminUserHandler *handler.AdminUserHandler,
+	mfaHandler *handler.MFAHandler,
+	loginAttemptHandler *handler.LoginAttemptHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	impersonationHandler *handler.ImpersonationHandler,
+	sessionHandler *handler.SessionHandler,
+	jwksHandler *handler.JWKSHandler,
+	tokenValidator middleware.TokenValidator,
+	apiKeyValidator middleware.APIKeyValidator,
 ) {
 	// Global middleware
 	app.Use(recover.New())
@@ -32,13 +45,22 @@ func SetupRoutes(
 		})
 	})
 
+	// Public keys for verifying access tokens signed with RS256 or EdDSA
+	app.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// API routes
 	api := app.Group("/api/v1")
 	
 	// Auth routes (public)
-	SetupAuthRoutes(api, authHandler, jwtUtil)
+	SetupAuthRoutes(api, authHandler, passwordHandler, verificationHandler, setupHandler, oidcHandler, tokenValidator)
 	
 	// Protected routes
-	SetupMahasiswaRoutes(api, mahasiswaHandler, jwtUtil)
-	SetupPekerjaanAlumniRoutes(api, pekerjaanHandler, jwtUtil)
+	SetupMahasiswaRoutes(api, mahasiswaHandler, mahasiswaStatusHandler, tokenValidator, apiKeyValidator)
+	SetupGraduationRoutes(api, graduationHandler, tokenValidator)
+	SetupPekerjaanAlumniRoutes(api, pekerjaanHandler, tokenValidator, apiKeyValidator)
+	SetupAdminUserRoutes(api, adminUserHandler, mfaHandler, tokenValidator)
+	SetupLoginAttemptRoutes(api, loginAttemptHandler, tokenValidator)
+	SetupAPIKeyRoutes(api, apiKeyHandler, tokenValidator)
+	SetupImpersonationRoutes(api, impersonationHandler, tokenValidator)
+	SetupSessionRoutes(api, sessionHandler, tokenValidator)
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package route
+
+import (
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/middleware"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupSessionRoutes(app fiber.Router, handler *handler.SessionHandler, tokenValidator middleware.TokenValidator) {
+	// The caller's own sessions need a valid token only
+	auth := middleware.RequireAuth(tokenValidator)
+	app.Get("/auth/sessions", auth, handler.GetMine)
+	app.Delete("/auth/sessions/:id", auth, handler.EndMine)
+
+	// Sessions of any user, for super admins
+	manage := middleware.RequirePermission(tokenValidator, entity.PermissionAdminManage)
+	app.Get("/sessions", manage, handler.GetAll)
+	app.Delete("/sessions/:id", manage, handler.End)
+}
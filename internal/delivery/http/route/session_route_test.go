@@ -0,0 +1,94 @@
+package route_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/delivery/http/handler"
+	"Fix-Go-Fiber-Backend/internal/delivery/http/route"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeTokenValidator maps bearer tokens to claims
+type fakeTokenValidator map[string]*service.JWTClaims
+
+func (v fakeTokenValidator) ValidateToken(_ context.Context, token string) (*service.JWTClaims, error) {
+	claims, ok := v[token]
+	if !ok {
+		return nil, errors.New("unknown token")
+	}
+	return claims, nil
+}
+
+// fakeSessionService has no sessions and ends none
+type fakeSessionService struct{}
+
+func (fakeSessionService) ListSessions(context.Context, *service.JWTClaims) ([]*entity.Session, error) {
+	return []*entity.Session{}, nil
+}
+
+func (fakeSessionService) EndSession(context.Context, uint, *service.JWTClaims) error {
+	return nil
+}
+
+func (fakeSessionService) ListUserSessions(context.Context, string, uint) ([]*entity.Session, error) {
+	return []*entity.Session{}, nil
+}
+
+func (fakeSessionService) EndUserSession(context.Context, uint) error {
+	return nil
+}
+
+func (fakeSessionService) RunCleanup(context.Context, time.Duration) {}
+
+func TestOwnSessionsRefuseRestrictedTokens(t *testing.T) {
+	tokens := fakeTokenValidator{
+		"mahasiswa": {UserID: 1, Role: "mahasiswa"},
+		"admin":     {UserID: 1, Role: "admin", AdminRole: "super_admin"},
+		"password":  {UserID: 2, Role: "admin", AdminRole: "admin", PasswordChangeRequired: true},
+		"mfa":       {UserID: 3, Role: "admin", AdminRole: "admin", MFAEnrollmentRequired: true},
+		"both":      {UserID: 4, Role: "admin", AdminRole: "admin", PasswordChangeRequired: true, MFAEnrollmentRequired: true},
+	}
+
+	app := fiber.New()
+	route.SetupSessionRoutes(app, handler.NewSessionHandler(fakeSessionService{}, validator.New()), tokens)
+
+	tests := []struct {
+		token string
+		want  int
+	}{
+		{"", fiber.StatusUnauthorized},
+		{"unknown", fiber.StatusUnauthorized},
+		{"mahasiswa", fiber.StatusOK},
+		{"admin", fiber.StatusOK},
+		{"password", fiber.StatusForbidden},
+		{"mfa", fiber.StatusForbidden},
+		{"both", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(fiber.MethodGet, "/auth/sessions", nil),
+			httptest.NewRequest(fiber.MethodDelete, "/auth/sessions/1", nil),
+		} {
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("%s %s with token %q = %d, want %d", req.Method, req.URL.Path, tt.token, resp.StatusCode, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package dto
+
+// Create a new admin account
+type CreateAdminRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	Password string `json:"password" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=super_admin admin moderator"`
+}
+
+// Update admin account details
+type UpdateAdminRequest struct {
+	Username string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	Email    string `json:"email,omitempty" validate:"omitempty,email,max=100"`
+}
+
+// Change the role of an admin
+type ChangeAdminRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=super_admin admin moderator"`
+}
+
+// Change the password of the logged in account
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// Credentials of the first admin, given through the environment or command line
+type InitialAdmin struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// Create the first super admin with the setup token printed at startup
+type SetupAdminRequest struct {
+	SetupToken string `json:"setup_token" validate:"required"`
+	Username   string `json:"username" validate:"required,min=3,max=50"`
+	Email      string `json:"email" validate:"required,email,max=100"`
+	Password   string `json:"password" validate:"required"`
+}
+
+// MFA state of the logged in admin
+type MFAStatusResponse struct {
+	Enabled                bool  `json:"enabled"`
+	Required               bool  `json:"required"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// A new TOTP secret, to be added to an authenticator app and confirmed with a code
+type MFAEnrollResponse struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to show as a QR code
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// A code from the authenticator app, or a recovery code where accepted
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+
+	// IPAddress is the client address, set by the handler
+	IPAddress string `json:"-"`
+}
+
+// Turn off MFA for the logged in admin
+type DisableMFARequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"`
+
+	// IPAddress is the client address, set by the handler
+	IPAddress string `json:"-"`
+}
+
+// Single-use recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Act as a mahasiswa or alumni to see what they see, e.g. for a support ticket
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+
+	// IPAddress and UserAgent describe the admin's client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// A short-lived token acting as the mahasiswa. It has no refresh token.
+type ImpersonationResponse struct {
+	Token     string      `json:"token"`
+	User      interface{} `json:"user"`
+	Role      string      `json:"role"`
+	ExpiresAt int64       `json:"expires_at"`
+}
+
+// Filter and paginate the impersonation audit log
+type ImpersonationLogQuery struct {
+	Page        int  `query:"page" validate:"omitempty,min=1"`
+	Limit       int  `query:"limit" validate:"omitempty,min=1,max=100"`
+	AdminID     uint `query:"admin_id"`
+	MahasiswaID uint `query:"mahasiswa_id"`
+}
+
+// Pagination returns the paging part of the query
+func (q *ImpersonationLogQuery) Pagination() *PaginationQuery {
+	return &PaginationQuery{Page: q.Page, Limit: q.Limit}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// Create an API key for an integration
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+	// ExpiresAt is optional; keys without it stay valid until revoked
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// An API key with its secret, shown only when it is created or rotated
+type APIKeySecretResponse struct {
+	*entity.APIKey
+	Key string `json:"key"`
+}
@@ -1,8 +1,20 @@
 package dto
 
+import "time"
+
+// LoginRequest is the body of POST /auth/login. Identifier is a mahasiswa email or NIM,
+// or an admin username or email.
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Identifier string `json:"identifier" validate:"required,max=255"`
+	Password   string `json:"password" validate:"required"`
+	DeviceID   string `json:"device_id" validate:"omitempty,max=100"`
+
+	// DeviceName labels the session the login starts, e.g. "Chrome on Windows"
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type LoginResponse struct {
@@ -10,19 +22,179 @@ type LoginResponse struct {
 	User      interface{} `json:"user"`
 	Role      string      `json:"role"`
 	ExpiresAt int64       `json:"expires_at"`
+
+	// Refresh token for POST /auth/refresh, bound to DeviceID
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
+	DeviceID         string `json:"device_id"`
+
+	// PasswordChangeRequired is set when the token only allows changing the password
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+	// MFAEnrollmentRequired is set when the token only allows enrolling in MFA
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+
+	// MFAChallenge replaces the tokens when an admin still has to enter a TOTP code
+	MFAChallenge *MFAChallengeResponse `json:"mfa_challenge,omitempty"`
+}
+
+// MFAChallengeResponse is the second step of an admin login, completed with POST /auth/admin/mfa/verify
+type MFAChallengeResponse struct {
+	MFAToken  string `json:"mfa_token"`
+	ExpiresAt int64  `json:"expires_at"`
 }
 
 type MahasiswaLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
+	DeviceID string `json:"device_id" validate:"omitempty,max=100"`
+
+	// DeviceName labels the session the login starts, e.g. "Chrome on Windows"
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type AlumniLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
+	DeviceID string `json:"device_id" validate:"omitempty,max=100"`
+
+	// DeviceName labels the session the login starts, e.g. "Chrome on Windows"
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type AdminLoginRequest struct {
 	Username string `json:"username" validate:"required"`
-	Password string `json:"password" validate:"required,min=6"`
-}
\ No newline at end of file
+	Password string `json:"password" validate:"required"`
+	DeviceID string `json:"device_id" validate:"omitempty,max=100"`
+
+	// DeviceName labels the session the login starts, e.g. "Chrome on Windows"
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// Complete an admin login with a TOTP or recovery code
+type AdminMFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"`
+	DeviceID string `json:"device_id" validate:"omitempty,max=100"`
+
+	// DeviceName labels the session the login starts, e.g. "Chrome on Windows"
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+
+	// IPAddress and UserAgent describe the client, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// ClientInfo describes the client a login comes from, recorded on the session it starts
+type ClientInfo struct {
+	DeviceID   string
+	DeviceName string
+	IPAddress  string
+	UserAgent  string
+}
+
+// Client returns the client the login comes from
+func (r *LoginRequest) Client() ClientInfo {
+	return ClientInfo{DeviceID: r.DeviceID, DeviceName: r.DeviceName, IPAddress: r.IPAddress, UserAgent: r.UserAgent}
+}
+
+// Client returns the client the login comes from
+func (r *MahasiswaLoginRequest) Client() ClientInfo {
+	return ClientInfo{DeviceID: r.DeviceID, DeviceName: r.DeviceName, IPAddress: r.IPAddress, UserAgent: r.UserAgent}
+}
+
+// Client returns the client the login comes from
+func (r *AdminLoginRequest) Client() ClientInfo {
+	return ClientInfo{DeviceID: r.DeviceID, DeviceName: r.DeviceName, IPAddress: r.IPAddress, UserAgent: r.UserAgent}
+}
+
+// Client returns the client the login comes from
+func (r *AdminMFAVerifyRequest) Client() ClientInfo {
+	return ClientInfo{DeviceID: r.DeviceID, DeviceName: r.DeviceName, IPAddress: r.IPAddress, UserAgent: r.UserAgent}
+}
+
+// SessionQuery names the user whose sessions an admin lists
+type SessionQuery struct {
+	UserType string `query:"user_type" validate:"required,oneof=mahasiswa admin"`
+	UserID   uint   `query:"user_id" validate:"required,min=1"`
+}
+
+// LogoutRequest optionally names the refresh token of the session to end with the access token
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"omitempty"`
+}
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// Filter and paginate the login attempt audit log
+type LoginAttemptQuery struct {
+	Page       int    `query:"page" validate:"omitempty,min=1"`
+	Limit      int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	UserType   string `query:"user_type" validate:"omitempty,oneof=mahasiswa alumni admin"`
+	Identifier string `query:"identifier"`
+	IPAddress  string `query:"ip_address"`
+	Outcome    string `query:"outcome" validate:"omitempty,oneof=success failure locked"`
+}
+
+// Pagination returns the paging part of the query
+func (q *LoginAttemptQuery) Pagination() *PaginationQuery {
+	return &PaginationQuery{Page: q.Page, Limit: q.Limit}
+}
+
+// OIDCLoginQuery starts an SSO login at GET /auth/oidc/login
+type OIDCLoginQuery struct {
+	DeviceID string `query:"device_id" validate:"omitempty,max=100"`
+}
+
+// OIDCCallbackQuery is what the identity provider redirects back to GET /auth/oidc/callback with
+type OIDCCallbackQuery struct {
+	State            string `query:"state" validate:"required,max=100"`
+	Code             string `query:"code" validate:"required_without=Error,max=2048"`
+	Error            string `query:"error" validate:"max=100"`
+	ErrorDescription string `query:"error_description" validate:"max=500"`
+
+	// StateCookie is the state the browser got in a cookie when it started the login, set by the handler
+	StateCookie string `query:"-"`
+
+	// IPAddress and UserAgent describe the browser, set by the handler
+	IPAddress string `query:"-"`
+	UserAgent string `query:"-"`
+}
+
+// OIDCRedirect sends the browser to the identity provider. The browser also gets State
+// in a cookie, which the callback must bring back, so a login only completes in the
+// browser that started it.
+type OIDCRedirect struct {
+	AuthURL   string
+	State     string
+	ExpiresAt time.Time
+}
@@ -5,7 +5,7 @@ type CreateMahasiswaRequest struct {
 	NIM      string `json:"nim" validate:"required,max=20"`
 	Nama     string `json:"nama" validate:"required,max=100"`
 	Email    string `json:"email" validate:"required,email,max=100"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 	Jurusan  string `json:"jurusan" validate:"required,max=50"`
 	Angkatan int    `json:"angkatan" validate:"required,min=1900,max=2100"`
 }
@@ -17,12 +17,35 @@ type UpdateMahasiswaRequest struct {
 	NoTelepon string `json:"no_telepon,omitempty" validate:"omitempty,max=15"`
 }
 
-// Graduate mahasiswa to alumni status
-type GraduateMahasiswaRequest struct {
-	MahasiswaID   uint   `json:"mahasiswa_id" validate:"required"`
-	TahunLulus    int    `json:"tahun_lulus" validate:"required,min=1900,max=2100"`
-	NoTelepon     string `json:"no_telepon" validate:"omitempty,max=15"`
-	AlamatAlumni  string `json:"alamat_alumni" validate:"omitempty"`
+// Submit a graduation request, graduated once an admin approves it
+type SubmitGraduationRequest struct {
+	TahunLulus   int    `json:"tahun_lulus" validate:"required,min=1900,max=2100"`
+	NoTelepon    string `json:"no_telepon" validate:"omitempty,max=15"`
+	AlamatAlumni string `json:"alamat_alumni" validate:"omitempty"`
+	DocumentRef  string `json:"document_ref" validate:"required,max=255"` // e.g. transcript or yudisium letter number or URL
+}
+
+// Approve or reject a graduation request
+type ReviewGraduationRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// Filter and paginate the graduation request queue
+type GraduationRequestQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected"`
+}
+
+// Pagination returns the paging part of the query
+func (q *GraduationRequestQuery) Pagination() *PaginationQuery {
+	return &PaginationQuery{Page: q.Page, Limit: q.Limit}
+}
+
+// Change mahasiswa status through one of the lifecycle transitions. Graduation goes through graduation requests.
+type ChangeStatusRequest struct {
+	Transition string `json:"transition" validate:"required,oneof=suspend reinstate drop_out graduate revoke_graduation"`
+	Reason     string `json:"reason" validate:"required,max=500"`
 }
 
 // Update alumni data (after graduation)
@@ -5,7 +5,7 @@ type RegisterMahasiswaRequest struct {
 	NIM      string `json:"nim" validate:"required"`
 	Nama     string `json:"nama" validate:"required,min=2"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 	Jurusan  string `json:"jurusan" validate:"required"`
 	Angkatan int    `json:"angkatan" validate:"required,min=1900,max=2030"`
 }
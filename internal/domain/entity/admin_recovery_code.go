@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// AdminRecoveryCode is a single-use code that stands in for a TOTP code when an admin
+// has lost their authenticator. Only the hash of the code is stored.
+type AdminRecoveryCode struct {
+	ID        uint       `json:"id"`
+	AdminID   uint       `json:"admin_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (AdminRecoveryCode) TableName() string {
+	return "admin_recovery_codes"
+}
@@ -20,30 +20,48 @@ type AdminUser struct {
 	Password  string         `json:"-" gorm:"not null"`
 	Role      AdminRole      `json:"role" gorm:"type:varchar(20);default:'moderator'"`
 	IsActive  bool           `json:"is_active" gorm:"default:true"`
+
+	// MustChangePassword limits the admin to changing their password until they do
+	MustChangePassword bool `json:"must_change_password"`
+	// PasswordChangedAt is when the password was last set, the start of its maximum age
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+
+	// TOTPSecret is set on MFA enrollment; TOTPEnabled once the first code confirmed it.
+	// TOTPLastStep is the last accepted time step, so that no code is accepted twice.
+	TOTPSecret   string `json:"-"`
+	TOTPEnabled  bool   `json:"mfa_enabled"`
+	TOTPLastStep int64  `json:"-"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 type AdminUserResponse struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      AdminRole `json:"role"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                 uint       `json:"id"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	Role               AdminRole  `json:"role"`
+	IsActive           bool       `json:"is_active"`
+	MustChangePassword bool       `json:"must_change_password"`
+	MFAEnabled         bool       `json:"mfa_enabled"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 func (a *AdminUser) ToResponse() *AdminUserResponse {
 	return &AdminUserResponse{
-		ID:        a.ID,
-		Username:  a.Username,
-		Email:     a.Email,
-		Role:      a.Role,
-		IsActive:  a.IsActive,
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		ID:                 a.ID,
+		Username:           a.Username,
+		Email:              a.Email,
+		Role:               a.Role,
+		IsActive:           a.IsActive,
+		MustChangePassword: a.MustChangePassword,
+		MFAEnabled:         a.TOTPEnabled,
+		PasswordChangedAt:  a.PasswordChangedAt,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
 	}
 }
 
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// APIKey lets an integration call the API without a user account, limited to its scopes.
+// Only the hash of the key is stored; Prefix identifies it in lists and logs.
+type APIKey struct {
+	ID         uint         `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"-"`
+	Scopes     []Permission `json:"scopes"`
+	CreatedBy  *uint        `json:"created_by"` // admin who created the key
+	ExpiresAt  *time.Time   `json:"expires_at"` // nil for keys that do not expire
+	LastUsedAt *time.Time   `json:"last_used_at"`
+	RotatedAt  *time.Time   `json:"rotated_at"`
+	RevokedAt  *time.Time   `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
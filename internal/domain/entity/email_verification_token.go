@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// EmailVerificationToken is a single-use token mailed to a newly registered mahasiswa.
+// Only the hash of the token is stored.
+type EmailVerificationToken struct {
+	ID          uint       `json:"id"`
+	TokenHash   string     `json:"-"`
+	MahasiswaID uint       `json:"mahasiswa_id"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (t *EmailVerificationToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
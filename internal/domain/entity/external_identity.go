@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// ExternalIdentity links an account at an OpenID Connect provider to a mahasiswa or admin.
+// The provider's subject identifies the user; the email is kept for reference only.
+type ExternalIdentity struct {
+	ID          uint       `json:"id"`
+	Issuer      string     `json:"issuer"`
+	Subject     string     `json:"subject"`
+	UserType    string     `json:"user_type"` // mahasiswa or admin
+	UserID      uint       `json:"user_id"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+func (ExternalIdentity) TableName() string {
+	return "external_identities"
+}
+
+// OIDCLoginState is a started SSO login waiting for the provider to redirect back. Only
+// the hash of the state parameter is stored; the verifier proves the callback belongs to
+// the same login (PKCE).
+type OIDCLoginState struct {
+	ID           uint      `json:"id"`
+	StateHash    string    `json:"-"`
+	Nonce        string    `json:"-"`
+	CodeVerifier string    `json:"-"`
+	DeviceID     string    `json:"device_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+func (s *OIDCLoginState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
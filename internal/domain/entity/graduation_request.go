@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// GraduationRequestStatus is the review state of a graduation request
+type GraduationRequestStatus string
+
+const (
+	GraduationRequestPending  GraduationRequestStatus = "pending"
+	GraduationRequestApproved GraduationRequestStatus = "approved"
+	GraduationRequestRejected GraduationRequestStatus = "rejected"
+)
+
+// GraduationRequest is a mahasiswa's request to graduate. Only its approval by an admin
+// graduates the mahasiswa.
+type GraduationRequest struct {
+	ID           uint   `json:"id"`
+	MahasiswaID  uint   `json:"mahasiswa_id"`
+	TahunLulus   int    `json:"tahun_lulus"`
+	NoTelepon    string `json:"no_telepon"`
+	AlamatAlumni string `json:"alamat_alumni"`
+	// DocumentRef points to the supporting document, such as a transcript or yudisium letter
+	DocumentRef string `json:"document_ref"`
+
+	Status       GraduationRequestStatus `json:"status"`
+	ReviewReason string                  `json:"review_reason"`
+	ReviewedBy   *uint                   `json:"reviewed_by"` // admin id, NULL while pending
+	ReviewedAt   *time.Time              `json:"reviewed_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (GraduationRequest) TableName() string {
+	return "graduation_requests"
+}
+
+func (r *GraduationRequest) IsPending() bool {
+	return r.Status == GraduationRequestPending
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// ImpersonationLog records an admin being issued a token to act as a mahasiswa or alumni
+type ImpersonationLog struct {
+	ID            uint      `json:"id"`
+	AdminID       uint      `json:"admin_id"`
+	AdminUsername string    `json:"admin_username"`
+	MahasiswaID   uint      `json:"mahasiswa_id"`
+	Role          string    `json:"role"` // role of the issued token, mahasiswa or alumni
+	Reason        string    `json:"reason"`
+	TokenID       string    `json:"token_id"` // jti of the issued token
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (ImpersonationLog) TableName() string {
+	return "impersonation_logs"
+}
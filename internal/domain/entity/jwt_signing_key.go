@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// JWTSigningKey is an asymmetric key access tokens are signed with, identified in token
+// headers by KeyID. RetiredAt is when a newer key took over signing.
+type JWTSigningKey struct {
+	ID         uint       `json:"id"`
+	KeyID      string     `json:"kid"`
+	Algorithm  string     `json:"algorithm"` // RS256, EdDSA
+	PrivateKey string     `json:"-"`         // PKCS #8 PEM sealed with JWT_KEY_ENCRYPTION_KEY
+	CreatedAt  time.Time  `json:"created_at"`
+	RetiredAt  *time.Time `json:"retired_at"`
+}
+
+func (JWTSigningKey) TableName() string {
+	return "jwt_signing_keys"
+}
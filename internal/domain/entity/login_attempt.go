@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// Outcomes of a login attempt
+const (
+	LoginOutcomeSuccess = "success"
+	LoginOutcomeFailure = "failure"
+	// LoginOutcomeLocked is an attempt refused without checking the password
+	LoginOutcomeLocked = "locked"
+)
+
+// LoginLockoutScopeIP is the lockout scope of a client address; accounts use their user type
+const LoginLockoutScopeIP = "ip"
+
+// LoginAttempt is the audit record of one login request
+type LoginAttempt struct {
+	ID         uint      `json:"id"`
+	UserType   string    `json:"user_type"`  // mahasiswa, alumni, admin
+	Identifier string    `json:"identifier"` // email or username as entered, lower-cased
+	IPAddress  string    `json:"ip_address"`
+	Outcome    string    `json:"outcome"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// LoginLockout counts the recent failed logins of an account or a client address and
+// holds back further attempts until LockedUntil
+type LoginLockout struct {
+	ID           uint       `json:"id"`
+	Scope        string     `json:"scope"`   // mahasiswa, admin or ip
+	Subject      string     `json:"subject"` // identifier or IP address
+	FailedCount  int        `json:"failed_count"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (l *LoginLockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && now.Before(*l.LockedUntil)
+}
+
+func (LoginLockout) TableName() string {
+	return "login_lockouts"
+}
@@ -33,6 +33,9 @@ type Mahasiswa struct {
 	NoTelepon     string  `json:"no_telepon" gorm:"size:15"`
 	AlamatAlumni  string  `json:"alamat_alumni" gorm:"type:text"`
 	
+	// EmailVerifiedAt is NULL until the mahasiswa opens the verification link
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
@@ -46,10 +49,32 @@ func (m *Mahasiswa) IsAlumni() bool {
 	return m.Status == StatusMahasiswaGraduated
 }
 
+// Role is the token role the account logs in with: alumni once graduated, mahasiswa before
+func (m *Mahasiswa) Role() string {
+	if m.IsAlumni() {
+		return "alumni"
+	}
+	return "mahasiswa"
+}
+
+// Permissions returns what the account may do in its current status. Suspended and
+// dropped out accounts can still log in, but hold no permissions.
+func (m *Mahasiswa) Permissions() []Permission {
+	switch m.Status {
+	case StatusMahasiswaSuspended, StatusMahasiswaDroppedOut:
+		return nil
+	}
+	return RolePermissions(m.Role())
+}
+
 func (m *Mahasiswa) IsActive() bool {
 	return m.Status == StatusMahasiswaActive
 }
 
+func (m *Mahasiswa) IsEmailVerified() bool {
+	return m.EmailVerifiedAt != nil
+}
+
 // Graduate - convert mahasiswa to alumni status
 func (m *Mahasiswa) Graduate(tahunLulus int, noTelepon, alamat string) {
 	m.Status = StatusMahasiswaGraduated
@@ -69,6 +94,7 @@ type MahasiswaResponse struct {
 	TahunLulus    *int            `json:"tahun_lulus,omitempty"`
 	NoTelepon     string          `json:"no_telepon,omitempty"`
 	AlamatAlumni  string          `json:"alamat_alumni,omitempty"`
+	EmailVerifiedAt *time.Time    `json:"email_verified_at"`
 	CreatedAt     time.Time       `json:"created_at"`
 	UpdatedAt     time.Time       `json:"updated_at"`
 }
@@ -85,6 +111,7 @@ func (m *Mahasiswa) ToResponse() *MahasiswaResponse {
 		TahunLulus:    m.TahunLulus,
 		NoTelepon:     m.NoTelepon,
 		AlamatAlumni:  m.AlamatAlumni,
+		EmailVerifiedAt: m.EmailVerifiedAt,
 		CreatedAt:     m.CreatedAt,
 		UpdatedAt:     m.UpdatedAt,
 	}
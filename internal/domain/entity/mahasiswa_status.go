@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StatusTransition is a named change of StatusMahasiswa
+type StatusTransition string
+
+const (
+	TransitionSuspend          StatusTransition = "suspend"           // active -> suspended
+	TransitionReinstate        StatusTransition = "reinstate"         // suspended/dropped_out -> active
+	TransitionDropOut          StatusTransition = "drop_out"          // active/suspended -> dropped_out
+	TransitionGraduate         StatusTransition = "graduate"          // active -> graduated
+	TransitionRevokeGraduation StatusTransition = "revoke_graduation" // graduated -> active
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+type statusRule struct {
+	from []StatusMahasiswa
+	to   StatusMahasiswa
+}
+
+// statusRules is the mahasiswa lifecycle: every allowed transition with the
+// statuses it may start from and the status it leads to
+var statusRules = map[StatusTransition]statusRule{
+	TransitionSuspend: {
+		from: []StatusMahasiswa{StatusMahasiswaActive},
+		to:   StatusMahasiswaSuspended,
+	},
+	TransitionReinstate: {
+		from: []StatusMahasiswa{StatusMahasiswaSuspended, StatusMahasiswaDroppedOut},
+		to:   StatusMahasiswaActive,
+	},
+	TransitionDropOut: {
+		from: []StatusMahasiswa{StatusMahasiswaActive, StatusMahasiswaSuspended},
+		to:   StatusMahasiswaDroppedOut,
+	},
+	TransitionGraduate: {
+		from: []StatusMahasiswa{StatusMahasiswaActive},
+		to:   StatusMahasiswaGraduated,
+	},
+	TransitionRevokeGraduation: {
+		from: []StatusMahasiswa{StatusMahasiswaGraduated},
+		to:   StatusMahasiswaActive,
+	},
+}
+
+// IsValid reports whether t is a known transition
+func (t StatusTransition) IsValid() bool {
+	_, ok := statusRules[t]
+	return ok
+}
+
+// CanTransition checks that t is allowed from the current status and returns the resulting status
+func (m *Mahasiswa) CanTransition(t StatusTransition) (StatusMahasiswa, error) {
+	rule, ok := statusRules[t]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown transition %q", ErrInvalidStatusTransition, t)
+	}
+
+	for _, from := range rule.from {
+		if m.Status == from {
+			return rule.to, nil
+		}
+	}
+	return "", fmt.Errorf("%w: cannot %s a mahasiswa with status %s", ErrInvalidStatusTransition, t, m.Status)
+}
+
+// ApplyTransition moves the mahasiswa to the status t leads to. Revoking a graduation
+// clears the graduation year; graduation data is filled in by Graduate.
+func (m *Mahasiswa) ApplyTransition(t StatusTransition) error {
+	to, err := m.CanTransition(t)
+	if err != nil {
+		return err
+	}
+
+	m.Status = to
+	if t == TransitionRevokeGraduation {
+		m.TahunLulus = nil
+	}
+	return nil
+}
+
+// MahasiswaStatusHistory records one status transition of a mahasiswa
+type MahasiswaStatusHistory struct {
+	ID          uint             `json:"id"`
+	MahasiswaID uint             `json:"mahasiswa_id"`
+	Transition  StatusTransition `json:"transition"`
+	FromStatus  StatusMahasiswa  `json:"from_status"`
+	ToStatus    StatusMahasiswa  `json:"to_status"`
+	Reason      string           `json:"reason"`
+	ActorID     *uint            `json:"actor_id"`   // NULL for changes made by the system
+	ActorRole   string           `json:"actor_role"` // admin, system
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+func (MahasiswaStatusHistory) TableName() string {
+	return "mahasiswa_status_history"
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// PasswordHistory is a password an account had, kept so that it cannot be chosen again soon
+type PasswordHistory struct {
+	ID           uint      `json:"id"`
+	UserType     string    `json:"user_type"` // mahasiswa, admin
+	UserID       uint      `json:"user_id"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
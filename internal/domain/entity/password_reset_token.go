@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// PasswordResetToken is a single-use token mailed to a user who forgot their password.
+// Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uint       `json:"id"`
+	TokenHash string     `json:"-"`
+	UserType  string     `json:"user_type"` // mahasiswa, admin
+	UserID    uint       `json:"user_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
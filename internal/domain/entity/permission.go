@@ -0,0 +1,123 @@
+package entity
+
+// Permission is an action a token holder may perform, written as resource:action
+type Permission string
+
+const (
+	PermissionMahasiswaCreate       Permission = "mahasiswa:create"
+	PermissionMahasiswaRead         Permission = "mahasiswa:read"
+	PermissionMahasiswaReadAll      Permission = "mahasiswa:read_all"
+	PermissionMahasiswaUpdate       Permission = "mahasiswa:update"
+	PermissionMahasiswaDelete       Permission = "mahasiswa:delete"
+	PermissionMahasiswaStatusRead   Permission = "mahasiswa:status_read"
+	PermissionMahasiswaStatusChange Permission = "mahasiswa:status_change"
+	PermissionMahasiswaImpersonate  Permission = "mahasiswa:impersonate"
+
+	PermissionPekerjaanCreate  Permission = "pekerjaan:create"
+	PermissionPekerjaanRead    Permission = "pekerjaan:read"
+	PermissionPekerjaanReadAll Permission = "pekerjaan:read_all"
+	PermissionPekerjaanUpdate  Permission = "pekerjaan:update"
+	PermissionPekerjaanDelete  Permission = "pekerjaan:delete"
+
+	PermissionGraduationSubmit Permission = "graduation:submit"
+	PermissionGraduationReview Permission = "graduation:review"
+
+	PermissionAdminManage  Permission = "admin:manage"
+	PermissionAPIKeyManage Permission = "api_key:manage"
+)
+
+// Permissions of mahasiswa and alumni. Actions without _all are limited to their own records by the policy package.
+var (
+	mahasiswaPermissions = []Permission{
+		PermissionMahasiswaRead,
+		PermissionMahasiswaUpdate,
+		PermissionGraduationSubmit,
+	}
+
+	alumniPermissions = []Permission{
+		PermissionMahasiswaRead,
+		PermissionMahasiswaUpdate,
+		PermissionPekerjaanCreate,
+		PermissionPekerjaanRead,
+		PermissionPekerjaanUpdate,
+		PermissionPekerjaanDelete,
+	}
+)
+
+// Permissions of each admin role; every role also holds the permissions of the roles below it
+var (
+	moderatorPermissions = []Permission{
+		PermissionMahasiswaRead,
+		PermissionMahasiswaReadAll,
+		PermissionMahasiswaStatusRead,
+		PermissionPekerjaanRead,
+		PermissionPekerjaanReadAll,
+		PermissionPekerjaanUpdate,
+		PermissionPekerjaanDelete,
+	}
+
+	adminPermissions = append(append([]Permission{}, moderatorPermissions...),
+		PermissionMahasiswaCreate,
+		PermissionMahasiswaUpdate,
+		PermissionMahasiswaDelete,
+		PermissionMahasiswaStatusChange,
+		PermissionMahasiswaImpersonate,
+		PermissionPekerjaanCreate,
+		PermissionGraduationReview,
+	)
+
+	superAdminPermissions = append(append([]Permission{}, adminPermissions...),
+		PermissionAdminManage,
+		PermissionAPIKeyManage,
+	)
+)
+
+// APIKeyScopes are the permissions an API key may be granted: read access for integrations
+var APIKeyScopes = []Permission{
+	PermissionMahasiswaRead,
+	PermissionMahasiswaReadAll,
+	PermissionMahasiswaStatusRead,
+	PermissionPekerjaanRead,
+	PermissionPekerjaanReadAll,
+}
+
+// IsAPIKeyScope reports whether an API key may be granted p
+func (p Permission) IsAPIKeyScope() bool {
+	for _, scope := range APIKeyScopes {
+		if scope == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RolePermissions returns the permissions of a mahasiswa or alumni token role
+func RolePermissions(role string) []Permission {
+	switch role {
+	case "mahasiswa":
+		return mahasiswaPermissions
+	case "alumni":
+		return alumniPermissions
+	default:
+		return nil
+	}
+}
+
+// Permissions returns the permissions granted to the admin role
+func (r AdminRole) Permissions() []Permission {
+	switch r {
+	case AdminRoleSuperAdmin:
+		return superAdminPermissions
+	case AdminRoleAdmin:
+		return adminPermissions
+	case AdminRoleModerator:
+		return moderatorPermissions
+	default:
+		return nil
+	}
+}
+
+// IsValid reports whether r is one of the defined admin roles
+func (r AdminRole) IsValid() bool {
+	return r.Permissions() != nil
+}
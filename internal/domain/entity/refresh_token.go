@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// RefreshToken is one link of a refresh token family. Every login starts a new family
+// for a device; every refresh revokes the presented token and adds its replacement.
+type RefreshToken struct {
+	ID           uint       `json:"id"`
+	TokenHash    string     `json:"-"`
+	FamilyID     string     `json:"family_id"`
+	UserType     string     `json:"user_type"` // mahasiswa, admin
+	UserID       uint       `json:"user_id"`
+	Role         string     `json:"role"`
+	DeviceID     string     `json:"device_id"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uint      `json:"replaced_by_id"` // set when the token was rotated
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// WasRotated reports whether the token has already been exchanged for a new one,
+// so presenting it again means it was copied
+func (t *RefreshToken) WasRotated() bool {
+	return t.ReplacedByID != nil
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Session is a login on a device: the refresh token family the login started, with the
+// client it came from. Access tokens name their session in the sid claim. A session is
+// active while its family holds an unrevoked, unexpired refresh token.
+type Session struct {
+	ID         uint      `json:"id"`
+	FamilyID   string    `json:"-"`
+	UserType   string    `json:"user_type"` // mahasiswa, admin
+	UserID     uint      `json:"user_id"`
+	DeviceID   string    `json:"device_id"`
+	DeviceName string    `json:"device_name"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	// Active is read with the session from its refresh token family
+	Active bool `json:"active"`
+	// Current marks the session of the token that listed it
+	Current bool `json:"current"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
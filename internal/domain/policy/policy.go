@@ -0,0 +1,79 @@
+// Package policy decides whether a subject may perform an action on a resource. Usecases
+// ask it before reading or changing a record, so ownership is enforced in one place
+// whichever route reaches the usecase.
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+// ErrImpersonated refuses an action an impersonation token may not perform; it wraps ErrForbidden
+var ErrImpersonated = fmt.Errorf("%w: not allowed while impersonating", ErrForbidden)
+
+// Resource is the record an action targets
+type Resource struct {
+	// OwnerID is the mahasiswa the record belongs to
+	OwnerID uint
+}
+
+// Mahasiswa is the resource of a mahasiswa record, owned by the mahasiswa
+func Mahasiswa(id uint) Resource {
+	return Resource{OwnerID: id}
+}
+
+// PekerjaanAlumni is the resource of a pekerjaan record, owned by its alumni
+func PekerjaanAlumni(pekerjaan *entity.PekerjaanAlumni) Resource {
+	return Resource{OwnerID: pekerjaan.MahasiswaID}
+}
+
+// rule decides whether a subject holding the permission of an action may perform it on the resource
+type rule func(subject *service.JWTClaims, resource Resource) bool
+
+// rules lists the actions limited by more than the permission. Actions without a rule,
+// like the _all permissions, are allowed to every holder of the permission.
+var rules = map[entity.Permission]rule{
+	entity.PermissionMahasiswaRead:   ownerOrAdmin,
+	entity.PermissionMahasiswaUpdate: ownerOrAdmin,
+
+	entity.PermissionPekerjaanCreate: ownerOrAdmin,
+	entity.PermissionPekerjaanRead:   ownerOrAdmin,
+	entity.PermissionPekerjaanUpdate: ownerOrAdmin,
+	entity.PermissionPekerjaanDelete: ownerOrAdmin,
+}
+
+// Authorize returns ErrForbidden unless the subject holds the permission of the action
+// and the rule of the action allows it on the resource
+func Authorize(subject *service.JWTClaims, action entity.Permission, resource Resource) error {
+	if subject == nil || !subject.HasPermission(action) {
+		return ErrForbidden
+	}
+	if allow, ok := rules[action]; ok && !allow(subject, resource) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// ownerOrAdmin lets admins and API keys act on any record and mahasiswa and alumni on
+// their own. API keys are issued by admins and limited by their scopes.
+func ownerOrAdmin(subject *service.JWTClaims, resource Resource) bool {
+	if subject.Role == "admin" || subject.Role == "api_key" {
+		return true
+	}
+	return resource.OwnerID != 0 && subject.UserID == resource.OwnerID
+}
+
+// AuthorizeCredentialChange refuses changes to the password, email or sessions of an
+// account made with an impersonation token, so an admin viewing as the user cannot take
+// the account over
+func AuthorizeCredentialChange(subject *service.JWTClaims) error {
+	if subject != nil && subject.Impersonator != nil {
+		return ErrImpersonated
+	}
+	return nil
+}
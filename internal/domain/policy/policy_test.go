@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var allPermissions = []entity.Permission{
+	entity.PermissionMahasiswaCreate,
+	entity.PermissionMahasiswaRead,
+	entity.PermissionMahasiswaReadAll,
+	entity.PermissionMahasiswaUpdate,
+	entity.PermissionMahasiswaDelete,
+	entity.PermissionMahasiswaStatusRead,
+	entity.PermissionMahasiswaStatusChange,
+	entity.PermissionMahasiswaImpersonate,
+	entity.PermissionPekerjaanCreate,
+	entity.PermissionPekerjaanRead,
+	entity.PermissionPekerjaanReadAll,
+	entity.PermissionPekerjaanUpdate,
+	entity.PermissionPekerjaanDelete,
+	entity.PermissionGraduationSubmit,
+	entity.PermissionGraduationReview,
+	entity.PermissionAdminManage,
+	entity.PermissionAPIKeyManage,
+}
+
+func permissionSet(permissions ...entity.Permission) map[entity.Permission]bool {
+	set := make(map[entity.Permission]bool, len(permissions))
+	for _, p := range permissions {
+		set[p] = true
+	}
+	return set
+}
+
+func TestAuthorize(t *testing.T) {
+	const self, other = 10, 20
+
+	mahasiswa := &service.JWTClaims{UserID: self, Role: "mahasiswa", Permissions: entity.RolePermissions("mahasiswa")}
+	alumni := &service.JWTClaims{UserID: self, Role: "alumni", Permissions: entity.RolePermissions("alumni")}
+	impersonated := &service.JWTClaims{
+		UserID:       self,
+		Role:         "alumni",
+		Permissions:  entity.RolePermissions("alumni"),
+		Impersonator: &service.Impersonator{AdminID: 1},
+	}
+	admin := func(role entity.AdminRole) *service.JWTClaims {
+		return &service.JWTClaims{UserID: self, Role: "admin", AdminRole: string(role), Permissions: role.Permissions()}
+	}
+	apiKey := &service.JWTClaims{UserID: self, Role: "api_key", Permissions: entity.APIKeyScopes}
+	narrowAPIKey := &service.JWTClaims{UserID: self, Role: "api_key", Permissions: []entity.Permission{entity.PermissionMahasiswaRead}}
+
+	moderatorAllowed := []entity.Permission{
+		entity.PermissionMahasiswaRead,
+		entity.PermissionMahasiswaReadAll,
+		entity.PermissionMahasiswaStatusRead,
+		entity.PermissionPekerjaanRead,
+		entity.PermissionPekerjaanReadAll,
+		entity.PermissionPekerjaanUpdate,
+		entity.PermissionPekerjaanDelete,
+	}
+	adminAllowed := append(append([]entity.Permission{}, moderatorAllowed...),
+		entity.PermissionMahasiswaCreate,
+		entity.PermissionMahasiswaUpdate,
+		entity.PermissionMahasiswaDelete,
+		entity.PermissionMahasiswaStatusChange,
+		entity.PermissionMahasiswaImpersonate,
+		entity.PermissionPekerjaanCreate,
+		entity.PermissionGraduationReview,
+	)
+	superAdminAllowed := append(append([]entity.Permission{}, adminAllowed...),
+		entity.PermissionAdminManage,
+		entity.PermissionAPIKeyManage,
+	)
+	alumniOwn := []entity.Permission{
+		entity.PermissionMahasiswaRead,
+		entity.PermissionMahasiswaUpdate,
+		entity.PermissionPekerjaanCreate,
+		entity.PermissionPekerjaanRead,
+		entity.PermissionPekerjaanUpdate,
+		entity.PermissionPekerjaanDelete,
+	}
+
+	tests := []struct {
+		name    string
+		subject *service.JWTClaims
+		// own and others are the actions allowed on a record of the subject and of someone else
+		own    []entity.Permission
+		others []entity.Permission
+	}{
+		{"nil subject", nil, nil, nil},
+		{"no permissions", &service.JWTClaims{UserID: self, Role: "mahasiswa"}, nil, nil},
+		{
+			"mahasiswa", mahasiswa,
+			[]entity.Permission{entity.PermissionMahasiswaRead, entity.PermissionMahasiswaUpdate, entity.PermissionGraduationSubmit},
+			[]entity.Permission{entity.PermissionGraduationSubmit},
+		},
+		{"alumni", alumni, alumniOwn, nil},
+		{"impersonated alumni", impersonated, alumniOwn, nil},
+		{"moderator", admin(entity.AdminRoleModerator), moderatorAllowed, moderatorAllowed},
+		{"admin", admin(entity.AdminRoleAdmin), adminAllowed, adminAllowed},
+		{"super admin", admin(entity.AdminRoleSuperAdmin), superAdminAllowed, superAdminAllowed},
+		{"api key", apiKey, entity.APIKeyScopes, entity.APIKeyScopes},
+		{"narrow api key", narrowAPIKey, narrowAPIKey.Permissions, narrowAPIKey.Permissions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, owner := range []struct {
+				name     string
+				resource Resource
+				allowed  map[entity.Permission]bool
+			}{
+				{"own", Mahasiswa(self), permissionSet(tt.own...)},
+				{"other", Mahasiswa(other), permissionSet(tt.others...)},
+				{"other pekerjaan", PekerjaanAlumni(&entity.PekerjaanAlumni{MahasiswaID: other}), permissionSet(tt.others...)},
+			} {
+				for _, action := range allPermissions {
+					err := Authorize(tt.subject, action, owner.resource)
+					if owner.allowed[action] {
+						if err != nil {
+							t.Errorf("Authorize(%s on %s record) = %v, want allowed", action, owner.name, err)
+						}
+					} else if !errors.Is(err, ErrForbidden) {
+						t.Errorf("Authorize(%s on %s record) = %v, want %v", action, owner.name, err, ErrForbidden)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOwnerOrAdmin(t *testing.T) {
+	tests := []struct {
+		role    string
+		userID  uint
+		ownerID uint
+		want    bool
+	}{
+		{"admin", 1, 2, true},
+		{"admin", 1, 0, true},
+		{"api_key", 1, 2, true},
+		{"api_key", 0, 0, true},
+		{"mahasiswa", 2, 2, true},
+		{"mahasiswa", 1, 2, false},
+		{"alumni", 2, 2, true},
+		{"alumni", 1, 2, false},
+		// A record without owner belongs to nobody, not to a subject without id
+		{"mahasiswa", 0, 0, false},
+		{"alumni", 0, 0, false},
+		{"unknown", 1, 2, false},
+	}
+
+	for _, tt := range tests {
+		subject := &service.JWTClaims{UserID: tt.userID, Role: tt.role}
+		if got := ownerOrAdmin(subject, Resource{OwnerID: tt.ownerID}); got != tt.want {
+			t.Errorf("ownerOrAdmin(%s %d, owner %d) = %v, want %v", tt.role, tt.userID, tt.ownerID, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorizeCredentialChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject *service.JWTClaims
+		want    error
+	}{
+		{"nil subject", nil, nil},
+		{"mahasiswa", &service.JWTClaims{UserID: 1, Role: "mahasiswa"}, nil},
+		{"alumni", &service.JWTClaims{UserID: 1, Role: "alumni"}, nil},
+		{"admin", &service.JWTClaims{UserID: 1, Role: "admin", AdminRole: string(entity.AdminRoleSuperAdmin)}, nil},
+		{"impersonated", &service.JWTClaims{UserID: 1, Role: "mahasiswa", Impersonator: &service.Impersonator{AdminID: 2}}, ErrImpersonated},
+	}
+
+	for _, tt := range tests {
+		err := AuthorizeCredentialChange(tt.subject)
+		if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s: AuthorizeCredentialChange() = %v, want %v", tt.name, err, tt.want)
+		}
+		if tt.want != nil && !errors.Is(err, ErrForbidden) {
+			t.Errorf("%s: AuthorizeCredentialChange() = %v, does not wrap %v", tt.name, err, ErrForbidden)
+		}
+	}
+}
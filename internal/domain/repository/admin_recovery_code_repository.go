@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+type AdminRecoveryCodeRepository interface {
+	// Replace deletes the admin's recovery codes and stores new ones by hash
+	Replace(ctx context.Context, adminID uint, codeHashes []string) error
+	// Use marks an unused code of the admin as used and reports whether it did
+	Use(ctx context.Context, adminID uint, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, adminID uint) (int64, error)
+	DeleteByAdmin(ctx context.Context, adminID uint) error
+}
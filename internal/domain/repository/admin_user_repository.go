@@ -12,6 +12,22 @@ type AdminUserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*entity.AdminUser, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error)
 	Update(ctx context.Context, id uint, admin *entity.AdminUser) error
+	// UpdatePassword sets a new password and clears a pending forced password change
+	UpdatePassword(ctx context.Context, id uint, hashedPassword string) error
+	// RehashPassword replaces the hash of the unchanged password, e.g. with a new bcrypt cost
+	RehashPassword(ctx context.Context, id uint, hashedPassword string) error
+	// RequirePasswordChange makes the admin replace their password on next login
+	RequirePasswordChange(ctx context.Context, id uint) error
 	Delete(ctx context.Context, id uint) error
 	GetActiveAdmins(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error)
+	// SetTOTPSecret stores a new, not yet confirmed MFA secret and turns MFA off until it is confirmed
+	SetTOTPSecret(ctx context.Context, id uint, secret string) error
+	EnableTOTP(ctx context.Context, id uint) error
+	// DisableTOTP removes the MFA secret
+	DisableTOTP(ctx context.Context, id uint) error
+	// UseTOTPStep accepts a TOTP time step once; it returns false for a step at or before the last accepted one
+	UseTOTPStep(ctx context.Context, id uint, step int64) (bool, error)
+	// LockActiveSuperAdmins returns the ids of the active super admins and, inside a
+	// transaction, locks their rows until it ends
+	LockActiveSuperAdmins(ctx context.Context) ([]uint, error)
 }
\ No newline at end of file
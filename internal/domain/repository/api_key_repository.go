@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByID(ctx context.Context, id uint) (*entity.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.APIKey, int64, error)
+	// Rotate replaces the key of an unrevoked API key and reports whether it did
+	Rotate(ctx context.Context, id uint, prefix, keyHash string) (bool, error)
+	// Revoke revokes a key that is not revoked yet and reports whether it did
+	Revoke(ctx context.Context, id uint) (bool, error)
+	// TouchLastUsed records a use unless one was already recorded after since
+	TouchLastUsed(ctx context.Context, id uint, now, since time.Time) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, token *entity.EmailVerificationToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error)
+	// GetLatestByMahasiswaID returns the most recently created token of the mahasiswa
+	GetLatestByMahasiswaID(ctx context.Context, mahasiswaID uint) (*entity.EmailVerificationToken, error)
+	// MarkUsed marks an unused token as used and reports whether it did
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+	// InvalidateForMahasiswa marks every unused token of the mahasiswa as used
+	InvalidateForMahasiswa(ctx context.Context, mahasiswaID uint) error
+}
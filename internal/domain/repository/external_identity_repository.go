@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type ExternalIdentityRepository interface {
+	Create(ctx context.Context, identity *entity.ExternalIdentity) error
+	// GetBySubject returns the identity the provider knows as subject, nil if it is not linked
+	GetBySubject(ctx context.Context, issuer, subject string) (*entity.ExternalIdentity, error)
+	// GetByUser returns the identity of the provider linked to the account, nil if there is none
+	GetByUser(ctx context.Context, issuer, userType string, userID uint) (*entity.ExternalIdentity, error)
+	TouchLogin(ctx context.Context, id uint, at time.Time) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type OIDCLoginStateRepository interface {
+	Create(ctx context.Context, state *entity.OIDCLoginState) error
+	// Consume deletes the login with the state hash and returns it; nil if it is unknown or was consumed already
+	Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type GraduationRequestRepository interface {
+	Create(ctx context.Context, request *entity.GraduationRequest) error
+	GetByID(ctx context.Context, id uint) (*entity.GraduationRequest, error)
+	// GetPendingByMahasiswa returns the request of the mahasiswa waiting for review, nil if there is none
+	GetPendingByMahasiswa(ctx context.Context, mahasiswaID uint) (*entity.GraduationRequest, error)
+	// ListByMahasiswa returns the requests of a mahasiswa, newest first
+	ListByMahasiswa(ctx context.Context, mahasiswaID uint) ([]*entity.GraduationRequest, error)
+	// List returns the requests with status, all when empty, oldest first so the queue is worked in order
+	List(ctx context.Context, status entity.GraduationRequestStatus, limit, offset int) ([]*entity.GraduationRequest, int64, error)
+	// Review stores the decision on a pending request. It returns false when the request was reviewed already.
+	Review(ctx context.Context, request *entity.GraduationRequest) (bool, error)
+}
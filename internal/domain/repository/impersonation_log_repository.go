@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// ImpersonationLogFilter narrows an impersonation log listing; zero fields match everything
+type ImpersonationLogFilter struct {
+	AdminID     uint
+	MahasiswaID uint
+}
+
+type ImpersonationLogRepository interface {
+	Create(ctx context.Context, log *entity.ImpersonationLog) error
+	// List returns matching entries, newest first
+	List(ctx context.Context, filter ImpersonationLogFilter, limit, offset int) ([]*entity.ImpersonationLog, int64, error)
+}
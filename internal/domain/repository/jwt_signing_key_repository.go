@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type JWTSigningKeyRepository interface {
+	// List returns every stored key, oldest first
+	List(ctx context.Context) ([]*entity.JWTSigningKey, error)
+	Create(ctx context.Context, key *entity.JWTSigningKey) error
+	// RetireCreatedBefore retires the active keys created before the given time
+	RetireCreatedBefore(ctx context.Context, before, retiredAt time.Time) error
+	// DeleteRetiredBefore deletes the keys retired before the given time
+	DeleteRetiredBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// LoginAttemptFilter narrows a login attempt listing; empty fields match everything
+type LoginAttemptFilter struct {
+	UserType   string
+	Identifier string
+	IPAddress  string
+	Outcome    string
+}
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *entity.LoginAttempt) error
+	// List returns matching attempts, newest first
+	List(ctx context.Context, filter LoginAttemptFilter, limit, offset int) ([]*entity.LoginAttempt, int64, error)
+}
+
+type LoginLockoutRepository interface {
+	Get(ctx context.Context, scope, subject string) (*entity.LoginLockout, error)
+	GetByID(ctx context.Context, id uint) (*entity.LoginLockout, error)
+	// RegisterFailure counts a failed login for the subject and returns its updated lockout.
+	// A count whose last failure is before windowStart starts over.
+	RegisterFailure(ctx context.Context, scope, subject string, at, windowStart time.Time) (*entity.LoginLockout, error)
+	SetLockedUntil(ctx context.Context, id uint, lockedUntil *time.Time) error
+	// ListLocked returns the lockouts still in force at now
+	ListLocked(ctx context.Context, now time.Time, limit, offset int) ([]*entity.LoginLockout, int64, error)
+	Delete(ctx context.Context, id uint) error
+	DeleteBySubject(ctx context.Context, scope, subject string) error
+}
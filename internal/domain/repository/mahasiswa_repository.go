@@ -8,10 +8,16 @@ import (
 type MahasiswaRepository interface {
 	Create(ctx context.Context, mahasiswa *entity.Mahasiswa) error
 	GetByID(ctx context.Context, id uint) (*entity.Mahasiswa, error)
+	// GetByIDForUpdate is GetByID that also locks the row until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uint) (*entity.Mahasiswa, error)
 	GetByNIM(ctx context.Context, nim string) (*entity.Mahasiswa, error)
 	GetByEmail(ctx context.Context, email string) (*entity.Mahasiswa, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*entity.Mahasiswa, int64, error)
 	Update(ctx context.Context, id uint, mahasiswa *entity.Mahasiswa) error
+	// UpdateStatus writes the status and alumni fields, provided the stored status is still from
+	UpdateStatus(ctx context.Context, mahasiswa *entity.Mahasiswa, from entity.StatusMahasiswa) error
+	// MarkEmailVerified sets email_verified_at unless it is set already
+	MarkEmailVerified(ctx context.Context, id uint) error
 	Delete(ctx context.Context, id uint) error
 	Search(ctx context.Context, query string, limit, offset int) ([]*entity.Mahasiswa, int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type MahasiswaStatusHistoryRepository interface {
+	Create(ctx context.Context, history *entity.MahasiswaStatusHistory) error
+	GetByMahasiswaID(ctx context.Context, mahasiswaID uint) ([]*entity.MahasiswaStatusHistory, error)
+}
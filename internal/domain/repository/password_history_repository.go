@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *entity.PasswordHistory) error
+	// ListRecent returns the newest entries of the user, newest first
+	ListRecent(ctx context.Context, userType string, userID uint, limit int) ([]*entity.PasswordHistory, error)
+	// DeleteBefore removes the entries of the user older than the entry with id
+	DeleteBefore(ctx context.Context, userType string, userID uint, id uint) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	// GetLatestByUser returns the newest token of the user, or nil when there is none
+	GetLatestByUser(ctx context.Context, userType string, userID uint) (*entity.PasswordResetToken, error)
+	// MarkUsed marks an unused token as used and reports whether it did
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+	// InvalidateForUser marks every unused token of the user as used
+	InvalidateForUser(ctx context.Context, userType string, userID uint) error
+}
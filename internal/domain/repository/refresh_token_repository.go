@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// Revoke revokes a token that is not revoked yet and reports whether it did
+	Revoke(ctx context.Context, id uint, replacedByID *uint) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeDevice(ctx context.Context, userType string, userID uint, deviceID string) error
+	RevokeUser(ctx context.Context, userType string, userID uint) error
+	// DeleteEnded deletes the expired tokens and the families without a usable token left,
+	// keeping rotated tokens of live families so their reuse is still detected
+	DeleteEnded(ctx context.Context, now time.Time) (int64, error)
+}
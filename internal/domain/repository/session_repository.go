@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// SessionRepository stores logins. The sessions it reads have Active set as of now.
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByID(ctx context.Context, id uint, now time.Time) (*entity.Session, error)
+	GetByFamily(ctx context.Context, familyID string, now time.Time) (*entity.Session, error)
+	// ListActive returns the active sessions of the user, most recently seen first
+	ListActive(ctx context.Context, userType string, userID uint, now time.Time) ([]*entity.Session, error)
+	TouchLastSeen(ctx context.Context, id uint, at time.Time) error
+	// DeleteEnded deletes the sessions with no refresh token left
+	DeleteEnded(ctx context.Context) (int64, error)
+}
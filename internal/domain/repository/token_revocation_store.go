@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationStore keeps track of access tokens that must no longer be accepted
+// although their signature and expiry are valid
+type TokenRevocationStore interface {
+	// RevokeToken revokes a single token by jti until it expires on its own
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+
+	// RevokeUserTokens revokes every token of the user issued at or before the given time
+	RevokeUserTokens(ctx context.Context, userType string, userID uint, before time.Time) error
+	// UserTokensRevokedBefore returns the latest RevokeUserTokens time, or zero when there is none
+	UserTokensRevokedBefore(ctx context.Context, userType string, userID uint) (time.Time, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// UnitOfWork runs several repository calls in one transaction. The transaction travels
+// in the context passed to fn, so repositories must be called with that context.
+// Returning an error from fn (or panicking) rolls the work back; a Do nested inside
+// another Do uses a savepoint and only rolls back its own part.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
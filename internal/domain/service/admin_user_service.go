@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// AdminUserService manages admin accounts. None of its methods leaves the system
+// without an active super admin.
+type AdminUserService interface {
+	CreateAdmin(ctx context.Context, req *dto.CreateAdminRequest) (*entity.AdminUser, error)
+	GetAdmin(ctx context.Context, id uint) (*entity.AdminUser, error)
+	ListAdmins(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error)
+	UpdateAdmin(ctx context.Context, id uint, req *dto.UpdateAdminRequest) (*entity.AdminUser, error)
+	ChangeRole(ctx context.Context, id uint, req *dto.ChangeAdminRoleRequest) (*entity.AdminUser, error)
+	// SetActive activates or deactivates an admin; deactivation ends their sessions
+	SetActive(ctx context.Context, id uint, active bool) (*entity.AdminUser, error)
+	DeleteAdmin(ctx context.Context, id uint) error
+	// ChangePassword changes the admin's own password and ends all of their sessions
+	ChangePassword(ctx context.Context, id uint, req *dto.ChangePasswordRequest) error
+}
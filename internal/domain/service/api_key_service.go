@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// APIKeyService manages the API keys integrations use instead of a user's token
+type APIKeyService interface {
+	// Create issues a key with scopes the creating admin holds
+	Create(ctx context.Context, req *dto.CreateAPIKeyRequest, actor *JWTClaims) (*dto.APIKeySecretResponse, error)
+	Get(ctx context.Context, id uint) (*entity.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.APIKey, int64, error)
+	// Rotate replaces the secret of a key; the old secret stops working at once
+	Rotate(ctx context.Context, id uint) (*dto.APIKeySecretResponse, error)
+	Revoke(ctx context.Context, id uint) error
+
+	// ValidateAPIKey returns claims granting the scopes of a valid key and records its use
+	ValidateAPIKey(ctx context.Context, key string) (*JWTClaims, error)
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
 )
@@ -12,21 +14,73 @@ type JWTClaims struct {
 	Email    string `json:"email"`
 	Role     string `json:"role"`
 	Username string `json:"username,omitempty"` // for admin
+
+	// AdminRole is the role of an admin (super_admin, admin, moderator); empty for mahasiswa and alumni
+	AdminRole   string              `json:"admin_role,omitempty"`
+	Permissions []entity.Permission `json:"permissions,omitempty"`
+
+	// PasswordChangeRequired marks an admin token that may only be used to change the password
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+	// MFAEnrollmentRequired marks an admin token that may only be used to enroll in MFA
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+
+	// Impersonator is the admin acting as the user, set on tokens issued by impersonation
+	Impersonator *Impersonator `json:"act,omitempty"`
+
+	// SessionID is the session a login started, carried by every access token of the session
+	SessionID uint `json:"sid,omitempty"`
+
+	// RoleChanged is set by token validation when a mahasiswa's status changed since the
+	// token was issued. Role and Permissions then hold the current ones, and the client
+	// should get a new token.
+	RoleChanged bool `json:"-"`
+
+	// Registered claims, filled in when a token is generated or validated
+	TokenID   string    `json:"jti,omitempty"`
+	IssuedAt  time.Time `json:"-"`
+	ExpiresAt time.Time `json:"-"`
+}
+
+// Impersonator identifies the admin behind an impersonation token, like the act claim of RFC 8693
+type Impersonator struct {
+	AdminID  uint   `json:"admin_id"`
+	Username string `json:"username"`
+}
+
+// HasPermission reports whether the token grants permission
+func (c *JWTClaims) HasPermission(permission entity.Permission) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
 }
 
 // AuthService interface untuk authentication domain services
 type AuthService interface {
 	// Login methods
-	LoginMahasiswa(req *dto.MahasiswaLoginRequest) (*dto.LoginResponse, error)
-	LoginAlumni(req *dto.AlumniLoginRequest) (*dto.LoginResponse, error)
-	LoginAdmin(req *dto.AdminLoginRequest) (*dto.LoginResponse, error)
+	// Login finds the account by email, NIM or username and derives its role; the others are aliases
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	LoginMahasiswa(ctx context.Context, req *dto.MahasiswaLoginRequest) (*dto.LoginResponse, error)
+	LoginAlumni(ctx context.Context, req *dto.AlumniLoginRequest) (*dto.LoginResponse, error)
+	LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest) (*dto.LoginResponse, error)
+	// VerifyAdminMFA completes an admin login that answered with an MFA challenge
+	VerifyAdminMFA(ctx context.Context, req *dto.AdminMFAVerifyRequest) (*dto.LoginResponse, error)
+	// LoginExternal logs in the account linked to an identity an SSO provider authenticated
+	LoginExternal(ctx context.Context, identity *entity.ExternalIdentity, client dto.ClientInfo) (*dto.LoginResponse, error)
 	
 	// Register methods
-	RegisterMahasiswa(req *dto.RegisterMahasiswaRequest) (*dto.RegisterResponse, error)
-	GraduateMahasiswa(req *dto.GraduateMahasiswaRequest) (*dto.RegisterResponse, error)
+	RegisterMahasiswa(ctx context.Context, req *dto.RegisterMahasiswaRequest) (*dto.RegisterResponse, error)
 	
 	// Token validation
-	ValidateToken(token string) (*JWTClaims, error)
+	ValidateToken(ctx context.Context, token string) (*JWTClaims, error)
+	RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error)
+
+	// Logout revokes the access token of claims and, when given, its refresh token family
+	Logout(ctx context.Context, claims *JWTClaims, req *dto.LogoutRequest) error
+	// LogoutAll revokes every access and refresh token the user holds
+	LogoutAll(ctx context.Context, claims *JWTClaims) error
 	
 	// Legacy methods for backward compatibility
 	ValidateCredentials(ctx context.Context, email, password string) (*entity.Mahasiswa, error)
@@ -36,9 +90,10 @@ type AuthService interface {
 
 // EmailService interface untuk email domain services
 type EmailService interface {
-	SendWelcomeEmail(ctx context.Context, email, name string) error
+	SendWelcomeEmail(ctx context.Context, email, name, verificationToken string) error
 	SendPasswordResetEmail(ctx context.Context, email, resetToken string) error
-	SendGraduationNotification(ctx context.Context, mahasiswa *entity.Mahasiswa) error
+	// SendGraduationNotification tells the mahasiswa that the graduation request was received, approved or rejected
+	SendGraduationNotification(ctx context.Context, mahasiswa *entity.Mahasiswa, request *entity.GraduationRequest) error
 }
 
 // NotificationService interface untuk notification domain services
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// BootstrapService creates the first admin of a fresh installation
+type BootstrapService interface {
+	// Bootstrap runs on startup. When no admin exists it creates initial as a super admin who must
+	// change the password on first login or, without initial credentials, returns a one-time setup
+	// token for SetupAdmin. It fails in production while an admin still has a known default password.
+	Bootstrap(ctx context.Context, initial *dto.InitialAdmin) (setupToken string, err error)
+	// SetupAdmin creates the first super admin with the setup token returned by Bootstrap
+	SetupAdmin(ctx context.Context, req *dto.SetupAdminRequest) (*entity.AdminUser, error)
+}
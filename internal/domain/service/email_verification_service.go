@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// EmailVerificationService confirms that a mahasiswa owns the email they registered with
+type EmailVerificationService interface {
+	// SendVerification replaces the mahasiswa's outstanding tokens with a new one and mails it
+	SendVerification(ctx context.Context, mahasiswa *entity.Mahasiswa) error
+	// VerifyEmail marks the email of the token's mahasiswa as verified
+	VerifyEmail(ctx context.Context, token string) error
+	// ResendVerification mails a new token to an unverified account, at most once per resend interval
+	ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// GraduationService runs graduation requests: a mahasiswa submits one, an admin approves
+// or rejects it, and only an approval graduates the mahasiswa
+type GraduationService interface {
+	// Submit files a request for an active mahasiswa that has none pending
+	Submit(ctx context.Context, mahasiswaID uint, req *dto.SubmitGraduationRequest) (*entity.GraduationRequest, error)
+	GetOwn(ctx context.Context, mahasiswaID uint) ([]*entity.GraduationRequest, error)
+	List(ctx context.Context, status entity.GraduationRequestStatus, limit, offset int) ([]*entity.GraduationRequest, int64, error)
+	GetByID(ctx context.Context, id uint) (*entity.GraduationRequest, error)
+	// Approve graduates the mahasiswa with the data of the request
+	Approve(ctx context.Context, id uint, req *dto.ReviewGraduationRequest, reviewer *JWTClaims) (*entity.GraduationRequest, error)
+	Reject(ctx context.Context, id uint, req *dto.ReviewGraduationRequest, reviewer *JWTClaims) (*entity.GraduationRequest, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+)
+
+// ImpersonationService lets admins act as a mahasiswa or alumni. Every token it issues
+// names the admin in its act claim and is recorded in the impersonation audit log.
+type ImpersonationService interface {
+	Impersonate(ctx context.Context, mahasiswaID uint, req *dto.ImpersonateRequest, actor *JWTClaims) (*dto.ImpersonationResponse, error)
+	ListLogs(ctx context.Context, filter repository.ImpersonationLogFilter, limit, offset int) ([]*entity.ImpersonationLog, int64, error)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+)
+
+// LoginAttemptService tracks failed logins per account and per client address, slows
+// down repeated failures and locks the account or address out for a while. Every
+// attempt is recorded in the login_attempts audit table.
+type LoginAttemptService interface {
+	// Check refuses, with a *usecase.LoginLockedError, an attempt whose account or address is held back
+	Check(ctx context.Context, attempt *entity.LoginAttempt) error
+	RecordFailure(ctx context.Context, attempt *entity.LoginAttempt) error
+	// RecordSuccess also clears the failures of the account
+	RecordSuccess(ctx context.Context, attempt *entity.LoginAttempt) error
+
+	ListLockouts(ctx context.Context, limit, offset int) ([]*entity.LoginLockout, int64, error)
+	ClearLockout(ctx context.Context, id uint) error
+	ListAttempts(ctx context.Context, filter repository.LoginAttemptFilter, limit, offset int) ([]*entity.LoginAttempt, int64, error)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// MahasiswaLifecycleService moves mahasiswa between statuses along the allowed
+// transitions and keeps the history of every change
+type MahasiswaLifecycleService interface {
+	// ChangeStatus applies a transition; a nil actor records the change as made by the system.
+	// It refuses to graduate, which takes an approved graduation request.
+	ChangeStatus(ctx context.Context, mahasiswaID uint, req *dto.ChangeStatusRequest, actor *JWTClaims) (*entity.Mahasiswa, *entity.MahasiswaStatusHistory, error)
+	// Graduate graduates the mahasiswa of an approved graduation request with its data
+	Graduate(ctx context.Context, request *entity.GraduationRequest, reason string, actor *JWTClaims) (*entity.Mahasiswa, *entity.MahasiswaStatusHistory, error)
+	GetStatusHistory(ctx context.Context, mahasiswaID uint) (*entity.Mahasiswa, []*entity.MahasiswaStatusHistory, error)
+}
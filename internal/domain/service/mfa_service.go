@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// MFAService manages the TOTP second factor of admin accounts
+type MFAService interface {
+	Status(ctx context.Context, adminID uint) (*dto.MFAStatusResponse, error)
+	// Enroll creates a new secret; MFA is off until Confirm accepts a code for it
+	Enroll(ctx context.Context, adminID uint) (*dto.MFAEnrollResponse, error)
+	// Confirm turns MFA on, returns the first recovery codes and ends the admin's sessions
+	Confirm(ctx context.Context, adminID uint, req *dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, adminID uint, req *dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error)
+	// Disable turns MFA off for the admin, unless their role requires it
+	Disable(ctx context.Context, adminID uint, req *dto.DisableMFARequest) error
+	// Reset turns MFA off for an admin who lost their authenticator and recovery codes
+	Reset(ctx context.Context, adminID uint) error
+
+	// VerifyCode accepts a TOTP code or an unused recovery code of an admin with MFA on
+	VerifyCode(ctx context.Context, admin *entity.AdminUser, code string) error
+	// IsRequired reports whether admins of the role must use MFA
+	IsRequired(role entity.AdminRole) bool
+}
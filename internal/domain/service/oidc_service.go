@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+)
+
+// OIDCService logs users in through the campus OpenID Connect provider
+type OIDCService interface {
+	// Start begins a login and returns the provider URL to send the user to
+	Start(ctx context.Context, query *dto.OIDCLoginQuery) (*dto.OIDCRedirect, error)
+	// Callback completes the login the provider redirected back from, linking the
+	// identity to a mahasiswa or admin on first use
+	Callback(ctx context.Context, query *dto.OIDCCallbackQuery) (*dto.LoginResponse, error)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// PasswordSubject is the account a new password is chosen for
+type PasswordSubject struct {
+	UserType string // mahasiswa, admin
+	// UserID is 0 for an account that is being created
+	UserID uint
+	// CurrentHash is the account's password now, empty for a new account
+	CurrentHash string
+	// Identifiers such as the NIM, name, email and username may not appear in the password
+	Identifiers []string
+}
+
+// PasswordPolicyService decides which new passwords are accepted and keeps the password history
+type PasswordPolicyService interface {
+	// Check refuses a password that breaks the policy, is known from breaches or was used recently
+	Check(ctx context.Context, password string, subject *PasswordSubject) error
+	// Remember adds a password just set for the user to their password history
+	Remember(ctx context.Context, userType string, userID uint, hashedPassword string) error
+	// IsExpired reports whether the admin's password is older than the maximum age for admins
+	IsExpired(admin *entity.AdminUser) bool
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+)
+
+// PasswordService handles forgotten passwords of mahasiswa, alumni and admins
+type PasswordService interface {
+	// ForgotPassword mails a reset token to every account with the email. It succeeds
+	// whether or not such an account exists, so callers cannot probe for emails.
+	ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error
+	// ResetPassword sets a new password with a reset token and ends all sessions of the account
+	ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error
+}
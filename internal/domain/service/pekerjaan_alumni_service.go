@@ -6,12 +6,13 @@ import (
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 )
 
-// PekerjaanAlumniService interface for pekerjaan alumni domain services
+// PekerjaanAlumniService interface for pekerjaan alumni domain services.
+// The actor is checked against the ownership policy of the record.
 type PekerjaanAlumniService interface {
-	CreatePekerjaan(ctx context.Context, req *dto.CreatePekerjaanRequest) (*entity.PekerjaanAlumni, error)
-	GetPekerjaanByID(ctx context.Context, id uint) (*entity.PekerjaanAlumni, error)
-	GetPekerjaanByMahasiswaID(ctx context.Context, mahasiswaID uint) ([]*entity.PekerjaanAlumni, error)
+	CreatePekerjaan(ctx context.Context, req *dto.CreatePekerjaanRequest, actor *JWTClaims) (*entity.PekerjaanAlumni, error)
+	GetPekerjaanByID(ctx context.Context, id uint, actor *JWTClaims) (*entity.PekerjaanAlumni, error)
+	GetPekerjaanByMahasiswaID(ctx context.Context, mahasiswaID uint, actor *JWTClaims) ([]*entity.PekerjaanAlumni, error)
 	GetAllPekerjaan(ctx context.Context, search string, limit, offset int) ([]*entity.PekerjaanAlumni, int64, error)
-	UpdatePekerjaan(ctx context.Context, id uint, req *dto.UpdatePekerjaanRequest) (*entity.PekerjaanAlumni, error)
-	DeletePekerjaan(ctx context.Context, id uint) error
+	UpdatePekerjaan(ctx context.Context, id uint, req *dto.UpdatePekerjaanRequest, actor *JWTClaims) (*entity.PekerjaanAlumni, error)
+	DeletePekerjaan(ctx context.Context, id uint, actor *JWTClaims) error
 }
\ No newline at end of file
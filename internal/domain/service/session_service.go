@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// SessionService lists the devices a user is logged in on and ends sessions. Ending a
+// session revokes its refresh tokens and the access tokens issued in it.
+type SessionService interface {
+	// ListSessions returns the active sessions of the token's user, the token's own marked current
+	ListSessions(ctx context.Context, claims *JWTClaims) ([]*entity.Session, error)
+	// EndSession ends a session of the token's user
+	EndSession(ctx context.Context, id uint, claims *JWTClaims) error
+
+	ListUserSessions(ctx context.Context, userType string, userID uint) ([]*entity.Session, error)
+	EndUserSession(ctx context.Context, id uint) error
+
+	// RunCleanup deletes the refresh tokens and sessions that can no longer be used, now and
+	// every interval, until ctx is done
+	RunCleanup(ctx context.Context, interval time.Duration)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type adminRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRecoveryCodeRepository(db *gorm.DB) repository.AdminRecoveryCodeRepository {
+	return &adminRecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Replace runs several statements, so it belongs in a transaction
+func (r *adminRecoveryCodeRepository) Replace(ctx context.Context, adminID uint, codeHashes []string) error {
+	if err := r.DeleteByAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO admin_recovery_codes (admin_id, code_hash, created_at) VALUES (?, ?, ?)`
+
+	now := time.Now()
+	for _, codeHash := range codeHashes {
+		if _, err := conn.ExecContext(ctx, query, adminID, codeHash, now); err != nil {
+			return fmt.Errorf("failed to create admin recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *adminRecoveryCodeRepository) Use(ctx context.Context, adminID uint, codeHash string) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE admin_recovery_codes SET used_at = ? WHERE admin_id = ? AND code_hash = ? AND used_at IS NULL`
+
+	result, err := conn.ExecContext(ctx, query, time.Now(), adminID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use admin recovery code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *adminRecoveryCodeRepository) CountUnused(ctx context.Context, adminID uint) (int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	query := `SELECT COUNT(*) FROM admin_recovery_codes WHERE admin_id = ? AND used_at IS NULL`
+	if err := conn.QueryRowContext(ctx, query, adminID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count admin recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *adminRecoveryCodeRepository) DeleteByAdmin(ctx context.Context, adminID uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM admin_recovery_codes WHERE admin_id = ?`, adminID); err != nil {
+		return fmt.Errorf("failed to delete admin recovery codes: %w", err)
+	}
+
+	return nil
+}
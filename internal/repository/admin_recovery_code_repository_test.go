@@ -0,0 +1,46 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestAdminRecoveryCodeUse(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	codes := repo.NewAdminRecoveryCodeRepository(db)
+	admin := createAdmin(t, repo.NewAdminUserRepository(db), "root", entity.AdminRoleSuperAdmin, true)
+
+	if err := codes.Replace(ctx, admin.ID, []string{"code-1", "code-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		adminID uint
+		code    string
+		want    bool
+	}{
+		{admin.ID, "code-1", true},
+		{admin.ID, "code-1", false},
+		{admin.ID + 1, "code-2", false},
+		{admin.ID, "unknown", false},
+		{admin.ID, "code-2", true},
+	}
+	for _, tt := range tests {
+		used, err := codes.Use(ctx, tt.adminID, tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if used != tt.want {
+			t.Errorf("Use(%d, %q) = %v, want %v", tt.adminID, tt.code, used, tt.want)
+		}
+	}
+
+	if count, err := codes.CountUnused(ctx, admin.ID); err != nil || count != 0 {
+		t.Errorf("CountUnused() = %d, %v, want 0", count, err)
+	}
+}
@@ -8,10 +8,15 @@ import (
 
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
 
 	"gorm.io/gorm"
 )
 
+// adminUserColumns is the column list read by every admin user query, in scanAdminUser order
+const adminUserColumns = `id, username, email, password, role, is_active, must_change_password,
+	password_changed_at, totp_secret, totp_enabled, totp_last_step, created_at, updated_at`
+
 type adminUserRepository struct {
 	db *gorm.DB
 }
@@ -23,49 +28,41 @@ func NewAdminUserRepository(db *gorm.DB) repository.AdminUserRepository {
 }
 
 func (r *adminUserRepository) Create(ctx context.Context, admin *entity.AdminUser) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
 
-	query := `INSERT INTO admin_users (username, email, password, role, is_active, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO admin_users (username, email, password, role, is_active, must_change_password, password_changed_at, created_at, updated_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	
 	now := time.Now()
-	result, err := sqlDB.ExecContext(ctx, query,
+	id, err := conn.InsertContext(ctx, query,
 		admin.Username, admin.Email, admin.Password, admin.Role,
-		admin.IsActive, now, now,
+		admin.IsActive, admin.MustChangePassword, now, now, now,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create admin user: %w", err)
 	}
 	
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %w", err)
-	}
-	
 	admin.ID = uint(id)
+	admin.PasswordChangedAt = &now
 	admin.CreatedAt = now
 	admin.UpdatedAt = now
 	return nil
 }
 
 func (r *adminUserRepository) GetByID(ctx context.Context, id uint) (*entity.AdminUser, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, username, email, password, role, is_active, created_at, updated_at 
+	query := `SELECT ` + adminUserColumns + ` 
 			  FROM admin_users WHERE id = ? AND deleted_at IS NULL`
 	
-	var admin entity.AdminUser
-	err = sqlDB.QueryRowContext(ctx, query, id).Scan(
-		&admin.ID, &admin.Username, &admin.Email, &admin.Password,
-		&admin.Role, &admin.IsActive, &admin.CreatedAt, &admin.UpdatedAt,
-	)
+	admin, err := scanAdminUser(conn.QueryRowContext(ctx, query, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -74,23 +71,19 @@ func (r *adminUserRepository) GetByID(ctx context.Context, id uint) (*entity.Adm
 		return nil, fmt.Errorf("failed to get admin user by ID: %w", err)
 	}
 
-	return &admin, nil
+	return admin, nil
 }
 
 func (r *adminUserRepository) GetByUsername(ctx context.Context, username string) (*entity.AdminUser, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, username, email, password, role, is_active, created_at, updated_at 
+	query := `SELECT ` + adminUserColumns + ` 
 			  FROM admin_users WHERE username = ? AND deleted_at IS NULL`
 	
-	var admin entity.AdminUser
-	err = sqlDB.QueryRowContext(ctx, query, username).Scan(
-		&admin.ID, &admin.Username, &admin.Email, &admin.Password,
-		&admin.Role, &admin.IsActive, &admin.CreatedAt, &admin.UpdatedAt,
-	)
+	admin, err := scanAdminUser(conn.QueryRowContext(ctx, query, username))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -99,23 +92,19 @@ func (r *adminUserRepository) GetByUsername(ctx context.Context, username string
 		return nil, fmt.Errorf("failed to get admin user by username: %w", err)
 	}
 
-	return &admin, nil
+	return admin, nil
 }
 
 func (r *adminUserRepository) GetByEmail(ctx context.Context, email string) (*entity.AdminUser, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, username, email, password, role, is_active, created_at, updated_at 
+	query := `SELECT ` + adminUserColumns + ` 
 			  FROM admin_users WHERE email = ? AND deleted_at IS NULL`
 	
-	var admin entity.AdminUser
-	err = sqlDB.QueryRowContext(ctx, query, email).Scan(
-		&admin.ID, &admin.Username, &admin.Email, &admin.Password,
-		&admin.Role, &admin.IsActive, &admin.CreatedAt, &admin.UpdatedAt,
-	)
+	admin, err := scanAdminUser(conn.QueryRowContext(ctx, query, email))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -124,11 +113,11 @@ func (r *adminUserRepository) GetByEmail(ctx context.Context, email string) (*en
 		return nil, fmt.Errorf("failed to get admin user by email: %w", err)
 	}
 
-	return &admin, nil
+	return admin, nil
 }
 
 func (r *adminUserRepository) GetAll(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -136,17 +125,17 @@ func (r *adminUserRepository) GetAll(ctx context.Context, limit, offset int) ([]
 	// Count total
 	countQuery := `SELECT COUNT(*) FROM admin_users WHERE deleted_at IS NULL`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = conn.QueryRowContext(ctx, countQuery).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count admin users: %w", err)
 	}
 
 	// Get data with pagination
-	query := `SELECT id, username, email, password, role, is_active, created_at, updated_at 
+	query := `SELECT ` + adminUserColumns + ` 
 			  FROM admin_users WHERE deleted_at IS NULL 
 			  ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, limit, offset)
+	rows, err := conn.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get admin users list: %w", err)
 	}
@@ -154,15 +143,11 @@ func (r *adminUserRepository) GetAll(ctx context.Context, limit, offset int) ([]
 
 	var admins []*entity.AdminUser
 	for rows.Next() {
-		var admin entity.AdminUser
-		err = rows.Scan(
-			&admin.ID, &admin.Username, &admin.Email, &admin.Password,
-			&admin.Role, &admin.IsActive, &admin.CreatedAt, &admin.UpdatedAt,
-		)
+		admin, err := scanAdminUser(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan admin user: %w", err)
 		}
-		admins = append(admins, &admin)
+		admins = append(admins, admin)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -173,7 +158,7 @@ func (r *adminUserRepository) GetAll(ctx context.Context, limit, offset int) ([]
 }
 
 func (r *adminUserRepository) Update(ctx context.Context, id uint, admin *entity.AdminUser) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -181,7 +166,7 @@ func (r *adminUserRepository) Update(ctx context.Context, id uint, admin *entity
 	query := `UPDATE admin_users SET username = ?, email = ?, role = ?, is_active = ?, updated_at = ? 
 			  WHERE id = ? AND deleted_at IS NULL`
 	
-	result, err := sqlDB.ExecContext(ctx, query, 
+	result, err := conn.ExecContext(ctx, query, 
 		admin.Username, admin.Email, admin.Role, admin.IsActive, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to update admin user: %w", err)
@@ -199,15 +184,59 @@ func (r *adminUserRepository) Update(ctx context.Context, id uint, admin *entity
 	return nil
 }
 
+func (r *adminUserRepository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	// A newly chosen password satisfies any pending forced change
+	query := `UPDATE admin_users SET password = ?, must_change_password = false, password_changed_at = ?, updated_at = ?
+			  WHERE id = ? AND deleted_at IS NULL`
+
+	now := time.Now()
+	result, err := conn.ExecContext(ctx, query, hashedPassword, now, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to update admin password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("admin user not found or already deleted")
+	}
+
+	return nil
+}
+
+func (r *adminUserRepository) RehashPassword(ctx context.Context, id uint, hashedPassword string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	// The password itself is unchanged, so its age and updated_at stay as they are
+	query := `UPDATE admin_users SET password = ? WHERE id = ? AND deleted_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, hashedPassword, id); err != nil {
+		return fmt.Errorf("failed to rehash admin password: %w", err)
+	}
+
+	return nil
+}
+
 func (r *adminUserRepository) Delete(ctx context.Context, id uint) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
 
 	query := `UPDATE admin_users SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	
-	result, err := sqlDB.ExecContext(ctx, query, time.Now(), id)
+	result, err := conn.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete admin user: %w", err)
 	}
@@ -225,7 +254,7 @@ func (r *adminUserRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *adminUserRepository) GetActiveAdmins(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -233,17 +262,17 @@ func (r *adminUserRepository) GetActiveAdmins(ctx context.Context, limit, offset
 	// Count total active admins
 	countQuery := `SELECT COUNT(*) FROM admin_users WHERE is_active = true AND deleted_at IS NULL`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = conn.QueryRowContext(ctx, countQuery).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count active admin users: %w", err)
 	}
 
 	// Get active admins with pagination
-	query := `SELECT id, username, email, password, role, is_active, created_at, updated_at 
+	query := `SELECT ` + adminUserColumns + ` 
 			  FROM admin_users WHERE is_active = true AND deleted_at IS NULL 
 			  ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, limit, offset)
+	rows, err := conn.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get active admin users: %w", err)
 	}
@@ -251,15 +280,11 @@ func (r *adminUserRepository) GetActiveAdmins(ctx context.Context, limit, offset
 
 	var admins []*entity.AdminUser
 	for rows.Next() {
-		var admin entity.AdminUser
-		err = rows.Scan(
-			&admin.ID, &admin.Username, &admin.Email, &admin.Password,
-			&admin.Role, &admin.IsActive, &admin.CreatedAt, &admin.UpdatedAt,
-		)
+		admin, err := scanAdminUser(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan active admin user: %w", err)
 		}
-		admins = append(admins, &admin)
+		admins = append(admins, admin)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -267,4 +292,144 @@ func (r *adminUserRepository) GetActiveAdmins(ctx context.Context, limit, offset
 	}
 
 	return admins, total, nil
-}
\ No newline at end of file
+}
+
+func (r *adminUserRepository) LockActiveSuperAdmins(ctx context.Context) ([]uint, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id FROM admin_users 
+			  WHERE role = ? AND is_active = true AND deleted_at IS NULL` + conn.Dialect.ForUpdate()
+
+	rows, err := conn.QueryContext(ctx, query, entity.AdminRoleSuperAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock super admins: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan super admin id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating super admins: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *adminUserRepository) RequirePasswordChange(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE admin_users SET must_change_password = true, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to require admin password change: %w", err)
+	}
+
+	return nil
+}
+
+func (r *adminUserRepository) SetTOTPSecret(ctx context.Context, id uint, secret string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	// A new secret has to be confirmed again before it protects the account
+	query := `UPDATE admin_users SET totp_secret = ?, totp_enabled = false, totp_last_step = 0, updated_at = ?
+			  WHERE id = ? AND deleted_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, secret, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to set admin totp secret: %w", err)
+	}
+
+	return nil
+}
+
+func (r *adminUserRepository) EnableTOTP(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE admin_users SET totp_enabled = true, updated_at = ?
+			  WHERE id = ? AND totp_secret IS NOT NULL AND deleted_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to enable admin totp: %w", err)
+	}
+
+	return nil
+}
+
+func (r *adminUserRepository) DisableTOTP(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE admin_users SET totp_secret = NULL, totp_enabled = false, totp_last_step = 0, updated_at = ?
+			  WHERE id = ? AND deleted_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to disable admin totp: %w", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep records step as the last accepted one. Only a step after the last accepted
+// one is recorded, so of two requests with the same code only one succeeds.
+func (r *adminUserRepository) UseTOTPStep(ctx context.Context, id uint, step int64) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE admin_users SET totp_last_step = ? WHERE id = ? AND totp_last_step < ? AND deleted_at IS NULL`
+
+	result, err := conn.ExecContext(ctx, query, step, id, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use admin totp step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// scanAdminUser reads one row selected with adminUserColumns
+func scanAdminUser(row rowScanner) (*entity.AdminUser, error) {
+	var admin entity.AdminUser
+	var totpSecret sql.NullString
+	var passwordChangedAt sql.NullTime
+
+	err := row.Scan(
+		&admin.ID, &admin.Username, &admin.Email, &admin.Password,
+		&admin.Role, &admin.IsActive, &admin.MustChangePassword, &passwordChangedAt,
+		&totpSecret, &admin.TOTPEnabled, &admin.TOTPLastStep, &admin.CreatedAt, &admin.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	admin.TOTPSecret = totpSecret.String
+	if passwordChangedAt.Valid {
+		admin.PasswordChangedAt = &passwordChangedAt.Time
+	}
+	return &admin, nil
+}
@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func createAdmin(t *testing.T, admins repository.AdminUserRepository, username string, role entity.AdminRole, active bool) *entity.AdminUser {
+	t.Helper()
+
+	admin := &entity.AdminUser{
+		Username: username,
+		Email:    username + "@example.com",
+		Password: "hash",
+		Role:     role,
+		IsActive: active,
+	}
+	if err := admins.Create(context.Background(), admin); err != nil {
+		t.Fatalf("failed to create admin %s: %v", username, err)
+	}
+	return admin
+}
+
+func TestAdminUserRepositoryCreate(t *testing.T) {
+	ctx := context.Background()
+	admins := repo.NewAdminUserRepository(dbtest.New(t))
+
+	for i := 1; i <= 3; i++ {
+		admin := createAdmin(t, admins, fmt.Sprintf("admin%d", i), entity.AdminRoleAdmin, true)
+		if admin.ID != uint(i) {
+			t.Errorf("admin%d got id %d, want %d", i, admin.ID, i)
+		}
+
+		stored, err := admins.GetByID(ctx, admin.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored == nil || stored.Username != admin.Username {
+			t.Errorf("GetByID(%d) = %+v, want admin %s", admin.ID, stored, admin.Username)
+		}
+	}
+}
+
+func TestLockActiveSuperAdmins(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	admins := repo.NewAdminUserRepository(db)
+
+	active := createAdmin(t, admins, "root", entity.AdminRoleSuperAdmin, true)
+	createAdmin(t, admins, "inactive", entity.AdminRoleSuperAdmin, false)
+	deleted := createAdmin(t, admins, "deleted", entity.AdminRoleSuperAdmin, true)
+	createAdmin(t, admins, "plain", entity.AdminRoleAdmin, true)
+	second := createAdmin(t, admins, "root2", entity.AdminRoleSuperAdmin, true)
+	if err := admins.Delete(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []uint
+	err := repo.NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		var err error
+		ids, err = admins.LockActiveSuperAdmins(ctx)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []uint{active.ID, second.ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("LockActiveSuperAdmins() = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyColumns is the column list read by every API key query, in scanAPIKey order
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, expires_at, last_used_at, rotated_at, revoked_at, created_at, updated_at`
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, expires_at, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		key.Name, key.Prefix, key.KeyHash, joinScopes(key.Scopes),
+		key.CreatedBy, key.ExpiresAt, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	key.ID = uint(id)
+	key.CreatedAt = now
+	key.UpdatedAt = now
+	return nil
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*entity.APIKey, error) {
+	return r.getOne(ctx, `WHERE id = ?`, id)
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	return r.getOne(ctx, `WHERE key_hash = ?`, keyHash)
+}
+
+func (r *apiKeyRepository) getOne(ctx context.Context, where string, args ...interface{}) (*entity.APIKey, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ` + where
+
+	key, err := scanAPIKey(conn.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context, limit, offset int) ([]*entity.APIKey, int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_keys`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count api keys: %w", err)
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	rows, err := conn.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*entity.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, total, nil
+}
+
+func (r *apiKeyRepository) Rotate(ctx context.Context, id uint, prefix, keyHash string) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE api_keys SET prefix = ?, key_hash = ?, rotated_at = ?, updated_at = ?
+			  WHERE id = ? AND revoked_at IS NULL`
+
+	now := time.Now()
+	result, err := conn.ExecContext(ctx, query, prefix, keyHash, now, now, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uint) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE api_keys SET revoked_at = ?, updated_at = ? WHERE id = ? AND revoked_at IS NULL`
+
+	now := time.Now()
+	result, err := conn.ExecContext(ctx, query, now, now, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, now, since time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE api_keys SET last_used_at = ? WHERE id = ? AND (last_used_at IS NULL OR last_used_at < ?)`
+
+	if _, err := conn.ExecContext(ctx, query, now, id, since); err != nil {
+		return fmt.Errorf("failed to record api key use: %w", err)
+	}
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var scopes string
+	var createdBy sql.NullInt64
+	var expiresAt, lastUsedAt, rotatedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &createdBy,
+		&expiresAt, &lastUsedAt, &rotatedAt, &revokedAt, &key.CreatedAt, &key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = splitScopes(scopes)
+	if createdBy.Valid {
+		id := uint(createdBy.Int64)
+		key.CreatedBy = &id
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if rotatedAt.Valid {
+		key.RotatedAt = &rotatedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
+
+// Scopes are stored space separated, as in an OAuth scope parameter
+func joinScopes(scopes []entity.Permission) string {
+	parts := make([]string, len(scopes))
+	for i, scope := range scopes {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, " ")
+}
+
+func splitScopes(scopes string) []entity.Permission {
+	fields := strings.Fields(scopes)
+	permissions := make([]entity.Permission, len(fields))
+	for i, field := range fields {
+		permissions[i] = entity.Permission(field)
+	}
+	return permissions
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// emailVerificationTokenColumns is the column list read by every token query, in scanEmailVerificationToken order
+const emailVerificationTokenColumns = `id, token_hash, mahasiswa_id, expires_at, used_at, created_at`
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) repository.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{
+		db: db,
+	}
+}
+
+func (r *emailVerificationTokenRepository) Create(ctx context.Context, token *entity.EmailVerificationToken) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO email_verification_tokens (token_hash, mahasiswa_id, expires_at, created_at)
+			  VALUES (?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query, token.TokenHash, token.MahasiswaID, token.ExpiresAt, now)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	token.ID = uint(id)
+	token.CreatedAt = now
+	return nil
+}
+
+func (r *emailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + emailVerificationTokenColumns + ` FROM email_verification_tokens WHERE token_hash = ?`
+
+	token, err := scanEmailVerificationToken(conn.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *emailVerificationTokenRepository) GetLatestByMahasiswaID(ctx context.Context, mahasiswaID uint) (*entity.EmailVerificationToken, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + emailVerificationTokenColumns + ` FROM email_verification_tokens
+			  WHERE mahasiswa_id = ? ORDER BY created_at DESC, id DESC LIMIT 1`
+
+	token, err := scanEmailVerificationToken(conn.QueryRowContext(ctx, query, mahasiswaID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest email verification token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *emailVerificationTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE email_verification_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`
+
+	result, err := conn.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark email verification token used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *emailVerificationTokenRepository) InvalidateForMahasiswa(ctx context.Context, mahasiswaID uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE email_verification_tokens SET used_at = ? WHERE mahasiswa_id = ? AND used_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), mahasiswaID); err != nil {
+		return fmt.Errorf("failed to invalidate email verification tokens: %w", err)
+	}
+	return nil
+}
+
+// scanEmailVerificationToken reads one row selected with emailVerificationTokenColumns
+func scanEmailVerificationToken(row rowScanner) (*entity.EmailVerificationToken, error) {
+	var token entity.EmailVerificationToken
+	var usedAt sql.NullTime
+
+	err := row.Scan(
+		&token.ID, &token.TokenHash, &token.MahasiswaID,
+		&token.ExpiresAt, &usedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
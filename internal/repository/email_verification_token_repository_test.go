@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func createMahasiswa(t *testing.T, mahasiswas repository.MahasiswaRepository, nim string) *entity.Mahasiswa {
+	t.Helper()
+
+	mahasiswa := &entity.Mahasiswa{
+		NIM:      nim,
+		Nama:     "Mahasiswa " + nim,
+		Jurusan:  "Teknik Informatika",
+		Angkatan: 2022,
+		Email:    nim + "@example.com",
+		Password: "hash",
+		Status:   entity.StatusMahasiswaActive,
+	}
+	if err := mahasiswas.Create(context.Background(), mahasiswa); err != nil {
+		t.Fatalf("failed to create mahasiswa %s: %v", nim, err)
+	}
+	return mahasiswa
+}
+
+func TestEmailVerificationTokenMarkUsed(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	tokens := repo.NewEmailVerificationTokenRepository(db)
+	mahasiswa := createMahasiswa(t, repo.NewMahasiswaRepository(db), "2201001")
+
+	token := &entity.EmailVerificationToken{
+		TokenHash:   "hash",
+		MahasiswaID: mahasiswa.ID,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	if err := tokens.Create(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []bool{true, false} {
+		used, err := tokens.MarkUsed(ctx, token.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if used != want {
+			t.Errorf("MarkUsed call %d = %v, want %v", i+1, used, want)
+		}
+	}
+}
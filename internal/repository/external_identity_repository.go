@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// externalIdentityColumns is the column list read by every identity query, in scanExternalIdentity order
+const externalIdentityColumns = `id, issuer, subject, user_type, user_id, email, created_at, last_login_at`
+
+type externalIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepository(db *gorm.DB) repository.ExternalIdentityRepository {
+	return &externalIdentityRepository{
+		db: db,
+	}
+}
+
+func (r *externalIdentityRepository) Create(ctx context.Context, identity *entity.ExternalIdentity) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO external_identities (issuer, subject, user_type, user_id, email, created_at, last_login_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		identity.Issuer, identity.Subject, identity.UserType, identity.UserID, identity.Email, now, identity.LastLoginAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create external identity: %w", err)
+	}
+
+	identity.ID = uint(id)
+	identity.CreatedAt = now
+	return nil
+}
+
+func (r *externalIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*entity.ExternalIdentity, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + externalIdentityColumns + ` FROM external_identities WHERE issuer = ? AND subject = ?`
+
+	identity, err := scanExternalIdentity(conn.QueryRowContext(ctx, query, issuer, subject))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get external identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *externalIdentityRepository) GetByUser(ctx context.Context, issuer, userType string, userID uint) (*entity.ExternalIdentity, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + externalIdentityColumns + ` FROM external_identities
+			  WHERE issuer = ? AND user_type = ? AND user_id = ? ORDER BY id LIMIT 1`
+
+	identity, err := scanExternalIdentity(conn.QueryRowContext(ctx, query, issuer, userType, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get external identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *externalIdentityRepository) TouchLogin(ctx context.Context, id uint, at time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE external_identities SET last_login_at = ? WHERE id = ?`
+
+	if _, err := conn.ExecContext(ctx, query, at, id); err != nil {
+		return fmt.Errorf("failed to update external identity: %w", err)
+	}
+	return nil
+}
+
+func (r *externalIdentityRepository) Delete(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM external_identities WHERE id = ?`
+
+	if _, err := conn.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to delete external identity: %w", err)
+	}
+	return nil
+}
+
+// scanExternalIdentity reads one row selected with externalIdentityColumns
+func scanExternalIdentity(row rowScanner) (*entity.ExternalIdentity, error) {
+	var identity entity.ExternalIdentity
+	var lastLoginAt sql.NullTime
+
+	err := row.Scan(
+		&identity.ID, &identity.Issuer, &identity.Subject, &identity.UserType,
+		&identity.UserID, &identity.Email, &identity.CreatedAt, &lastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+	return &identity, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+const graduationRequestColumns = `id, mahasiswa_id, tahun_lulus, no_telepon, alamat_alumni, document_ref,
+	status, review_reason, reviewed_by, reviewed_at, created_at, updated_at`
+
+type graduationRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewGraduationRequestRepository(db *gorm.DB) repository.GraduationRequestRepository {
+	return &graduationRequestRepository{
+		db: db,
+	}
+}
+
+func (r *graduationRequestRepository) Create(ctx context.Context, request *entity.GraduationRequest) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO graduation_requests (mahasiswa_id, tahun_lulus, no_telepon, alamat_alumni, document_ref, status, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		request.MahasiswaID, request.TahunLulus, request.NoTelepon, request.AlamatAlumni,
+		request.DocumentRef, request.Status, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create graduation request: %w", err)
+	}
+
+	request.ID = uint(id)
+	request.CreatedAt = now
+	request.UpdatedAt = now
+	return nil
+}
+
+func (r *graduationRequestRepository) GetByID(ctx context.Context, id uint) (*entity.GraduationRequest, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + graduationRequestColumns + ` FROM graduation_requests WHERE id = ?`
+
+	request, err := scanGraduationRequest(conn.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get graduation request: %w", err)
+	}
+	return request, nil
+}
+
+func (r *graduationRequestRepository) GetPendingByMahasiswa(ctx context.Context, mahasiswaID uint) (*entity.GraduationRequest, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + graduationRequestColumns + ` FROM graduation_requests
+			  WHERE mahasiswa_id = ? AND status = ? ORDER BY id DESC LIMIT 1`
+
+	request, err := scanGraduationRequest(conn.QueryRowContext(ctx, query, mahasiswaID, entity.GraduationRequestPending))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending graduation request: %w", err)
+	}
+	return request, nil
+}
+
+func (r *graduationRequestRepository) ListByMahasiswa(ctx context.Context, mahasiswaID uint) ([]*entity.GraduationRequest, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + graduationRequestColumns + ` FROM graduation_requests
+			  WHERE mahasiswa_id = ? ORDER BY created_at DESC, id DESC`
+
+	rows, err := conn.QueryContext(ctx, query, mahasiswaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graduation requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*entity.GraduationRequest{}
+	for rows.Next() {
+		request, err := scanGraduationRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan graduation request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating graduation requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (r *graduationRequestRepository) List(ctx context.Context, status entity.GraduationRequestStatus, limit, offset int) ([]*entity.GraduationRequest, int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := ""
+	var args []interface{}
+	if status != "" {
+		where = " WHERE status = ?"
+		args = append(args, status)
+	}
+
+	var total int64
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM graduation_requests`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count graduation requests: %w", err)
+	}
+
+	query := `SELECT ` + graduationRequestColumns + ` FROM graduation_requests` + where +
+		` ORDER BY created_at ASC, id ASC LIMIT ? OFFSET ?`
+
+	rows, err := conn.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list graduation requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*entity.GraduationRequest{}
+	for rows.Next() {
+		request, err := scanGraduationRequest(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan graduation request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating graduation requests: %w", err)
+	}
+
+	return requests, total, nil
+}
+
+func (r *graduationRequestRepository) Review(ctx context.Context, request *entity.GraduationRequest) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE graduation_requests SET status = ?, review_reason = ?, reviewed_by = ?, reviewed_at = ?, updated_at = ?
+			  WHERE id = ? AND status = ?`
+
+	now := time.Now()
+	result, err := conn.ExecContext(ctx, query,
+		request.Status, request.ReviewReason, request.ReviewedBy, now, now,
+		request.ID, entity.GraduationRequestPending,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to review graduation request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	request.ReviewedAt = &now
+	request.UpdatedAt = now
+	return true, nil
+}
+
+// scanGraduationRequest reads one row selected with graduationRequestColumns
+func scanGraduationRequest(row rowScanner) (*entity.GraduationRequest, error) {
+	var request entity.GraduationRequest
+	var reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&request.ID, &request.MahasiswaID, &request.TahunLulus, &request.NoTelepon,
+		&request.AlamatAlumni, &request.DocumentRef, &request.Status, &request.ReviewReason,
+		&reviewedBy, &reviewedAt, &request.CreatedAt, &request.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewedBy.Valid {
+		id := uint(reviewedBy.Int64)
+		request.ReviewedBy = &id
+	}
+	if reviewedAt.Valid {
+		request.ReviewedAt = &reviewedAt.Time
+	}
+	return &request, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type impersonationLogRepository struct {
+	db *gorm.DB
+}
+
+func NewImpersonationLogRepository(db *gorm.DB) repository.ImpersonationLogRepository {
+	return &impersonationLogRepository{
+		db: db,
+	}
+}
+
+func (r *impersonationLogRepository) Create(ctx context.Context, log *entity.ImpersonationLog) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO impersonation_logs (admin_id, admin_username, mahasiswa_id, role, reason, token_id, ip_address, user_agent, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		log.AdminID, log.AdminUsername, log.MahasiswaID, log.Role, log.Reason,
+		log.TokenID, log.IPAddress, log.UserAgent, log.ExpiresAt, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create impersonation log: %w", err)
+	}
+
+	log.ID = uint(id)
+	log.CreatedAt = now
+	return nil
+}
+
+func (r *impersonationLogRepository) List(ctx context.Context, filter repository.ImpersonationLogFilter, limit, offset int) ([]*entity.ImpersonationLog, int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	if filter.AdminID != 0 {
+		conditions = append(conditions, "admin_id = ?")
+		args = append(args, filter.AdminID)
+	}
+	if filter.MahasiswaID != 0 {
+		conditions = append(conditions, "mahasiswa_id = ?")
+		args = append(args, filter.MahasiswaID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM impersonation_logs`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count impersonation logs: %w", err)
+	}
+
+	query := `SELECT id, admin_id, admin_username, mahasiswa_id, role, reason, token_id, ip_address, user_agent, expires_at, created_at
+			  FROM impersonation_logs` + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	rows, err := conn.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list impersonation logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []*entity.ImpersonationLog{}
+	for rows.Next() {
+		var log entity.ImpersonationLog
+		if err := rows.Scan(
+			&log.ID, &log.AdminID, &log.AdminUsername, &log.MahasiswaID, &log.Role, &log.Reason,
+			&log.TokenID, &log.IPAddress, &log.UserAgent, &log.ExpiresAt, &log.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan impersonation log: %w", err)
+		}
+		logs = append(logs, &log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating impersonation logs: %w", err)
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+	"Fix-Go-Fiber-Backend/pkg/jwt"
+
+	"gorm.io/gorm"
+)
+
+type jwtSigningKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewJWTSigningKeyRepository(db *gorm.DB) repository.JWTSigningKeyRepository {
+	return &jwtSigningKeyRepository{
+		db: db,
+	}
+}
+
+func (r *jwtSigningKeyRepository) List(ctx context.Context) ([]*entity.JWTSigningKey, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, kid, algorithm, private_key, created_at, retired_at
+			  FROM jwt_signing_keys ORDER BY created_at, id`
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jwt signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.JWTSigningKey
+	for rows.Next() {
+		var key entity.JWTSigningKey
+		var retiredAt sql.NullTime
+		if err := rows.Scan(
+			&key.ID, &key.KeyID, &key.Algorithm, &key.PrivateKey, &key.CreatedAt, &retiredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan jwt signing key: %w", err)
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
+		keys = append(keys, &key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jwt signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *jwtSigningKeyRepository) Create(ctx context.Context, key *entity.JWTSigningKey) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO jwt_signing_keys (kid, algorithm, private_key, created_at) VALUES (?, ?, ?, ?)`
+
+	id, err := conn.InsertContext(ctx, query, key.KeyID, key.Algorithm, key.PrivateKey, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create jwt signing key: %w", err)
+	}
+
+	key.ID = uint(id)
+	return nil
+}
+
+func (r *jwtSigningKeyRepository) RetireCreatedBefore(ctx context.Context, before, retiredAt time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE jwt_signing_keys SET retired_at = ? WHERE created_at < ? AND retired_at IS NULL`
+	if _, err := conn.ExecContext(ctx, query, retiredAt, before); err != nil {
+		return fmt.Errorf("failed to retire jwt signing keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jwtSigningKeyRepository) DeleteRetiredBefore(ctx context.Context, before time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM jwt_signing_keys WHERE retired_at < ?`, before); err != nil {
+		return fmt.Errorf("failed to delete retired jwt signing keys: %w", err)
+	}
+
+	return nil
+}
+
+// jwtKeyStore keeps the keys of a *jwt.JWTUtil in a JWTSigningKeyRepository
+type jwtKeyStore struct {
+	repo repository.JWTSigningKeyRepository
+}
+
+func NewJWTKeyStore(repo repository.JWTSigningKeyRepository) jwt.KeyStore {
+	return &jwtKeyStore{
+		repo: repo,
+	}
+}
+
+func (s *jwtKeyStore) List(ctx context.Context) ([]*jwt.StoredKey, error) {
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]*jwt.StoredKey, 0, len(keys))
+	for _, key := range keys {
+		stored = append(stored, &jwt.StoredKey{
+			KeyID:      key.KeyID,
+			Algorithm:  key.Algorithm,
+			PrivateKey: key.PrivateKey,
+			CreatedAt:  key.CreatedAt,
+			RetiredAt:  key.RetiredAt,
+		})
+	}
+	return stored, nil
+}
+
+func (s *jwtKeyStore) Create(ctx context.Context, key *jwt.StoredKey) error {
+	return s.repo.Create(ctx, &entity.JWTSigningKey{
+		KeyID:      key.KeyID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: key.PrivateKey,
+		CreatedAt:  key.CreatedAt,
+	})
+}
+
+func (s *jwtKeyStore) RetireCreatedBefore(ctx context.Context, before, retiredAt time.Time) error {
+	return s.repo.RetireCreatedBefore(ctx, before, retiredAt)
+}
+
+func (s *jwtKeyStore) DeleteRetiredBefore(ctx context.Context, before time.Time) error {
+	return s.repo.DeleteRetiredBefore(ctx, before)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *loginAttemptRepository) Create(ctx context.Context, attempt *entity.LoginAttempt) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO login_attempts (user_type, identifier, ip_address, outcome, created_at)
+			  VALUES (?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query, attempt.UserType, attempt.Identifier, attempt.IPAddress, attempt.Outcome, now)
+	if err != nil {
+		return fmt.Errorf("failed to create login attempt: %w", err)
+	}
+
+	attempt.ID = uint(id)
+	attempt.CreatedAt = now
+	return nil
+}
+
+func (r *loginAttemptRepository) List(ctx context.Context, filter repository.LoginAttemptFilter, limit, offset int) ([]*entity.LoginAttempt, int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	for column, value := range map[string]string{
+		"user_type":  filter.UserType,
+		"identifier": filter.Identifier,
+		"ip_address": filter.IPAddress,
+		"outcome":    filter.Outcome,
+	} {
+		if value != "" {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM login_attempts`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count login attempts: %w", err)
+	}
+
+	query := `SELECT id, user_type, identifier, ip_address, outcome, created_at
+			  FROM login_attempts` + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	rows, err := conn.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*entity.LoginAttempt
+	for rows.Next() {
+		var attempt entity.LoginAttempt
+		if err := rows.Scan(
+			&attempt.ID, &attempt.UserType, &attempt.Identifier,
+			&attempt.IPAddress, &attempt.Outcome, &attempt.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan login attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating login attempts: %w", err)
+	}
+
+	return attempts, total, nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// loginLockoutColumns is the column list read by every lockout query, in scanLoginLockout order
+const loginLockoutColumns = `id, scope, subject, failed_count, last_failed_at, locked_until, created_at, updated_at`
+
+type loginLockoutRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginLockoutRepository(db *gorm.DB) repository.LoginLockoutRepository {
+	return &loginLockoutRepository{
+		db: db,
+	}
+}
+
+func (r *loginLockoutRepository) Get(ctx context.Context, scope, subject string) (*entity.LoginLockout, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.get(ctx, conn, scope, subject, "")
+}
+
+func (r *loginLockoutRepository) GetByID(ctx context.Context, id uint) (*entity.LoginLockout, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + loginLockoutColumns + ` FROM login_lockouts WHERE id = ?`
+
+	lockout, err := scanLoginLockout(conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// RegisterFailure locks the lockout row while it counts, so it belongs in a transaction.
+// Two first failures of a subject at once make one of the inserts fail; callers retry.
+func (r *loginLockoutRepository) RegisterFailure(ctx context.Context, scope, subject string, at, windowStart time.Time) (*entity.LoginLockout, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	lockout, err := r.get(ctx, conn, scope, subject, conn.Dialect.ForUpdate())
+	if err != nil {
+		return nil, err
+	}
+
+	if lockout == nil {
+		query := `INSERT INTO login_lockouts (scope, subject, failed_count, last_failed_at, created_at, updated_at)
+				  VALUES (?, ?, ?, ?, ?, ?)`
+
+		id, err := conn.InsertContext(ctx, query, scope, subject, 1, at, at, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create login lockout: %w", err)
+		}
+
+		return &entity.LoginLockout{
+			ID:           uint(id),
+			Scope:        scope,
+			Subject:      subject,
+			FailedCount:  1,
+			LastFailedAt: at,
+			CreatedAt:    at,
+			UpdatedAt:    at,
+		}, nil
+	}
+
+	if lockout.LastFailedAt.Before(windowStart) {
+		lockout.FailedCount = 1
+	} else {
+		lockout.FailedCount++
+	}
+	lockout.LastFailedAt = at
+	lockout.UpdatedAt = at
+
+	query := `UPDATE login_lockouts SET failed_count = ?, last_failed_at = ?, updated_at = ? WHERE id = ?`
+	if _, err := conn.ExecContext(ctx, query, lockout.FailedCount, at, at, lockout.ID); err != nil {
+		return nil, fmt.Errorf("failed to update login lockout: %w", err)
+	}
+
+	return lockout, nil
+}
+
+func (r *loginLockoutRepository) SetLockedUntil(ctx context.Context, id uint, lockedUntil *time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE login_lockouts SET locked_until = ?, updated_at = ? WHERE id = ?`
+	if _, err := conn.ExecContext(ctx, query, lockedUntil, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to set login lockout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginLockoutRepository) ListLocked(ctx context.Context, now time.Time, limit, offset int) ([]*entity.LoginLockout, int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM login_lockouts WHERE locked_until > ?`
+	if err := conn.QueryRowContext(ctx, countQuery, now).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count login lockouts: %w", err)
+	}
+
+	query := `SELECT ` + loginLockoutColumns + ` FROM login_lockouts
+			  WHERE locked_until > ? ORDER BY locked_until DESC LIMIT ? OFFSET ?`
+
+	rows, err := conn.QueryContext(ctx, query, now, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list login lockouts: %w", err)
+	}
+	defer rows.Close()
+
+	var lockouts []*entity.LoginLockout
+	for rows.Next() {
+		lockout, err := scanLoginLockout(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan login lockout: %w", err)
+		}
+		lockouts = append(lockouts, lockout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating login lockouts: %w", err)
+	}
+
+	return lockouts, total, nil
+}
+
+func (r *loginLockoutRepository) Delete(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM login_lockouts WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete login lockout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginLockoutRepository) DeleteBySubject(ctx context.Context, scope, subject string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM login_lockouts WHERE scope = ? AND subject = ?`
+	if _, err := conn.ExecContext(ctx, query, scope, subject); err != nil {
+		return fmt.Errorf("failed to delete login lockout: %w", err)
+	}
+
+	return nil
+}
+
+// get reads the lockout of a subject; lock is appended to the query, see Dialect.ForUpdate
+func (r *loginLockoutRepository) get(ctx context.Context, conn *database.Conn, scope, subject, lock string) (*entity.LoginLockout, error) {
+	query := `SELECT ` + loginLockoutColumns + ` FROM login_lockouts WHERE scope = ? AND subject = ?` + lock
+
+	lockout, err := scanLoginLockout(conn.QueryRowContext(ctx, query, scope, subject))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// scanLoginLockout reads one row selected with loginLockoutColumns
+func scanLoginLockout(row rowScanner) (*entity.LoginLockout, error) {
+	var lockout entity.LoginLockout
+	var lockedUntil sql.NullTime
+
+	err := row.Scan(
+		&lockout.ID, &lockout.Scope, &lockout.Subject, &lockout.FailedCount,
+		&lockout.LastFailedAt, &lockedUntil, &lockout.CreatedAt, &lockout.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedUntil.Valid {
+		lockout.LockedUntil = &lockedUntil.Time
+	}
+	return &lockout, nil
+}
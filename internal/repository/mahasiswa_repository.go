@@ -10,10 +10,14 @@ import (
 
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
 
 	"gorm.io/gorm"
 )
 
+// mahasiswaColumns is the column list read by every mahasiswa query, in scanMahasiswa order
+const mahasiswaColumns = `id, nim, nama, jurusan, angkatan, email, password, status, tahun_lulus, no_telepon, alamat_alumni, email_verified_at, created_at, updated_at`
+
 type mahasiswaRepository struct {
 	db *gorm.DB
 }
@@ -25,30 +29,30 @@ func NewMahasiswaRepository(db *gorm.DB) repository.MahasiswaRepository {
 }
 
 func (r *mahasiswaRepository) Create(ctx context.Context, mahasiswa *entity.Mahasiswa) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
 
-	query := `INSERT INTO mahasiswas (nim, nama, jurusan, angkatan, email, password, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO mahasiswas (nim, nama, jurusan, angkatan, email, password, status, tahun_lulus, no_telepon, alamat_alumni, email_verified_at, created_at, updated_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	
+	if mahasiswa.Status == "" {
+		mahasiswa.Status = entity.StatusMahasiswaActive
+	}
+
 	now := time.Now()
-	result, err := sqlDB.ExecContext(ctx, query,
+	id, err := conn.InsertContext(ctx, query,
 		mahasiswa.NIM, mahasiswa.Nama, mahasiswa.Jurusan,
 		mahasiswa.Angkatan, mahasiswa.Email, mahasiswa.Password,
-		now, now,
+		mahasiswa.Status, mahasiswa.TahunLulus, mahasiswa.NoTelepon, mahasiswa.AlamatAlumni,
+		mahasiswa.EmailVerifiedAt, now, now,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create mahasiswa: %w", err)
 	}
 	
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %w", err)
-	}
-	
 	mahasiswa.ID = uint(id)
 	mahasiswa.CreatedAt = now
 	mahasiswa.UpdatedAt = now
@@ -56,21 +60,15 @@ func (r *mahasiswaRepository) Create(ctx context.Context, mahasiswa *entity.Maha
 }
 
 func (r *mahasiswaRepository) GetByID(ctx context.Context, id uint) (*entity.Mahasiswa, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, nim, nama, jurusan, angkatan, email, password, created_at, updated_at 
+	query := `SELECT ` + mahasiswaColumns + ` 
 			  FROM mahasiswas WHERE id = ? AND deleted_at IS NULL`
 	
-	var mahasiswa entity.Mahasiswa
-	err = sqlDB.QueryRowContext(ctx, query, id).Scan(
-		&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
-		&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
-		&mahasiswa.Password, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
-	)
-
+	mahasiswa, err := scanMahasiswa(conn.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -78,25 +76,39 @@ func (r *mahasiswaRepository) GetByID(ctx context.Context, id uint) (*entity.Mah
 		return nil, fmt.Errorf("failed to get mahasiswa by ID: %w", err)
 	}
 
-	return &mahasiswa, nil
+	return mahasiswa, nil
+}
+
+func (r *mahasiswaRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entity.Mahasiswa, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + mahasiswaColumns + `
+			  FROM mahasiswas WHERE id = ? AND deleted_at IS NULL` + conn.Dialect.ForUpdate()
+
+	mahasiswa, err := scanMahasiswa(conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock mahasiswa: %w", err)
+	}
+
+	return mahasiswa, nil
 }
 
 func (r *mahasiswaRepository) GetByNIM(ctx context.Context, nim string) (*entity.Mahasiswa, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, nim, nama, jurusan, angkatan, email, password, created_at, updated_at 
+	query := `SELECT ` + mahasiswaColumns + ` 
 			  FROM mahasiswas WHERE nim = ? AND deleted_at IS NULL`
 	
-	var mahasiswa entity.Mahasiswa
-	err = sqlDB.QueryRowContext(ctx, query, nim).Scan(
-		&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
-		&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
-		&mahasiswa.Password, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
-	)
-
+	mahasiswa, err := scanMahasiswa(conn.QueryRowContext(ctx, query, nim))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -104,25 +116,19 @@ func (r *mahasiswaRepository) GetByNIM(ctx context.Context, nim string) (*entity
 		return nil, fmt.Errorf("failed to get mahasiswa by NIM: %w", err)
 	}
 
-	return &mahasiswa, nil
+	return mahasiswa, nil
 }
 
 func (r *mahasiswaRepository) GetByEmail(ctx context.Context, email string) (*entity.Mahasiswa, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, nim, nama, jurusan, angkatan, email, password, created_at, updated_at 
+	query := `SELECT ` + mahasiswaColumns + ` 
 			  FROM mahasiswas WHERE email = ? AND deleted_at IS NULL`
 	
-	var mahasiswa entity.Mahasiswa
-	err = sqlDB.QueryRowContext(ctx, query, email).Scan(
-		&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
-		&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
-		&mahasiswa.Password, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
-	)
-
+	mahasiswa, err := scanMahasiswa(conn.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -130,7 +136,7 @@ func (r *mahasiswaRepository) GetByEmail(ctx context.Context, email string) (*en
 		return nil, fmt.Errorf("failed to get mahasiswa by email: %w", err)
 	}
 
-	return &mahasiswa, nil
+	return mahasiswa, nil
 }
 
 // GetByEmail without context for auth service compatibility
@@ -139,7 +145,7 @@ func (r *mahasiswaRepository) GetByEmailSimple(email string) (*entity.Mahasiswa,
 }
 
 func (r *mahasiswaRepository) GetAll(ctx context.Context, limit, offset int) ([]*entity.Mahasiswa, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -147,17 +153,17 @@ func (r *mahasiswaRepository) GetAll(ctx context.Context, limit, offset int) ([]
 	// Count total
 	countQuery := `SELECT COUNT(*) FROM mahasiswas WHERE deleted_at IS NULL`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = conn.QueryRowContext(ctx, countQuery).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count mahasiswa: %w", err)
 	}
 
 	// Get data with pagination
-	query := `SELECT id, nim, nama, jurusan, angkatan, email, password, created_at, updated_at 
+	query := `SELECT ` + mahasiswaColumns + ` 
 			  FROM mahasiswas WHERE deleted_at IS NULL 
 			  ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, limit, offset)
+	rows, err := conn.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get mahasiswa list: %w", err)
 	}
@@ -165,16 +171,11 @@ func (r *mahasiswaRepository) GetAll(ctx context.Context, limit, offset int) ([]
 
 	var mahasiswas []*entity.Mahasiswa
 	for rows.Next() {
-		var mahasiswa entity.Mahasiswa
-		err = rows.Scan(
-			&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
-			&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
-			&mahasiswa.Password, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
-		)
+		mahasiswa, err := scanMahasiswa(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan mahasiswa: %w", err)
 		}
-		mahasiswas = append(mahasiswas, &mahasiswa)
+		mahasiswas = append(mahasiswas, mahasiswa)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -185,7 +186,7 @@ func (r *mahasiswaRepository) GetAll(ctx context.Context, limit, offset int) ([]
 }
 
 func (r *mahasiswaRepository) Update(ctx context.Context, id uint, mahasiswa *entity.Mahasiswa) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -210,13 +211,31 @@ func (r *mahasiswaRepository) Update(ctx context.Context, id uint, mahasiswa *en
 		args = append(args, mahasiswa.Angkatan)
 	}
 	if mahasiswa.Email != "" {
-		setParts = append(setParts, "email = ?")
-		args = append(args, mahasiswa.Email)
+		// A new email is unverified. This is set before the email because MySQL evaluates
+		// the assignments in order, where the others compare against the old row.
+		setParts = append(setParts, "email_verified_at = CASE WHEN email = ? THEN email_verified_at ELSE NULL END", "email = ?")
+		args = append(args, mahasiswa.Email, mahasiswa.Email)
 	}
 	if mahasiswa.Password != "" {
 		setParts = append(setParts, "password = ?")
 		args = append(args, mahasiswa.Password)
 	}
+	if mahasiswa.Status != "" {
+		setParts = append(setParts, "status = ?")
+		args = append(args, mahasiswa.Status)
+	}
+	if mahasiswa.TahunLulus != nil {
+		setParts = append(setParts, "tahun_lulus = ?")
+		args = append(args, *mahasiswa.TahunLulus)
+	}
+	if mahasiswa.NoTelepon != "" {
+		setParts = append(setParts, "no_telepon = ?")
+		args = append(args, mahasiswa.NoTelepon)
+	}
+	if mahasiswa.AlamatAlumni != "" {
+		setParts = append(setParts, "alamat_alumni = ?")
+		args = append(args, mahasiswa.AlamatAlumni)
+	}
 
 	if len(setParts) == 0 {
 		return errors.New("no fields to update")
@@ -232,7 +251,7 @@ func (r *mahasiswaRepository) Update(ctx context.Context, id uint, mahasiswa *en
 	query := fmt.Sprintf("UPDATE mahasiswas SET %s WHERE id = ? AND deleted_at IS NULL", 
 		strings.Join(setParts, ", "))
 
-	result, err := sqlDB.ExecContext(ctx, query, args...)
+	result, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update mahasiswa: %w", err)
 	}
@@ -249,15 +268,63 @@ func (r *mahasiswaRepository) Update(ctx context.Context, id uint, mahasiswa *en
 	return nil
 }
 
+func (r *mahasiswaRepository) UpdateStatus(ctx context.Context, mahasiswa *entity.Mahasiswa, from entity.StatusMahasiswa) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	// The status condition makes concurrent transitions of the same mahasiswa fail instead of overwriting each other
+	query := `UPDATE mahasiswas SET status = ?, tahun_lulus = ?, no_telepon = ?, alamat_alumni = ?, updated_at = ? 
+			  WHERE id = ? AND status = ? AND deleted_at IS NULL`
+
+	now := time.Now()
+	result, err := conn.ExecContext(ctx, query,
+		mahasiswa.Status, mahasiswa.TahunLulus, mahasiswa.NoTelepon, mahasiswa.AlamatAlumni, now,
+		mahasiswa.ID, from,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update mahasiswa status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("mahasiswa not found or its status was changed concurrently")
+	}
+
+	mahasiswa.UpdatedAt = now
+	return nil
+}
+
+func (r *mahasiswaRepository) MarkEmailVerified(ctx context.Context, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE mahasiswas SET email_verified_at = ?, updated_at = ? 
+			  WHERE id = ? AND email_verified_at IS NULL AND deleted_at IS NULL`
+
+	now := time.Now()
+	if _, err := conn.ExecContext(ctx, query, now, now, id); err != nil {
+		return fmt.Errorf("failed to mark mahasiswa email verified: %w", err)
+	}
+	return nil
+}
+
 func (r *mahasiswaRepository) Delete(ctx context.Context, id uint) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
 
 	query := `UPDATE mahasiswas SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	
-	result, err := sqlDB.ExecContext(ctx, query, time.Now(), id)
+	result, err := conn.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete mahasiswa: %w", err)
 	}
@@ -275,7 +342,7 @@ func (r *mahasiswaRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *mahasiswaRepository) Search(ctx context.Context, query string, limit, offset int) ([]*entity.Mahasiswa, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -289,13 +356,13 @@ func (r *mahasiswaRepository) Search(ctx context.Context, query string, limit, o
 					 LOWER(jurusan) LIKE ? OR LOWER(email) LIKE ?
 				 )`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countSQL, searchQuery, searchQuery, searchQuery, searchQuery).Scan(&total)
+	err = conn.QueryRowContext(ctx, countSQL, searchQuery, searchQuery, searchQuery, searchQuery).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
 	}
 
 	// Get data with pagination
-	dataSQL := `SELECT id, nim, nama, jurusan, angkatan, email, password, created_at, updated_at 
+	dataSQL := `SELECT ` + mahasiswaColumns + ` 
 				FROM mahasiswas 
 				WHERE deleted_at IS NULL AND (
 					LOWER(nim) LIKE ? OR LOWER(nama) LIKE ? OR 
@@ -303,7 +370,7 @@ func (r *mahasiswaRepository) Search(ctx context.Context, query string, limit, o
 				)
 				ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, dataSQL, searchQuery, searchQuery, searchQuery, searchQuery, limit, offset)
+	rows, err := conn.QueryContext(ctx, dataSQL, searchQuery, searchQuery, searchQuery, searchQuery, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search mahasiswa: %w", err)
 	}
@@ -311,16 +378,11 @@ func (r *mahasiswaRepository) Search(ctx context.Context, query string, limit, o
 
 	var mahasiswas []*entity.Mahasiswa
 	for rows.Next() {
-		var mahasiswa entity.Mahasiswa
-		err = rows.Scan(
-			&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
-			&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
-			&mahasiswa.Password, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
-		)
+		mahasiswa, err := scanMahasiswa(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan mahasiswa: %w", err)
 		}
-		mahasiswas = append(mahasiswas, &mahasiswa)
+		mahasiswas = append(mahasiswas, mahasiswa)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -328,4 +390,29 @@ func (r *mahasiswaRepository) Search(ctx context.Context, query string, limit, o
 	}
 
 	return mahasiswas, total, nil
+}
+
+// scanMahasiswa reads one row selected with mahasiswaColumns
+func scanMahasiswa(row rowScanner) (*entity.Mahasiswa, error) {
+	var mahasiswa entity.Mahasiswa
+	var noTelepon, alamatAlumni sql.NullString
+	var emailVerifiedAt sql.NullTime
+
+	err := row.Scan(
+		&mahasiswa.ID, &mahasiswa.NIM, &mahasiswa.Nama,
+		&mahasiswa.Jurusan, &mahasiswa.Angkatan, &mahasiswa.Email,
+		&mahasiswa.Password, &mahasiswa.Status, &mahasiswa.TahunLulus,
+		&noTelepon, &alamatAlumni, &emailVerifiedAt, &mahasiswa.CreatedAt, &mahasiswa.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailVerifiedAt.Valid {
+		mahasiswa.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+
+	mahasiswa.NoTelepon = noTelepon.String
+	mahasiswa.AlamatAlumni = alamatAlumni.String
+	return &mahasiswa, nil
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestMahasiswaGetByIDForUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	mahasiswas := repo.NewMahasiswaRepository(db)
+
+	mahasiswa := createMahasiswa(t, mahasiswas, "2201001")
+	deleted := createMahasiswa(t, mahasiswas, "2201002")
+	if err := mahasiswas.Delete(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	err := repo.NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		locked, err := mahasiswas.GetByIDForUpdate(ctx, mahasiswa.ID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || locked.NIM != mahasiswa.NIM {
+			t.Errorf("GetByIDForUpdate(%d) = %+v, want mahasiswa %s", mahasiswa.ID, locked, mahasiswa.NIM)
+		}
+
+		for _, id := range []uint{deleted.ID, 999} {
+			locked, err := mahasiswas.GetByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			if locked != nil {
+				t.Errorf("GetByIDForUpdate(%d) = %+v, want nil", id, locked)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type mahasiswaStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewMahasiswaStatusHistoryRepository(db *gorm.DB) repository.MahasiswaStatusHistoryRepository {
+	return &mahasiswaStatusHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *mahasiswaStatusHistoryRepository) Create(ctx context.Context, history *entity.MahasiswaStatusHistory) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO mahasiswa_status_history (mahasiswa_id, transition, from_status, to_status, reason, actor_id, actor_role, created_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		history.MahasiswaID, history.Transition, history.FromStatus, history.ToStatus,
+		history.Reason, history.ActorID, history.ActorRole, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	history.ID = uint(id)
+	history.CreatedAt = now
+	return nil
+}
+
+func (r *mahasiswaStatusHistoryRepository) GetByMahasiswaID(ctx context.Context, mahasiswaID uint) ([]*entity.MahasiswaStatusHistory, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, mahasiswa_id, transition, from_status, to_status, reason, actor_id, actor_role, created_at 
+			  FROM mahasiswa_status_history WHERE mahasiswa_id = ? 
+			  ORDER BY created_at ASC, id ASC`
+
+	rows, err := conn.QueryContext(ctx, query, mahasiswaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer rows.Close()
+
+	histories := []*entity.MahasiswaStatusHistory{}
+	for rows.Next() {
+		var history entity.MahasiswaStatusHistory
+		var actorID sql.NullInt64
+
+		err := rows.Scan(
+			&history.ID, &history.MahasiswaID, &history.Transition, &history.FromStatus,
+			&history.ToStatus, &history.Reason, &actorID, &history.ActorRole, &history.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status history: %w", err)
+		}
+
+		if actorID.Valid {
+			id := uint(actorID.Int64)
+			history.ActorID = &id
+		}
+		histories = append(histories, &history)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status history rows: %w", err)
+	}
+
+	return histories, nil
+}
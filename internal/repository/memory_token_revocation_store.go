@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+)
+
+// memoryTokenRevocationStore keeps revocations in process memory. They are lost on
+// restart and not shared between instances; use the database store for that.
+type memoryTokenRevocationStore struct {
+	mu      sync.RWMutex
+	tokens  map[string]time.Time // jti -> token expiry
+	cutoffs map[string]time.Time // user key -> revoked before
+}
+
+func NewMemoryTokenRevocationStore() repository.TokenRevocationStore {
+	return &memoryTokenRevocationStore{
+		tokens:  map[string]time.Time{},
+		cutoffs: map[string]time.Time{},
+	}
+}
+
+func (s *memoryTokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Forget tokens that have expired anyway so the map does not grow forever
+	now := time.Now()
+	for id, exp := range s.tokens {
+		if now.After(exp) {
+			delete(s.tokens, id)
+		}
+	}
+
+	s.tokens[tokenID] = expiresAt
+	return nil
+}
+
+func (s *memoryTokenRevocationStore) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.tokens[tokenID]
+	return ok, nil
+}
+
+func (s *memoryTokenRevocationStore) RevokeUserTokens(ctx context.Context, userType string, userID uint, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cutoffs[userKey(userType, userID)] = before
+	return nil
+}
+
+func (s *memoryTokenRevocationStore) UserTokensRevokedBefore(ctx context.Context, userType string, userID uint) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cutoffs[userKey(userType, userID)], nil
+}
+
+func userKey(userType string, userID uint) string {
+	return fmt.Sprintf("%s:%d", userType, userID)
+}
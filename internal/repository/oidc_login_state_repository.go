@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// oidcLoginStateColumns is the column list read by every login state query, in scanOIDCLoginState order
+const oidcLoginStateColumns = `id, state_hash, nonce, code_verifier, device_id, expires_at, created_at`
+
+type oidcLoginStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCLoginStateRepository(db *gorm.DB) repository.OIDCLoginStateRepository {
+	return &oidcLoginStateRepository{
+		db: db,
+	}
+}
+
+func (r *oidcLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, device_id, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query, state.StateHash, state.Nonce, state.CodeVerifier, state.DeviceID, state.ExpiresAt, now)
+	if err != nil {
+		return fmt.Errorf("failed to create oidc login state: %w", err)
+	}
+
+	state.ID = uint(id)
+	state.CreatedAt = now
+	return nil
+}
+
+func (r *oidcLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + oidcLoginStateColumns + ` FROM oidc_login_states WHERE state_hash = ?`
+
+	state, err := scanOIDCLoginState(conn.QueryRowContext(ctx, query, stateHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get oidc login state: %w", err)
+	}
+
+	// Only the request that deletes the row may use it
+	result, err := conn.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE id = ?`, state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume oidc login state: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+func (r *oidcLoginStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM oidc_login_states WHERE expires_at < ?`
+
+	if _, err := conn.ExecContext(ctx, query, now); err != nil {
+		return fmt.Errorf("failed to delete expired oidc login states: %w", err)
+	}
+	return nil
+}
+
+// scanOIDCLoginState reads one row selected with oidcLoginStateColumns
+func scanOIDCLoginState(row rowScanner) (*entity.OIDCLoginState, error) {
+	var state entity.OIDCLoginState
+
+	err := row.Scan(
+		&state.ID, &state.StateHash, &state.Nonce, &state.CodeVerifier,
+		&state.DeviceID, &state.ExpiresAt, &state.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
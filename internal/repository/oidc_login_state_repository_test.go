@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestOIDCLoginStateConsume(t *testing.T) {
+	ctx := context.Background()
+	states := repo.NewOIDCLoginStateRepository(dbtest.New(t))
+
+	state := &entity.OIDCLoginState{
+		StateHash:    "hash",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+	if err := states.Create(ctx, state); err != nil {
+		t.Fatal(err)
+	}
+
+	consumed, err := states.Consume(ctx, "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consumed == nil || consumed.ID != state.ID || consumed.Nonce != "nonce" || consumed.CodeVerifier != "verifier" {
+		t.Fatalf("first Consume() = %+v, want state %d", consumed, state.ID)
+	}
+
+	if consumed, err = states.Consume(ctx, "hash"); err != nil || consumed != nil {
+		t.Errorf("second Consume() = %+v, %v, want nil, nil", consumed, err)
+	}
+}
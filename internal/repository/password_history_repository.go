@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository(db *gorm.DB) repository.PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *passwordHistoryRepository) Create(ctx context.Context, entry *entity.PasswordHistory) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO password_history (user_type, user_id, password_hash, created_at) VALUES (?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query, entry.UserType, entry.UserID, entry.PasswordHash, now)
+	if err != nil {
+		return fmt.Errorf("failed to create password history: %w", err)
+	}
+
+	entry.ID = uint(id)
+	entry.CreatedAt = now
+	return nil
+}
+
+func (r *passwordHistoryRepository) ListRecent(ctx context.Context, userType string, userID uint, limit int) ([]*entity.PasswordHistory, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, user_type, user_id, password_hash, created_at FROM password_history
+			  WHERE user_type = ? AND user_id = ? ORDER BY id DESC LIMIT ?`
+
+	rows, err := conn.QueryContext(ctx, query, userType, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*entity.PasswordHistory{}
+	for rows.Next() {
+		var entry entity.PasswordHistory
+		if err := rows.Scan(&entry.ID, &entry.UserType, &entry.UserID, &entry.PasswordHash, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan password history: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating password history: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *passwordHistoryRepository) DeleteBefore(ctx context.Context, userType string, userID uint, id uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM password_history WHERE user_type = ? AND user_id = ? AND id < ?`
+
+	if _, err := conn.ExecContext(ctx, query, userType, userID, id); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) repository.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db: db,
+	}
+}
+
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO password_reset_tokens (token_hash, user_type, user_id, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query, token.TokenHash, token.UserType, token.UserID, token.ExpiresAt, now)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	token.ID = uint(id)
+	token.CreatedAt = now
+	return nil
+}
+
+func (r *passwordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, token_hash, user_type, user_id, expires_at, used_at, created_at
+			  FROM password_reset_tokens WHERE token_hash = ?`
+
+	var token entity.PasswordResetToken
+	var usedAt sql.NullTime
+	err = conn.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID, &token.TokenHash, &token.UserType, &token.UserID,
+		&token.ExpiresAt, &usedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) GetLatestByUser(ctx context.Context, userType string, userID uint) (*entity.PasswordResetToken, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, token_hash, user_type, user_id, expires_at, used_at, created_at
+			  FROM password_reset_tokens WHERE user_type = ? AND user_id = ?
+			  ORDER BY created_at DESC, id DESC LIMIT 1`
+
+	var token entity.PasswordResetToken
+	var usedAt sql.NullTime
+	err = conn.QueryRowContext(ctx, query, userType, userID).Scan(
+		&token.ID, &token.TokenHash, &token.UserType, &token.UserID,
+		&token.ExpiresAt, &usedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest password reset token: %w", err)
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`
+
+	result, err := conn.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *passwordResetTokenRepository) InvalidateForUser(ctx context.Context, userType string, userID uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE password_reset_tokens SET used_at = ? WHERE user_type = ? AND user_id = ? AND used_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), userType, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+	return nil
+}
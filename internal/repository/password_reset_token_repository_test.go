@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestPasswordResetTokenMarkUsed(t *testing.T) {
+	ctx := context.Background()
+	tokens := repo.NewPasswordResetTokenRepository(dbtest.New(t))
+
+	token := &entity.PasswordResetToken{
+		TokenHash: "hash",
+		UserType:  "mahasiswa",
+		UserID:    1,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := tokens.Create(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []bool{true, false} {
+		used, err := tokens.MarkUsed(ctx, token.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if used != want {
+			t.Errorf("MarkUsed call %d = %v, want %v", i+1, used, want)
+		}
+	}
+
+	stored, err := tokens.GetByHash(ctx, "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == nil || stored.UsedAt == nil {
+		t.Errorf("GetByHash() = %+v, want a used token", stored)
+	}
+}
+
+func TestPasswordResetTokenGetLatestByUser(t *testing.T) {
+	ctx := context.Background()
+	tokens := repo.NewPasswordResetTokenRepository(dbtest.New(t))
+
+	latest, err := tokens.GetLatestByUser(ctx, "mahasiswa", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != nil {
+		t.Fatalf("GetLatestByUser() = %+v before any token, want nil", latest)
+	}
+
+	for _, tt := range []struct {
+		hash     string
+		userType string
+		userID   uint
+	}{
+		{"first", "mahasiswa", 1},
+		{"second", "mahasiswa", 1},
+		{"other user", "mahasiswa", 2},
+		{"admin", "admin", 1},
+	} {
+		err := tokens.Create(ctx, &entity.PasswordResetToken{
+			TokenHash: tt.hash,
+			UserType:  tt.userType,
+			UserID:    tt.userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err = tokens.GetLatestByUser(ctx, "mahasiswa", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest == nil || latest.TokenHash != "second" {
+		t.Errorf("GetLatestByUser() = %+v, want token %q", latest, "second")
+	}
+}
@@ -9,10 +9,14 @@ import (
 
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
 
 	"gorm.io/gorm"
 )
 
+// pekerjaanColumns is the column list read by every pekerjaan query, in scanPekerjaan order
+const pekerjaanColumns = `id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at`
+
 type pekerjaanAlumniRepository struct {
 	db *gorm.DB
 }
@@ -22,7 +26,7 @@ func NewPekerjaanAlumniRepository(db *gorm.DB) repository.PekerjaanAlumniReposit
 }
 
 func (r *pekerjaanAlumniRepository) Create(ctx context.Context, pekerjaan *entity.PekerjaanAlumni) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -31,7 +35,7 @@ func (r *pekerjaanAlumniRepository) Create(ctx context.Context, pekerjaan *entit
 			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	
 	now := time.Now()
-	result, err := sqlDB.ExecContext(ctx, query,
+	id, err := conn.InsertContext(ctx, query,
 		pekerjaan.MahasiswaID, pekerjaan.NamaCompany, pekerjaan.Posisi,
 		pekerjaan.TanggalMulai, pekerjaan.TanggalSelesai, pekerjaan.Status,
 		pekerjaan.Deskripsi, now, now,
@@ -41,11 +45,6 @@ func (r *pekerjaanAlumniRepository) Create(ctx context.Context, pekerjaan *entit
 		return fmt.Errorf("failed to create pekerjaan alumni: %w", err)
 	}
 	
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %w", err)
-	}
-	
 	pekerjaan.ID = uint(id)
 	pekerjaan.CreatedAt = now
 	pekerjaan.UpdatedAt = now
@@ -54,21 +53,15 @@ func (r *pekerjaanAlumniRepository) Create(ctx context.Context, pekerjaan *entit
 }
 
 func (r *pekerjaanAlumniRepository) GetByID(ctx context.Context, id uint) (*entity.PekerjaanAlumni, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE id = ? AND deleted_at IS NULL`
 	
-	pekerjaan := &entity.PekerjaanAlumni{}
-	err = sqlDB.QueryRowContext(ctx, query, id).Scan(
-		&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-		&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-		&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-	)
-
+	pekerjaan, err := scanPekerjaan(conn.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -80,15 +73,15 @@ func (r *pekerjaanAlumniRepository) GetByID(ctx context.Context, id uint) (*enti
 }
 
 func (r *pekerjaanAlumniRepository) GetByMahasiswaID(ctx context.Context, mahasiswaID uint) ([]*entity.PekerjaanAlumni, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE mahasiswa_id = ? AND deleted_at IS NULL ORDER BY created_at DESC`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, mahasiswaID)
+	rows, err := conn.QueryContext(ctx, query, mahasiswaID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pekerjaan alumni by mahasiswa ID: %w", err)
 	}
@@ -96,12 +89,7 @@ func (r *pekerjaanAlumniRepository) GetByMahasiswaID(ctx context.Context, mahasi
 
 	var pekerjaanList []*entity.PekerjaanAlumni
 	for rows.Next() {
-		pekerjaan := &entity.PekerjaanAlumni{}
-		err = rows.Scan(
-			&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-			&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-			&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
+		pekerjaan, err := scanPekerjaan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pekerjaan alumni: %w", err)
 		}
@@ -112,15 +100,15 @@ func (r *pekerjaanAlumniRepository) GetByMahasiswaID(ctx context.Context, mahasi
 }
 
 func (r *pekerjaanAlumniRepository) GetAll(ctx context.Context) ([]*entity.PekerjaanAlumni, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE deleted_at IS NULL ORDER BY created_at DESC`
 	
-	rows, err := sqlDB.QueryContext(ctx, query)
+	rows, err := conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all pekerjaan alumni: %w", err)
 	}
@@ -128,12 +116,7 @@ func (r *pekerjaanAlumniRepository) GetAll(ctx context.Context) ([]*entity.Peker
 
 	var pekerjaanList []*entity.PekerjaanAlumni
 	for rows.Next() {
-		pekerjaan := &entity.PekerjaanAlumni{}
-		err = rows.Scan(
-			&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-			&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-			&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
+		pekerjaan, err := scanPekerjaan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pekerjaan alumni: %w", err)
 		}
@@ -144,7 +127,7 @@ func (r *pekerjaanAlumniRepository) GetAll(ctx context.Context) ([]*entity.Peker
 }
 
 func (r *pekerjaanAlumniRepository) Update(ctx context.Context, pekerjaan *entity.PekerjaanAlumni) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -191,7 +174,7 @@ func (r *pekerjaanAlumniRepository) Update(ctx context.Context, pekerjaan *entit
 
 	query := fmt.Sprintf("UPDATE pekerjaan_alumni SET %s WHERE id = ? AND deleted_at IS NULL", strings.Join(setParts, ", "))
 	
-	result, err := sqlDB.ExecContext(ctx, query, args...)
+	result, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update pekerjaan alumni: %w", err)
 	}
@@ -209,14 +192,14 @@ func (r *pekerjaanAlumniRepository) Update(ctx context.Context, pekerjaan *entit
 }
 
 func (r *pekerjaanAlumniRepository) Delete(ctx context.Context, id uint) error {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return err
 	}
 
 	query := `UPDATE pekerjaan_alumni SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	
-	result, err := sqlDB.ExecContext(ctx, query, time.Now(), id)
+	result, err := conn.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete pekerjaan alumni: %w", err)
 	}
@@ -234,7 +217,7 @@ func (r *pekerjaanAlumniRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *pekerjaanAlumniRepository) GetWithPagination(ctx context.Context, limit, offset int) ([]*entity.PekerjaanAlumni, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -242,16 +225,16 @@ func (r *pekerjaanAlumniRepository) GetWithPagination(ctx context.Context, limit
 	// Get total count
 	countQuery := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE deleted_at IS NULL`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = conn.QueryRowContext(ctx, countQuery).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 	}
 
 	// Get paginated results
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, limit, offset)
+	rows, err := conn.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get pekerjaan alumni with pagination: %w", err)
 	}
@@ -259,12 +242,7 @@ func (r *pekerjaanAlumniRepository) GetWithPagination(ctx context.Context, limit
 
 	var pekerjaanList []*entity.PekerjaanAlumni
 	for rows.Next() {
-		pekerjaan := &entity.PekerjaanAlumni{}
-		err = rows.Scan(
-			&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-			&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-			&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
+		pekerjaan, err := scanPekerjaan(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan pekerjaan alumni: %w", err)
 		}
@@ -275,21 +253,15 @@ func (r *pekerjaanAlumniRepository) GetWithPagination(ctx context.Context, limit
 }
 
 func (r *pekerjaanAlumniRepository) GetByIDAndMahasiswaID(ctx context.Context, id, mahasiswaID uint) (*entity.PekerjaanAlumni, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE id = ? AND mahasiswa_id = ? AND deleted_at IS NULL`
 	
-	pekerjaan := &entity.PekerjaanAlumni{}
-	err = sqlDB.QueryRowContext(ctx, query, id, mahasiswaID).Scan(
-		&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-		&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-		&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-	)
-
+	pekerjaan, err := scanPekerjaan(conn.QueryRowContext(ctx, query, id, mahasiswaID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -301,7 +273,7 @@ func (r *pekerjaanAlumniRepository) GetByIDAndMahasiswaID(ctx context.Context, i
 }
 
 func (r *pekerjaanAlumniRepository) GetByMahasiswaIDWithPagination(ctx context.Context, mahasiswaID uint, limit, offset int) ([]*entity.PekerjaanAlumni, int64, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -309,16 +281,16 @@ func (r *pekerjaanAlumniRepository) GetByMahasiswaIDWithPagination(ctx context.C
 	// Get total count
 	countQuery := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE mahasiswa_id = ? AND deleted_at IS NULL`
 	var total int64
-	err = sqlDB.QueryRowContext(ctx, countQuery, mahasiswaID).Scan(&total)
+	err = conn.QueryRowContext(ctx, countQuery, mahasiswaID).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 	}
 
 	// Get paginated results
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE mahasiswa_id = ? AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	
-	rows, err := sqlDB.QueryContext(ctx, query, mahasiswaID, limit, offset)
+	rows, err := conn.QueryContext(ctx, query, mahasiswaID, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get pekerjaan alumni by mahasiswa ID with pagination: %w", err)
 	}
@@ -326,12 +298,7 @@ func (r *pekerjaanAlumniRepository) GetByMahasiswaIDWithPagination(ctx context.C
 
 	var pekerjaanList []*entity.PekerjaanAlumni
 	for rows.Next() {
-		pekerjaan := &entity.PekerjaanAlumni{}
-		err = rows.Scan(
-			&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-			&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-			&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
+		pekerjaan, err := scanPekerjaan(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan pekerjaan alumni: %w", err)
 		}
@@ -342,12 +309,12 @@ func (r *pekerjaanAlumniRepository) GetByMahasiswaIDWithPagination(ctx context.C
 }
 
 func (r *pekerjaanAlumniRepository) GetWithFilters(ctx context.Context, filters map[string]interface{}) ([]*entity.PekerjaanAlumni, error) {
-	sqlDB, err := r.db.DB()
+	conn, err := database.Connect(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT id, mahasiswa_id, nama_company, posisi, tanggal_mulai, tanggal_selesai, status, deskripsi, created_at, updated_at 
+	query := `SELECT ` + pekerjaanColumns + ` 
 			  FROM pekerjaan_alumni WHERE deleted_at IS NULL`
 	
 	var args []interface{}
@@ -376,7 +343,7 @@ func (r *pekerjaanAlumniRepository) GetWithFilters(ctx context.Context, filters
 
 	query += " ORDER BY created_at DESC"
 
-	rows, err := sqlDB.QueryContext(ctx, query, args...)
+	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pekerjaan alumni with filters: %w", err)
 	}
@@ -384,12 +351,7 @@ func (r *pekerjaanAlumniRepository) GetWithFilters(ctx context.Context, filters
 
 	var pekerjaanList []*entity.PekerjaanAlumni
 	for rows.Next() {
-		pekerjaan := &entity.PekerjaanAlumni{}
-		err = rows.Scan(
-			&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
-			&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
-			&pekerjaan.Deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
+		pekerjaan, err := scanPekerjaan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pekerjaan alumni: %w", err)
 		}
@@ -397,4 +359,22 @@ func (r *pekerjaanAlumniRepository) GetWithFilters(ctx context.Context, filters
 	}
 
 	return pekerjaanList, nil
+}
+
+// scanPekerjaan reads one row selected with pekerjaanColumns
+func scanPekerjaan(row rowScanner) (*entity.PekerjaanAlumni, error) {
+	pekerjaan := &entity.PekerjaanAlumni{}
+	var deskripsi sql.NullString
+
+	err := row.Scan(
+		&pekerjaan.ID, &pekerjaan.MahasiswaID, &pekerjaan.NamaCompany, &pekerjaan.Posisi,
+		&pekerjaan.TanggalMulai, &pekerjaan.TanggalSelesai, &pekerjaan.Status,
+		&deskripsi, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pekerjaan.Deskripsi = deskripsi.String
+	return pekerjaan, nil
 }
\ No newline at end of file
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// refreshTokenColumns is the column list read by every refresh token query, in scanRefreshToken order
+const refreshTokenColumns = `id, token_hash, family_id, user_type, user_id, role, device_id, expires_at, revoked_at, replaced_by_id, created_at`
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO refresh_tokens (token_hash, family_id, user_type, user_id, role, device_id, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		token.TokenHash, token.FamilyID, token.UserType, token.UserID,
+		token.Role, token.DeviceID, token.ExpiresAt, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	token.ID = uint(id)
+	token.CreatedAt = now
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE token_hash = ?`
+
+	token, err := scanRefreshToken(conn.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id uint, replacedByID *uint) (bool, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE refresh_tokens SET revoked_at = ?, replaced_by_id = ? WHERE id = ? AND revoked_at IS NULL`
+
+	result, err := conn.ExecContext(ctx, query, time.Now(), replacedByID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeDevice(ctx context.Context, userType string, userID uint, deviceID string) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE refresh_tokens SET revoked_at = ?
+			  WHERE user_type = ? AND user_id = ? AND device_id = ? AND revoked_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), userType, userID, deviceID); err != nil {
+		return fmt.Errorf("failed to revoke device refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeUser(ctx context.Context, userType string, userID uint) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE user_type = ? AND user_id = ? AND revoked_at IS NULL`
+
+	if _, err := conn.ExecContext(ctx, query, time.Now(), userType, userID); err != nil {
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) DeleteEnded(ctx context.Context, now time.Time) (int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	// MySQL cannot select from the table it deletes from, so the live families are read
+	// through a derived table; DISTINCT keeps the optimizer from merging it back
+	query := `DELETE FROM refresh_tokens
+			  WHERE expires_at <= ?
+			  OR family_id NOT IN (SELECT family_id FROM (
+				  SELECT DISTINCT family_id FROM refresh_tokens WHERE revoked_at IS NULL AND expires_at > ?
+			  ) live)`
+
+	result, err := conn.ExecContext(ctx, query, now, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete ended refresh tokens: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// scanRefreshToken reads one row selected with refreshTokenColumns
+func scanRefreshToken(row rowScanner) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	var revokedAt sql.NullTime
+	var replacedByID sql.NullInt64
+
+	err := row.Scan(
+		&token.ID, &token.TokenHash, &token.FamilyID, &token.UserType,
+		&token.UserID, &token.Role, &token.DeviceID, &token.ExpiresAt,
+		&revokedAt, &replacedByID, &token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	if replacedByID.Valid {
+		id := uint(replacedByID.Int64)
+		token.ReplacedByID = &id
+	}
+	return &token, nil
+}
@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestRefreshTokenRevoke(t *testing.T) {
+	ctx := context.Background()
+	tokens := repo.NewRefreshTokenRepository(dbtest.New(t))
+
+	newToken := func(hash string) *entity.RefreshToken {
+		token := &entity.RefreshToken{
+			TokenHash: hash,
+			FamilyID:  "family",
+			UserType:  "mahasiswa",
+			UserID:    1,
+			Role:      "mahasiswa",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		if err := tokens.Create(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	old, replacement := newToken("old"), newToken("new")
+
+	// Only one of two concurrent rotations of the same token may win
+	for i, want := range []bool{true, false} {
+		revoked, err := tokens.Revoke(ctx, old.ID, &replacement.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked != want {
+			t.Errorf("Revoke call %d = %v, want %v", i+1, revoked, want)
+		}
+	}
+
+	stored, err := tokens.GetByHash(ctx, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == nil || stored.RevokedAt == nil || stored.ReplacedByID == nil || *stored.ReplacedByID != replacement.ID {
+		t.Errorf("GetByHash() = %+v, want revoked and replaced by %d", stored, replacement.ID)
+	}
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// sessionColumns is the column list read by every session query, in scanSession order.
+// Its placeholder is the time the session's refresh tokens must still be valid at.
+const sessionColumns = `s.id, s.family_id, s.user_type, s.user_id, s.device_id, s.device_name, s.ip_address, s.user_agent, s.created_at, s.last_seen_at,
+	EXISTS (SELECT 1 FROM refresh_tokens r WHERE r.family_id = s.family_id AND r.revoked_at IS NULL AND r.expires_at > ?)`
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO sessions (family_id, user_type, user_id, device_id, device_name, ip_address, user_agent, created_at, last_seen_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	id, err := conn.InsertContext(ctx, query,
+		session.FamilyID, session.UserType, session.UserID, session.DeviceID,
+		session.DeviceName, session.IPAddress, session.UserAgent, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	session.ID = uint(id)
+	session.CreatedAt = now
+	session.LastSeenAt = now
+	session.Active = true
+	return nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uint, now time.Time) (*entity.Session, error) {
+	return r.getOne(ctx, `WHERE s.id = ?`, now, id)
+}
+
+func (r *sessionRepository) GetByFamily(ctx context.Context, familyID string, now time.Time) (*entity.Session, error) {
+	return r.getOne(ctx, `WHERE s.family_id = ?`, now, familyID)
+}
+
+func (r *sessionRepository) getOne(ctx context.Context, where string, now time.Time, args ...interface{}) (*entity.Session, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions s ` + where
+
+	session, err := scanSession(conn.QueryRowContext(ctx, query, append([]interface{}{now}, args...)...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) ListActive(ctx context.Context, userType string, userID uint, now time.Time) ([]*entity.Session, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions s
+			  WHERE s.user_type = ? AND s.user_id = ?
+			  AND EXISTS (SELECT 1 FROM refresh_tokens r WHERE r.family_id = s.family_id AND r.revoked_at IS NULL AND r.expires_at > ?)
+			  ORDER BY s.last_seen_at DESC, s.id DESC`
+
+	rows, err := conn.QueryContext(ctx, query, now, userType, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*entity.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) TouchLastSeen(ctx context.Context, id uint, at time.Time) error {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE sessions SET last_seen_at = ? WHERE id = ? AND last_seen_at < ?`
+
+	if _, err := conn.ExecContext(ctx, query, at, id, at); err != nil {
+		return fmt.Errorf("failed to record session activity: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) DeleteEnded(ctx context.Context) (int64, error) {
+	conn, err := database.Connect(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `DELETE FROM sessions
+			  WHERE NOT EXISTS (SELECT 1 FROM refresh_tokens r WHERE r.family_id = sessions.family_id)`
+
+	result, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete ended sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanSession(row rowScanner) (*entity.Session, error) {
+	var session entity.Session
+
+	err := row.Scan(
+		&session.ID, &session.FamilyID, &session.UserType, &session.UserID, &session.DeviceID,
+		&session.DeviceName, &session.IPAddress, &session.UserAgent, &session.CreatedAt,
+		&session.LastSeenAt, &session.Active,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
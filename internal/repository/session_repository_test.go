@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	repo "Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+// sessionFixture is a user with sessions in every state: live, rotated once, logged out,
+// expired and reused
+type sessionFixture struct {
+	sessions map[string]*entity.Session
+	tokens   map[string]*entity.RefreshToken
+}
+
+func newSessionFixture(t *testing.T, sessions repository.SessionRepository, tokens repository.RefreshTokenRepository) *sessionFixture {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	f := &sessionFixture{sessions: map[string]*entity.Session{}, tokens: map[string]*entity.RefreshToken{}}
+
+	newToken := func(family, hash string, expiresAt time.Time) *entity.RefreshToken {
+		token := &entity.RefreshToken{
+			TokenHash: hash,
+			FamilyID:  family,
+			UserType:  "mahasiswa",
+			UserID:    1,
+			Role:      "mahasiswa",
+			ExpiresAt: expiresAt,
+		}
+		if err := tokens.Create(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+		f.tokens[hash] = token
+		return token
+	}
+	rotate := func(old, replacement *entity.RefreshToken) {
+		if _, err := tokens.Revoke(ctx, old.ID, &replacement.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, family := range []string{"live", "rotated", "logged-out", "expired", "reused"} {
+		session := &entity.Session{FamilyID: family, UserType: "mahasiswa", UserID: 1, DeviceID: family}
+		if err := sessions.Create(ctx, session); err != nil {
+			t.Fatal(err)
+		}
+		f.sessions[family] = session
+	}
+
+	newToken("live", "live", now.Add(time.Hour))
+
+	// The rotated token stays revoked next to its live replacement
+	rotate(newToken("rotated", "rotated-old", now.Add(time.Hour)), newToken("rotated", "rotated-new", now.Add(time.Hour)))
+	// An earlier link of the family expired already
+	rotate(newToken("rotated", "rotated-expired", now.Add(-time.Minute)), f.tokens["rotated-old"])
+
+	newToken("logged-out", "logged-out", now.Add(time.Hour))
+	if err := tokens.RevokeFamily(ctx, "logged-out"); err != nil {
+		t.Fatal(err)
+	}
+
+	newToken("expired", "expired", now.Add(-time.Minute))
+
+	rotate(newToken("reused", "reused-old", now.Add(time.Hour)), newToken("reused", "reused-new", now.Add(time.Hour)))
+	if err := tokens.RevokeFamily(ctx, "reused"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another user's session is never listed
+	other := &entity.Session{FamilyID: "other-user", UserType: "mahasiswa", UserID: 2}
+	if err := sessions.Create(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	newToken("other-user", "other-user", now.Add(time.Hour))
+
+	return f
+}
+
+func TestSessionListActive(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	sessions := repo.NewSessionRepository(db)
+	fixture := newSessionFixture(t, sessions, repo.NewRefreshTokenRepository(db))
+
+	active, err := sessions.ListActive(ctx, "mahasiswa", 1, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []uint{fixture.sessions["rotated"].ID, fixture.sessions["live"].ID}
+	if len(active) != len(want) {
+		t.Fatalf("ListActive() returned %d sessions, want %d", len(active), len(want))
+	}
+	for i, session := range active {
+		if session.ID != want[i] || !session.Active {
+			t.Errorf("ListActive()[%d] = session %d active=%v, want active session %d", i, session.ID, session.Active, want[i])
+		}
+	}
+}
+
+func TestSessionDeleteEnded(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	sessions := repo.NewSessionRepository(db)
+	tokens := repo.NewRefreshTokenRepository(db)
+	fixture := newSessionFixture(t, sessions, tokens)
+
+	deleted, err := tokens.DeleteEnded(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// rotated-expired, logged-out, expired, reused-old and reused-new
+	if deleted != 5 {
+		t.Errorf("refresh tokens DeleteEnded() = %d, want 5", deleted)
+	}
+
+	for hash, wantKept := range map[string]bool{
+		"live":            true,
+		"rotated-old":     true, // kept so that presenting it again ends the session
+		"rotated-new":     true,
+		"rotated-expired": false,
+		"logged-out":      false,
+		"expired":         false,
+		"reused-old":      false,
+		"reused-new":      false,
+		"other-user":      true,
+	} {
+		token, err := tokens.GetByHash(ctx, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kept := token != nil; kept != wantKept {
+			t.Errorf("refresh token %s kept = %v, want %v", hash, kept, wantKept)
+		}
+	}
+
+	deleted, err = sessions.DeleteEnded(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 3 {
+		t.Errorf("sessions DeleteEnded() = %d, want 3", deleted)
+	}
+	for family, session := range fixture.sessions {
+		stored, err := sessions.GetByID(ctx, session.ID, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantKept := family == "live" || family == "rotated"
+		if kept := stored != nil; kept != wantKept {
+			t.Errorf("session %s kept = %v, want %v", family, kept, wantKept)
+		}
+	}
+}
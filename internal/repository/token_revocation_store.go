@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// tokenRevocationStore keeps revocations in the revoked_tokens and
+// token_revocation_cutoffs tables so they survive restarts and are shared
+// between instances
+type tokenRevocationStore struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationStore(db *gorm.DB) repository.TokenRevocationStore {
+	return &tokenRevocationStore{
+		db: db,
+	}
+}
+
+func (s *tokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	conn, err := database.Connect(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	// Rows of expired tokens are no longer needed
+	if _, err := conn.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < ?`, now); err != nil {
+		return fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+
+	revoked, err := s.IsTokenRevoked(ctx, tokenID)
+	if err != nil || revoked {
+		return err
+	}
+
+	query := `INSERT INTO revoked_tokens (jti, expires_at, revoked_at) VALUES (?, ?, ?)`
+	if _, err := conn.ExecContext(ctx, query, tokenID, expiresAt, now); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (s *tokenRevocationStore) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	conn, err := database.Connect(ctx, s.db)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM revoked_tokens WHERE jti = ?`, tokenID).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (s *tokenRevocationStore) RevokeUserTokens(ctx context.Context, userType string, userID uint, before time.Time) error {
+	conn, err := database.Connect(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE token_revocation_cutoffs SET revoked_before = ? WHERE user_type = ? AND user_id = ?`
+	result, err := conn.ExecContext(ctx, query, before, userType, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	query = `INSERT INTO token_revocation_cutoffs (user_type, user_id, revoked_before) VALUES (?, ?, ?)`
+	if _, err := conn.ExecContext(ctx, query, userType, userID, before); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+func (s *tokenRevocationStore) UserTokensRevokedBefore(ctx context.Context, userType string, userID uint) (time.Time, error) {
+	conn, err := database.Connect(ctx, s.db)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	query := `SELECT revoked_before FROM token_revocation_cutoffs WHERE user_type = ? AND user_id = ?`
+
+	var before time.Time
+	if err := conn.QueryRowContext(ctx, query, userType, userID).Scan(&before); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get user token cutoff: %w", err)
+	}
+	return before, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) repository.UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return database.WithTx(ctx, u.db, fn)
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+)
+
+var (
+	ErrAdminNotFound          = errors.New("admin tidak ditemukan")
+	ErrAdminUsernameTaken     = errors.New("username admin sudah digunakan")
+	ErrAdminEmailTaken        = errors.New("email admin sudah digunakan")
+	ErrLastSuperAdmin         = errors.New("super admin aktif terakhir tidak boleh dihapus, dinonaktifkan, atau diturunkan")
+	ErrInvalidCurrentPassword = errors.New("password saat ini salah")
+	ErrPasswordUnchanged      = errors.New("password baru harus berbeda dari password saat ini")
+)
+
+type adminUserUsecase struct {
+	adminRepo   repository.AdminUserRepository
+	refreshRepo repository.RefreshTokenRepository
+	revocations repository.TokenRevocationStore
+	uow         repository.UnitOfWork
+	bcryptUtil  *bcrypt.BcryptUtil
+	passwords   service.PasswordPolicyService
+}
+
+func NewAdminUserUsecase(
+	adminRepo repository.AdminUserRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationStore,
+	uow repository.UnitOfWork,
+	bcryptUtil *bcrypt.BcryptUtil,
+	passwords service.PasswordPolicyService,
+) service.AdminUserService {
+	return &adminUserUsecase{
+		adminRepo:   adminRepo,
+		refreshRepo: refreshRepo,
+		revocations: revocations,
+		uow:         uow,
+		bcryptUtil:  bcryptUtil,
+		passwords:   passwords,
+	}
+}
+
+func (u *adminUserUsecase) CreateAdmin(ctx context.Context, req *dto.CreateAdminRequest) (*entity.AdminUser, error) {
+	if err := u.checkUnique(ctx, 0, req.Username, req.Email); err != nil {
+		return nil, err
+	}
+
+	admin := &entity.AdminUser{
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     entity.AdminRole(req.Role),
+		IsActive: true,
+	}
+	if err := u.passwords.Check(ctx, req.Password, adminPasswordSubject(admin)); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := u.bcryptUtil.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	admin.Password = hashedPassword
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.adminRepo.Create(ctx, admin); err != nil {
+			return err
+		}
+		return u.passwords.Remember(ctx, "admin", admin.ID, hashedPassword)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+func (u *adminUserUsecase) GetAdmin(ctx context.Context, id uint) (*entity.AdminUser, error) {
+	admin, err := u.adminRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+func (u *adminUserUsecase) ListAdmins(ctx context.Context, limit, offset int) ([]*entity.AdminUser, int64, error) {
+	return u.adminRepo.GetAll(ctx, limit, offset)
+}
+
+func (u *adminUserUsecase) UpdateAdmin(ctx context.Context, id uint, req *dto.UpdateAdminRequest) (*entity.AdminUser, error) {
+	admin, err := u.GetAdmin(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Username != "" {
+		admin.Username = req.Username
+	}
+	if req.Email != "" {
+		admin.Email = req.Email
+	}
+
+	if err := u.checkUnique(ctx, admin.ID, admin.Username, admin.Email); err != nil {
+		return nil, err
+	}
+
+	if err := u.adminRepo.Update(ctx, admin.ID, admin); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (u *adminUserUsecase) ChangeRole(ctx context.Context, id uint, req *dto.ChangeAdminRoleRequest) (*entity.AdminUser, error) {
+	role := entity.AdminRole(req.Role)
+
+	admin, err := u.modify(ctx, id, role != entity.AdminRoleSuperAdmin, func(ctx context.Context, admin *entity.AdminUser) error {
+		admin.Role = role
+		return u.adminRepo.Update(ctx, admin.ID, admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens carry the permissions of the old role
+	if err := endSessions(ctx, u.refreshRepo, u.revocations, "admin", admin.ID); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (u *adminUserUsecase) SetActive(ctx context.Context, id uint, active bool) (*entity.AdminUser, error) {
+	admin, err := u.modify(ctx, id, !active, func(ctx context.Context, admin *entity.AdminUser) error {
+		admin.IsActive = active
+		return u.adminRepo.Update(ctx, admin.ID, admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !active {
+		if err := endSessions(ctx, u.refreshRepo, u.revocations, "admin", admin.ID); err != nil {
+			return nil, err
+		}
+	}
+	return admin, nil
+}
+
+func (u *adminUserUsecase) DeleteAdmin(ctx context.Context, id uint) error {
+	_, err := u.modify(ctx, id, true, func(ctx context.Context, admin *entity.AdminUser) error {
+		return u.adminRepo.Delete(ctx, admin.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return endSessions(ctx, u.refreshRepo, u.revocations, "admin", id)
+}
+
+func (u *adminUserUsecase) ChangePassword(ctx context.Context, id uint, req *dto.ChangePasswordRequest) error {
+	admin, err := u.GetAdmin(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !u.bcryptUtil.CheckPasswordHash(req.CurrentPassword, admin.Password) {
+		return ErrInvalidCurrentPassword
+	}
+	// Keeping the old password would let a forced password change be skipped
+	if req.NewPassword == req.CurrentPassword {
+		return ErrPasswordUnchanged
+	}
+	if isDefaultAdminPassword(req.NewPassword) {
+		return ErrDefaultAdminPassword
+	}
+	if err := u.passwords.Check(ctx, req.NewPassword, adminPasswordSubject(admin)); err != nil {
+		return err
+	}
+
+	hashedPassword, err := u.bcryptUtil.HashPassword(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.adminRepo.UpdatePassword(ctx, admin.ID, hashedPassword); err != nil {
+			return err
+		}
+		return u.passwords.Remember(ctx, "admin", admin.ID, hashedPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	return endSessions(ctx, u.refreshRepo, u.revocations, "admin", admin.ID)
+}
+
+// modify applies change to an admin in a transaction. When the change can take away
+// super admin rights, the active super admins are locked first so that concurrent
+// changes cannot remove the last one between them.
+func (u *adminUserUsecase) modify(ctx context.Context, id uint, removesSuperAdmin bool, change func(ctx context.Context, admin *entity.AdminUser) error) (*entity.AdminUser, error) {
+	var admin *entity.AdminUser
+
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var superAdminIDs []uint
+		if removesSuperAdmin {
+			var err error
+			if superAdminIDs, err = u.adminRepo.LockActiveSuperAdmins(ctx); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		admin, err = u.adminRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if admin == nil {
+			return ErrAdminNotFound
+		}
+
+		if removesSuperAdmin && isLastSuperAdmin(admin, superAdminIDs) {
+			return ErrLastSuperAdmin
+		}
+
+		return change(ctx, admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+// isLastSuperAdmin reports whether admin is the only one among the active super admins
+func isLastSuperAdmin(admin *entity.AdminUser, superAdminIDs []uint) bool {
+	if !admin.IsSuperAdmin() || !admin.IsActive {
+		return false
+	}
+	for _, id := range superAdminIDs {
+		if id != admin.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// checkUnique fails when another admin than id already uses the username or email
+func (u *adminUserUsecase) checkUnique(ctx context.Context, id uint, username, email string) error {
+	existing, err := u.adminRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return ErrAdminUsernameTaken
+	}
+
+	existing, err = u.adminRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return ErrAdminEmailTaken
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var (
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	ErrAPIKeyRevoked       = errors.New("API key is revoked")
+	ErrAPIKeyExpired       = errors.New("API key is expired")
+	ErrInvalidAPIKey       = errors.New("invalid or expired API key")
+	ErrInvalidAPIKeyScope  = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyExpiry = errors.New("API key expiry must be in the future")
+)
+
+const (
+	// apiKeyPrefix starts every key, so leaked keys are easy to recognise
+	apiKeyPrefix = "fgk_"
+	// apiKeyDisplayLength is how much of a key is kept to tell keys apart
+	apiKeyDisplayLength = 12
+	// apiKeyUseInterval limits how often the last use of a key is written
+	apiKeyUseInterval = time.Minute
+)
+
+type apiKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+	adminRepo  repository.AdminUserRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository, adminRepo repository.AdminUserRepository) service.APIKeyService {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+		adminRepo:  adminRepo,
+	}
+}
+
+func (u *apiKeyUsecase) Create(ctx context.Context, req *dto.CreateAPIKeyRequest, actor *service.JWTClaims) (*dto.APIKeySecretResponse, error) {
+	scopes := make([]entity.Permission, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scope := entity.Permission(strings.TrimSpace(s))
+		// Admins cannot hand out more than they hold themselves
+		if !scope.IsAPIKeyScope() || !actor.HasPermission(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, s)
+		}
+		if !containsPermission(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy := actor.UserID
+	key := &entity.APIKey{
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    secret[:apiKeyDisplayLength],
+		KeyHash:   utils.HashToken(secret),
+		Scopes:    scopes,
+		CreatedBy: &createdBy,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := u.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeySecretResponse{APIKey: key, Key: secret}, nil
+}
+
+func (u *apiKeyUsecase) Get(ctx context.Context, id uint) (*entity.APIKey, error) {
+	key, err := u.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (u *apiKeyUsecase) List(ctx context.Context, limit, offset int) ([]*entity.APIKey, int64, error) {
+	return u.apiKeyRepo.List(ctx, limit, offset)
+}
+
+func (u *apiKeyUsecase) Rotate(ctx context.Context, id uint) (*dto.APIKeySecretResponse, error) {
+	key, err := u.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, ErrAPIKeyRevoked
+	}
+	if key.IsExpired() {
+		return nil, ErrAPIKeyExpired
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := u.apiKeyRepo.Rotate(ctx, key.ID, secret[:apiKeyDisplayLength], utils.HashToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	// Revoked while the new secret was generated
+	if !rotated {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	key, err = u.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.APIKeySecretResponse{APIKey: key, Key: secret}, nil
+}
+
+func (u *apiKeyUsecase) Revoke(ctx context.Context, id uint) error {
+	key, err := u.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := u.apiKeyRepo.Revoke(ctx, key.ID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyRevoked
+	}
+	return nil
+}
+
+func (u *apiKeyUsecase) ValidateAPIKey(ctx context.Context, secret string) (*service.JWTClaims, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := u.apiKeyRepo.GetByHash(ctx, utils.HashToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.IsRevoked() || key.IsExpired() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// A key ends with its creator's access: when they are deactivated, deleted or no longer hold its scopes
+	allowed, err := u.creatorHoldsScopes(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if err := u.apiKeyRepo.TouchLastUsed(ctx, key.ID, now, now.Add(-apiKeyUseInterval)); err != nil {
+		log.Printf("failed to record use of api key %d: %v", key.ID, err)
+	}
+
+	return &service.JWTClaims{
+		UserID:      key.ID,
+		Role:        "api_key",
+		Username:    key.Name,
+		Permissions: key.Scopes,
+	}, nil
+}
+
+// creatorHoldsScopes reports whether the admin who created the key is active and holds every scope of it
+func (u *apiKeyUsecase) creatorHoldsScopes(ctx context.Context, key *entity.APIKey) (bool, error) {
+	if key.CreatedBy == nil {
+		return false, nil
+	}
+	admin, err := u.adminRepo.GetByID(ctx, *key.CreatedBy)
+	if err != nil {
+		return false, err
+	}
+	if admin == nil || !admin.IsActive {
+		return false, nil
+	}
+
+	permissions := admin.Role.Permissions()
+	for _, scope := range key.Scopes {
+		if !containsPermission(permissions, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func generateAPIKey() (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + token, nil
+}
+
+func containsPermission(permissions []entity.Permission, permission entity.Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
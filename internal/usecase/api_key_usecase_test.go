@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	domainrepo "Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+func TestValidateAPIKeyFollowsCreator(t *testing.T) {
+	tests := []struct {
+		name string
+		// change alters the creator after the key was issued
+		change func(ctx context.Context, admins domainrepo.AdminUserRepository, admin *entity.AdminUser) error
+		valid  bool
+	}{
+		{"unchanged creator", func(context.Context, domainrepo.AdminUserRepository, *entity.AdminUser) error {
+			return nil
+		}, true},
+		{"creator demoted to a role with the scopes", func(ctx context.Context, admins domainrepo.AdminUserRepository, admin *entity.AdminUser) error {
+			admin.Role = entity.AdminRoleModerator
+			return admins.Update(ctx, admin.ID, admin)
+		}, true},
+		{"creator deactivated", func(ctx context.Context, admins domainrepo.AdminUserRepository, admin *entity.AdminUser) error {
+			admin.IsActive = false
+			return admins.Update(ctx, admin.ID, admin)
+		}, false},
+		{"creator deleted", func(ctx context.Context, admins domainrepo.AdminUserRepository, admin *entity.AdminUser) error {
+			return admins.Delete(ctx, admin.ID)
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := dbtest.New(t)
+			adminRepo := repository.NewAdminUserRepository(db)
+			apiKeys := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db), adminRepo)
+
+			admin := &entity.AdminUser{Username: "root", Email: "root@example.com", Password: "hash", Role: entity.AdminRoleSuperAdmin, IsActive: true}
+			if err := adminRepo.Create(ctx, admin); err != nil {
+				t.Fatal(err)
+			}
+			actor := &service.JWTClaims{UserID: admin.ID, Role: "admin", AdminRole: string(admin.Role), Permissions: admin.Role.Permissions()}
+			key, err := apiKeys.Create(ctx, &dto.CreateAPIKeyRequest{Name: "warehouse", Scopes: []string{string(entity.PermissionPekerjaanReadAll)}}, actor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.change(ctx, adminRepo, admin); err != nil {
+				t.Fatal(err)
+			}
+
+			claims, err := apiKeys.ValidateAPIKey(ctx, key.Key)
+			if tt.valid {
+				if err != nil || claims == nil {
+					t.Fatalf("ValidateAPIKey() = %v, %v, want the key accepted", claims, err)
+				}
+			} else if !errors.Is(err, usecase.ErrInvalidAPIKey) {
+				t.Fatalf("ValidateAPIKey() = %v, %v, want %v", claims, err, usecase.ErrInvalidAPIKey)
+			}
+		})
+	}
+}
+
+// TestValidateAPIKeyRefusesScopesTheCreatorLacks covers a key whose scopes its creator's role
+// no longer grants, e.g. after the role lost a permission
+func TestValidateAPIKeyRefusesScopesTheCreatorLacks(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	adminRepo := repository.NewAdminUserRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeys := usecase.NewAPIKeyUsecase(apiKeyRepo, adminRepo)
+
+	moderator := &entity.AdminUser{Username: "mod", Email: "mod@example.com", Password: "hash", Role: entity.AdminRoleModerator, IsActive: true}
+	if err := adminRepo.Create(ctx, moderator); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		secret string
+		scopes []entity.Permission
+		valid  bool
+	}{
+		{"fgk_granted", []entity.Permission{entity.PermissionMahasiswaReadAll}, true},
+		{"fgk_not_granted", []entity.Permission{entity.PermissionMahasiswaReadAll, entity.PermissionMahasiswaCreate}, false},
+	} {
+		err := apiKeyRepo.Create(ctx, &entity.APIKey{
+			Name:      tt.secret,
+			Prefix:    tt.secret,
+			KeyHash:   utils.HashToken(tt.secret),
+			Scopes:    tt.scopes,
+			CreatedBy: &moderator.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = apiKeys.ValidateAPIKey(ctx, tt.secret)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateAPIKey(%s) = %v, want the key accepted", tt.secret, err)
+		}
+		if !tt.valid && !errors.Is(err, usecase.ErrInvalidAPIKey) {
+			t.Errorf("ValidateAPIKey(%s) = %v, want %v", tt.secret, err, usecase.ErrInvalidAPIKey)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used, please log in again")
+	ErrTokenRevoked        = errors.New("token has been revoked")
+	ErrAccountDisabled     = errors.New("account is disabled")
+
+	// errRefreshTokenRaced means another request rotated the same token first
+	errRefreshTokenRaced = errors.New("refresh token was rotated concurrently")
+)
+
+// sessionSeenInterval limits how often the last activity of a session is written
+const sessionSeenInterval = time.Minute
+
+// userTypeForRole maps a token role to the table its user id refers to
+func userTypeForRole(role string) string {
+	if role == "admin" {
+		return "admin"
+	}
+	return "mahasiswa"
+}
+
+// login starts a new session with its own refresh token family on the device, replacing
+// the session the device had before, and returns the first token pair
+func (s *authService) login(ctx context.Context, claims *service.JWTClaims, user interface{}, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	var err error
+	deviceID := client.DeviceID
+	if deviceID == "" {
+		if deviceID, err = utils.GenerateRandomToken(16); err != nil {
+			return nil, fmt.Errorf("failed to generate device id: %w", err)
+		}
+	}
+
+	familyID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token family: %w", err)
+	}
+
+	var response *dto.LoginResponse
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.refreshRepo.RevokeDevice(ctx, userTypeForRole(claims.Role), claims.UserID, deviceID); err != nil {
+			return err
+		}
+
+		session := &entity.Session{
+			FamilyID:   familyID,
+			UserType:   userTypeForRole(claims.Role),
+			UserID:     claims.UserID,
+			DeviceID:   deviceID,
+			DeviceName: client.DeviceName,
+			IPAddress:  client.IPAddress,
+			UserAgent:  utils.Truncate(client.UserAgent, 255),
+		}
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+		claims.SessionID = session.ID
+
+		response, _, err = s.issueTokens(ctx, claims, user, familyID, deviceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// issueTokens signs an access token and adds a new refresh token to the family
+func (s *authService) issueTokens(ctx context.Context, claims *service.JWTClaims, user interface{}, familyID, deviceID string) (*dto.LoginResponse, *entity.RefreshToken, error) {
+	token, expiresAt, err := s.tokens.GenerateToken(claims)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	rawRefresh, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	refresh := &entity.RefreshToken{
+		TokenHash: utils.HashToken(rawRefresh),
+		FamilyID:  familyID,
+		UserType:  userTypeForRole(claims.Role),
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		DeviceID:  deviceID,
+		ExpiresAt: time.Now().Add(s.tokens.RefreshExpire()),
+	}
+	if err := s.refreshRepo.Create(ctx, refresh); err != nil {
+		return nil, nil, err
+	}
+
+	return &dto.LoginResponse{
+		Token:            token,
+		User:             user,
+		Role:             claims.Role,
+		ExpiresAt:        expiresAt.Unix(),
+		RefreshToken:     rawRefresh,
+		RefreshExpiresAt: refresh.ExpiresAt.Unix(),
+		DeviceID:         deviceID,
+
+		PasswordChangeRequired: claims.PasswordChangeRequired,
+		MFAEnrollmentRequired:  claims.MFAEnrollmentRequired,
+	}, refresh, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. The presented token is
+// revoked; presenting it again revokes its whole family.
+func (s *authService) RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	var response *dto.LoginResponse
+	reused := false
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := s.refreshRepo.GetByHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			return err
+		}
+		if current == nil || current.IsExpired() {
+			return ErrInvalidRefreshToken
+		}
+
+		if current.IsRevoked() {
+			if !current.WasRotated() {
+				return ErrInvalidRefreshToken
+			}
+			// A rotated token came back: someone holds a copy, so end the whole family.
+			// Returning nil keeps the revocation committed.
+			reused = true
+			return s.refreshRepo.RevokeFamily(ctx, current.FamilyID)
+		}
+
+		claims, user, err := s.refreshSubject(ctx, current)
+		if err != nil {
+			return err
+		}
+		if claims.SessionID, err = s.refreshSession(ctx, current, req); err != nil {
+			return err
+		}
+
+		err = s.uow.Do(ctx, func(ctx context.Context) error {
+			var next *entity.RefreshToken
+			response, next, err = s.issueTokens(ctx, claims, user, current.FamilyID, current.DeviceID)
+			if err != nil {
+				return err
+			}
+
+			rotated, err := s.refreshRepo.Revoke(ctx, current.ID, &next.ID)
+			if err != nil {
+				return err
+			}
+			if !rotated {
+				return errRefreshTokenRaced
+			}
+			return nil
+		})
+		if errors.Is(err, errRefreshTokenRaced) {
+			reused = true
+			return s.refreshRepo.RevokeFamily(ctx, current.FamilyID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, ErrRefreshTokenReused
+	}
+
+	return response, nil
+}
+
+// refreshSession records the activity of the session a refresh token belongs to and
+// returns its id. Families started before sessions were recorded get one here.
+func (s *authService) refreshSession(ctx context.Context, token *entity.RefreshToken, req *dto.RefreshTokenRequest) (uint, error) {
+	now := time.Now()
+	session, err := s.sessionRepo.GetByFamily(ctx, token.FamilyID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if session == nil {
+		session = &entity.Session{
+			FamilyID:  token.FamilyID,
+			UserType:  token.UserType,
+			UserID:    token.UserID,
+			DeviceID:  token.DeviceID,
+			IPAddress: req.IPAddress,
+			UserAgent: utils.Truncate(req.UserAgent, 255),
+		}
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return 0, err
+		}
+		return session.ID, nil
+	}
+
+	if err := s.sessionRepo.TouchLastSeen(ctx, session.ID, now); err != nil {
+		return 0, err
+	}
+	return session.ID, nil
+}
+
+// refreshSubject reloads the user a refresh token belongs to and builds its access token claims
+func (s *authService) refreshSubject(ctx context.Context, token *entity.RefreshToken) (*service.JWTClaims, interface{}, error) {
+	if token.UserType == "admin" {
+		admin, err := s.adminRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if admin == nil || !admin.IsActive {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+
+		// Permissions follow the admin's current role, so a role change applies on the next refresh
+		return s.adminClaims(admin), admin.ToResponse(), nil
+	}
+
+	mahasiswa, err := s.mahasiswaRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mahasiswa == nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	// The role follows the current status, so a graduation applies on the next refresh
+	claims := &service.JWTClaims{
+		UserID: mahasiswa.ID,
+		Email:  mahasiswa.Email,
+		Role:   mahasiswa.Role(),
+
+		Permissions: mahasiswa.Permissions(),
+	}
+	return claims, mahasiswa.ToResponse(), nil
+}
+
+// adminClaims builds the access token claims of admin. An admin who must change their
+// password or whose password has expired, or who must enroll in MFA required for their
+// role, gets no permissions until they do.
+func (s *authService) adminClaims(admin *entity.AdminUser) *service.JWTClaims {
+	claims := &service.JWTClaims{
+		UserID:   admin.ID,
+		Email:    admin.Email,
+		Role:     "admin",
+		Username: admin.Username,
+
+		AdminRole: string(admin.Role),
+
+		PasswordChangeRequired: admin.MustChangePassword || s.passwords.IsExpired(admin),
+		MFAEnrollmentRequired:  !admin.TOTPEnabled && s.mfa.IsRequired(admin.Role),
+	}
+	if !claims.PasswordChangeRequired && !claims.MFAEnrollmentRequired {
+		claims.Permissions = admin.Role.Permissions()
+	}
+	return claims
+}
+
+// Logout revokes the access token and, when a refresh token of the same user is given,
+// the refresh token family it belongs to
+func (s *authService) Logout(ctx context.Context, claims *service.JWTClaims, req *dto.LogoutRequest) error {
+
+	if err := s.revocations.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		return err
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	refresh, err := s.refreshRepo.GetByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	if refresh == nil || refresh.UserType != userTypeForRole(claims.Role) || refresh.UserID != claims.UserID {
+		// The access token is revoked already; an unknown refresh token has nothing left to end
+		return nil
+	}
+
+	return s.refreshRepo.RevokeFamily(ctx, refresh.FamilyID)
+}
+
+// LogoutAll revokes every access token issued to the user up to now and all of their refresh tokens
+func (s *authService) LogoutAll(ctx context.Context, claims *service.JWTClaims) error {
+	// An admin acting as the user may not end the user's own sessions
+	if err := policy.AuthorizeCredentialChange(claims); err != nil {
+		return err
+	}
+
+	return endSessions(ctx, s.refreshRepo, s.revocations, userTypeForRole(claims.Role), claims.UserID)
+}
+
+// endSessions revokes every refresh token of the user and every access token issued to them up to now
+func endSessions(ctx context.Context, refreshRepo repository.RefreshTokenRepository, revocations repository.TokenRevocationStore, userType string, userID uint) error {
+	if err := refreshRepo.RevokeUser(ctx, userType, userID); err != nil {
+		return err
+	}
+
+	return revocations.RevokeUserTokens(ctx, userType, userID, time.Now())
+}
+
+func (s *authService) checkRevoked(ctx context.Context, claims *service.JWTClaims) error {
+	if claims.TokenID == "" {
+		// Tokens issued before jti was introduced cannot be revoked one by one
+		return ErrTokenRevoked
+	}
+
+	revoked, err := s.revocations.IsTokenRevoked(ctx, claims.TokenID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+
+	// iat has second precision, so a token from the same second as a logout-all is revoked too
+	before, err := s.revocations.UserTokensRevokedBefore(ctx, userTypeForRole(claims.Role), claims.UserID)
+	if err != nil {
+		return err
+	}
+	if !before.IsZero() && !claims.IssuedAt.After(before) {
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
+
+// checkSession rejects tokens of sessions that were ended, by logout or from the session
+// list, and records the activity of the session at most once per sessionSeenInterval
+func (s *authService) checkSession(ctx context.Context, claims *service.JWTClaims) error {
+	if claims.SessionID == 0 {
+		// Impersonation tokens and tokens issued before sessions were recorded have none
+		return nil
+	}
+
+	now := time.Now()
+	session, err := s.sessionRepo.GetByID(ctx, claims.SessionID, now)
+	if err != nil {
+		return err
+	}
+	if session == nil || !session.Active || session.UserType != userTypeForRole(claims.Role) || session.UserID != claims.UserID {
+		return ErrTokenRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionSeenInterval {
+		if err := s.sessionRepo.TouchLastSeen(ctx, session.ID, now); err != nil {
+			log.Printf("failed to record activity of session %d: %v", session.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *authService) checkAccountEnabled(ctx context.Context, claims *service.JWTClaims) error {
+	if userTypeForRole(claims.Role) == "admin" {
+		admin, err := s.adminRepo.GetByID(ctx, claims.UserID)
+		if err != nil {
+			return err
+		}
+		if admin == nil || !admin.IsActive {
+			return ErrAccountDisabled
+		}
+		return nil
+	}
+
+	status, ok := s.statuses.get(claims.UserID)
+	if !ok {
+		mahasiswa, err := s.mahasiswaRepo.GetByID(ctx, claims.UserID)
+		if err != nil {
+			return err
+		}
+		status = s.statuses.put(claims.UserID, mahasiswa)
+	}
+	if !status.found {
+		return ErrAccountDisabled
+	}
+
+	// A graduation or suspension applies to tokens issued before it
+	current := &entity.Mahasiswa{Status: status.status}
+	role, permissions := current.Role(), current.Permissions()
+	if claims.Role != role || !samePermissions(claims.Permissions, permissions) {
+		claims.Role = role
+		claims.Permissions = permissions
+		claims.RoleChanged = true
+	}
+	return nil
+}
+
+// checkImpersonator ends an impersonation token once the admin behind it is disabled or
+// logged out of all sessions
+func (s *authService) checkImpersonator(ctx context.Context, claims *service.JWTClaims) error {
+	if claims.Impersonator == nil {
+		return nil
+	}
+
+	admin, err := s.adminRepo.GetByID(ctx, claims.Impersonator.AdminID)
+	if err != nil {
+		return err
+	}
+	if admin == nil || !admin.IsActive {
+		return ErrAccountDisabled
+	}
+
+	before, err := s.revocations.UserTokensRevokedBefore(ctx, "admin", admin.ID)
+	if err != nil {
+		return err
+	}
+	if !before.IsZero() && !claims.IssuedAt.After(before) {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+func samePermissions(a, b []entity.Permission) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
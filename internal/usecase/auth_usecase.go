@@ -4,113 +4,172 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
 	"Fix-Go-Fiber-Backend/internal/domain/service"
 	"Fix-Go-Fiber-Backend/pkg/bcrypt"
-	"Fix-Go-Fiber-Backend/pkg/jwt"
+)
+
+var (
+	ErrEmailNotVerified = errors.New("email address has not been verified")
+	// ErrInvalidCredentials is returned for an unknown account and for a wrong password alike
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAdminInactive      = errors.New("admin account is inactive")
 )
 
 type authService struct {
 	mahasiswaRepo repository.MahasiswaRepository
 	adminRepo     repository.AdminUserRepository
-	jwtUtil       *jwt.JWTUtil
+	refreshRepo   repository.RefreshTokenRepository
+	sessionRepo   repository.SessionRepository
+	revocations   repository.TokenRevocationStore
+	verification  service.EmailVerificationService
+	attempts      service.LoginAttemptService
+	mfa           service.MFAService
+	passwords     service.PasswordPolicyService
+	uow           repository.UnitOfWork
+	tokens        *TokenSigner
 	bcryptUtil    *bcrypt.BcryptUtil
+
+	// requireEmailVerification makes mahasiswa and alumni logins refuse unverified emails
+	requireEmailVerification bool
+
+	// statuses caches the mahasiswa status token roles are checked against
+	statuses *mahasiswaStatusCache
+
+	// dummyHash is checked against when a login names an unknown account, see checkPassword
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
 func NewAuthService(
 	mahasiswaRepo repository.MahasiswaRepository,
 	adminRepo repository.AdminUserRepository,
-	jwtUtil *jwt.JWTUtil,
+	refreshRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	revocations repository.TokenRevocationStore,
+	verification service.EmailVerificationService,
+	attempts service.LoginAttemptService,
+	mfa service.MFAService,
+	passwords service.PasswordPolicyService,
+	uow repository.UnitOfWork,
+	tokens *TokenSigner,
 	bcryptUtil *bcrypt.BcryptUtil,
+	requireEmailVerification bool,
+	roleCacheTTL time.Duration,
 ) service.AuthService {
 	return &authService{
 		mahasiswaRepo: mahasiswaRepo,
 		adminRepo:     adminRepo,
-		jwtUtil:       jwtUtil,
+		refreshRepo:   refreshRepo,
+		sessionRepo:   sessionRepo,
+		revocations:   revocations,
+		verification:  verification,
+		attempts:      attempts,
+		mfa:           mfa,
+		passwords:     passwords,
+		uow:           uow,
+		tokens:        tokens,
 		bcryptUtil:    bcryptUtil,
-	}
-}
 
-func (s *authService) LoginMahasiswa(req *dto.MahasiswaLoginRequest) (*dto.LoginResponse, error) {
-	ctx := context.Background()
-	
-	// Find mahasiswa by email
-	mahasiswa, err := s.mahasiswaRepo.GetByEmail(ctx, req.Email)
-	if err != nil || mahasiswa == nil {
-		return nil, errors.New("invalid credentials")
-	}
+		requireEmailVerification: requireEmailVerification,
 
-	// Verify password
-	if !s.bcryptUtil.CheckPasswordHash(req.Password, mahasiswa.Password) {
-		return nil, errors.New("invalid credentials")
+		statuses: newMahasiswaStatusCache(roleCacheTTL),
 	}
+}
 
-	// Generate JWT token
-	claims := &service.JWTClaims{
-		UserID: mahasiswa.ID,
-		Email:  mahasiswa.Email,
-		Role:   "mahasiswa",
+// Login resolves the account an identifier names and logs it in. Mahasiswa emails and NIMs
+// are looked up before admin usernames and emails; the role follows the mahasiswa status.
+func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	identifier := strings.TrimSpace(req.Identifier)
+
+	var mahasiswa *entity.Mahasiswa
+	var admin *entity.AdminUser
+	var err error
+	if strings.Contains(identifier, "@") {
+		if mahasiswa, err = s.mahasiswaRepo.GetByEmail(ctx, identifier); err == nil && mahasiswa == nil {
+			admin, err = s.adminRepo.GetByEmail(ctx, identifier)
+		}
+	} else {
+		if mahasiswa, err = s.mahasiswaRepo.GetByNIM(ctx, identifier); err == nil && mahasiswa == nil {
+			admin, err = s.adminRepo.GetByUsername(ctx, identifier)
+		}
 	}
-
-	token, expiresAt, err := s.jwtUtil.GenerateToken(claims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, err
 	}
 
-	return &dto.LoginResponse{
-		Token:     token,
-		User:      mahasiswa.ToResponse(),
-		Role:      "mahasiswa",
-		ExpiresAt: expiresAt.Unix(),
-	}, nil
+	if admin != nil {
+		return s.loginAdmin(ctx, identifier, admin, req.Password, req.Client())
+	}
+	// Unknown identifiers fail like a wrong mahasiswa password
+	return s.loginMahasiswa(ctx, identifier, mahasiswa, req.Password, req.Client())
 }
 
-func (s *authService) LoginAlumni(req *dto.AlumniLoginRequest) (*dto.LoginResponse, error) {
-	ctx := context.Background()
-	
+// LoginMahasiswa is the alias of Login for mahasiswa and alumni emails
+func (s *authService) LoginMahasiswa(ctx context.Context, req *dto.MahasiswaLoginRequest) (*dto.LoginResponse, error) {
 	// Find mahasiswa by email
 	mahasiswa, err := s.mahasiswaRepo.GetByEmail(ctx, req.Email)
-	if err != nil || mahasiswa == nil {
-		return nil, errors.New("invalid credentials")
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if mahasiswa has graduated (is alumni)
-	if !mahasiswa.IsAlumni() {
-		return nil, errors.New("account is not an alumni account")
+	return s.loginMahasiswa(ctx, req.Email, mahasiswa, req.Password, req.Client())
+}
+
+// LoginAlumni is the same as LoginMahasiswa: a graduated account logs in as alumni on either
+func (s *authService) LoginAlumni(ctx context.Context, req *dto.AlumniLoginRequest) (*dto.LoginResponse, error) {
+	return s.LoginMahasiswa(ctx, &dto.MahasiswaLoginRequest{
+		Email:      req.Email,
+		Password:   req.Password,
+		DeviceID:   req.DeviceID,
+		DeviceName: req.DeviceName,
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+	})
+}
+
+// loginMahasiswa checks the password of the mahasiswa found for identifier, nil for an
+// unknown account, and issues tokens for the role of its current status
+func (s *authService) loginMahasiswa(ctx context.Context, identifier string, mahasiswa *entity.Mahasiswa, password string, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	// Attempts count against the account whichever identifier named it
+	attempt := newLoginAttempt("mahasiswa", identifier, client.IPAddress)
+	if mahasiswa != nil {
+		attempt = newLoginAttempt(mahasiswa.Role(), mahasiswa.Email, client.IPAddress)
+	}
+	if err := s.attempts.Check(ctx, attempt); err != nil {
+		return nil, err
 	}
 
 	// Verify password
-	if !s.bcryptUtil.CheckPasswordHash(req.Password, mahasiswa.Password) {
-		return nil, errors.New("invalid credentials")
+	if err := s.checkPassword(ctx, attempt, password, mahasiswa, true); err != nil {
+		return nil, err
 	}
 
-	// Generate JWT token
+	if s.requireEmailVerification && !mahasiswa.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	// Issue access and refresh tokens
 	claims := &service.JWTClaims{
 		UserID: mahasiswa.ID,
 		Email:  mahasiswa.Email,
-		Role:   "alumni",
-	}
+		Role:   mahasiswa.Role(),
 
-	token, expiresAt, err := s.jwtUtil.GenerateToken(claims)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		Permissions: mahasiswa.Permissions(),
 	}
 
-	return &dto.LoginResponse{
-		Token:     token,
-		User:      mahasiswa.ToResponse(),
-		Role:      "alumni",
-		ExpiresAt: expiresAt.Unix(),
-	}, nil
+	return s.login(ctx, claims, mahasiswa.ToResponse(), client)
 }
 
 // RegisterMahasiswa creates a new mahasiswa account
-func (s *authService) RegisterMahasiswa(req *dto.RegisterMahasiswaRequest) (*dto.RegisterResponse, error) {
-	ctx := context.Background()
-	
+func (s *authService) RegisterMahasiswa(ctx context.Context, req *dto.RegisterMahasiswaRequest) (*dto.RegisterResponse, error) {
 	// Check if email already exists
 	existingMahasiswa, _ := s.mahasiswaRepo.GetByEmail(ctx, req.Email)
 	if existingMahasiswa != nil {
@@ -123,104 +182,217 @@ func (s *authService) RegisterMahasiswa(req *dto.RegisterMahasiswaRequest) (*dto
 		return nil, errors.New("NIM already registered")
 	}
 	
-	// Hash password
-	hashedPassword, err := s.bcryptUtil.HashPassword(req.Password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
-	}
-	
 	// Create mahasiswa entity
 	mahasiswa := &entity.Mahasiswa{
 		NIM:      req.NIM,
 		Nama:     req.Nama,
 		Email:    req.Email,
-		Password: hashedPassword,
 		Jurusan:  req.Jurusan,
 		Angkatan: req.Angkatan,
 	}
-	
-	// Save to database
-	if err := s.mahasiswaRepo.Create(ctx, mahasiswa); err != nil {
-		return nil, fmt.Errorf("failed to create mahasiswa: %w", err)
+	if err := s.passwords.Check(ctx, req.Password, mahasiswaPasswordSubject(mahasiswa)); err != nil {
+		return nil, err
 	}
 	
-	return &dto.RegisterResponse{
-		ID:      int64(mahasiswa.ID),
-		Message: "Mahasiswa registered successfully",
-	}, nil
-}
-
-// GraduateMahasiswa marks a mahasiswa as graduated (alumni)
-func (s *authService) GraduateMahasiswa(req *dto.GraduateMahasiswaRequest) (*dto.RegisterResponse, error) {
-	ctx := context.Background()
-	
-	// Find mahasiswa by ID
-	mahasiswa, err := s.mahasiswaRepo.GetByID(ctx, req.MahasiswaID)
-	if err != nil || mahasiswa == nil {
-		return nil, errors.New("mahasiswa not found")
+	// Hash password
+	hashedPassword, err := s.bcryptUtil.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
+	mahasiswa.Password = hashedPassword
 	
-	// Check if already graduated
-	if mahasiswa.IsAlumni() {
-		return nil, errors.New("mahasiswa is already graduated")
+	// Save to database
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.mahasiswaRepo.Create(ctx, mahasiswa); err != nil {
+			return fmt.Errorf("failed to create mahasiswa: %w", err)
+		}
+		return s.passwords.Remember(ctx, "mahasiswa", mahasiswa.ID, hashedPassword)
+	})
+	if err != nil {
+		return nil, err
 	}
 	
-	// Graduate the mahasiswa
-	mahasiswa.Graduate(req.TahunLulus, req.NoTelepon, req.AlamatAlumni)
-	
-	// Update in database
-	if err := s.mahasiswaRepo.Update(ctx, req.MahasiswaID, mahasiswa); err != nil {
-		return nil, fmt.Errorf("failed to graduate mahasiswa: %w", err)
+	// The account exists either way; a failed email can be requested again via the resend endpoint
+	if err := s.verification.SendVerification(ctx, mahasiswa); err != nil {
+		log.Printf("failed to start email verification for mahasiswa %d: %v", mahasiswa.ID, err)
 	}
 	
 	return &dto.RegisterResponse{
 		ID:      int64(mahasiswa.ID),
-		Message: "Mahasiswa graduated successfully",
+		Message: "Mahasiswa registered successfully",
 	}, nil
 }
 
-func (s *authService) LoginAdmin(req *dto.AdminLoginRequest) (*dto.LoginResponse, error) {
-	ctx := context.Background()
-	
+// LoginAdmin is the alias of Login for admin usernames
+func (s *authService) LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest) (*dto.LoginResponse, error) {
 	// Find admin by username
 	admin, err := s.adminRepo.GetByUsername(ctx, req.Username)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, err
+	}
+
+	return s.loginAdmin(ctx, req.Username, admin, req.Password, req.Client())
+}
+
+// loginAdmin checks the password of the admin found for identifier, nil for an unknown
+// account, and issues tokens or, with MFA on, an MFA challenge
+func (s *authService) loginAdmin(ctx context.Context, identifier string, admin *entity.AdminUser, password string, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	attempt := newLoginAttempt("admin", identifier, client.IPAddress)
+	if admin != nil {
+		attempt = newLoginAttempt("admin", admin.Username, client.IPAddress)
+	}
+	if err := s.attempts.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	// Verify password before telling anything about the account. With MFA on, the
+	// login only succeeds once the code is verified too.
+	mfaEnabled := admin != nil && admin.TOTPEnabled
+	if err := s.checkPassword(ctx, attempt, password, admin, !mfaEnabled); err != nil {
+		return nil, err
 	}
 
 	// Check if admin is active
 	if !admin.IsActive {
-		return nil, errors.New("admin account is inactive")
+		return nil, ErrAdminInactive
 	}
 
-	// Verify password
-	if !s.bcryptUtil.CheckPasswordHash(req.Password, admin.Password) {
-		return nil, errors.New("invalid credentials")
+	if mfaEnabled {
+		return s.mfaChallenge(admin)
 	}
 
-	// Generate JWT token
-	claims := &service.JWTClaims{
-		UserID:   admin.ID,
-		Email:    admin.Email,
-		Role:     "admin",
-		Username: admin.Username,
+	// Issue access and refresh tokens
+	return s.login(ctx, s.adminClaims(admin), admin.ToResponse(), client)
+}
+
+// LoginExternal issues tokens for the account linked to an identity the provider has
+// authenticated. An admin with MFA on still has to enter the TOTP code.
+func (s *authService) LoginExternal(ctx context.Context, identity *entity.ExternalIdentity, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	if identity.UserType == "admin" {
+		admin, err := s.adminRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if admin == nil {
+			return nil, ErrInvalidCredentials
+		}
+		if !admin.IsActive {
+			return nil, ErrAdminInactive
+		}
+		if admin.TOTPEnabled {
+			return s.mfaChallenge(admin)
+		}
+		return s.login(ctx, s.adminClaims(admin), admin.ToResponse(), client)
 	}
 
-	token, expiresAt, err := s.jwtUtil.GenerateToken(claims)
+	mahasiswa, err := s.mahasiswaRepo.GetByID(ctx, identity.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, err
 	}
+	if mahasiswa == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// The provider vouches for the person, so the email need not be verified here
+	claims := &service.JWTClaims{
+		UserID: mahasiswa.ID,
+		Email:  mahasiswa.Email,
+		Role:   mahasiswa.Role(),
 
+		Permissions: mahasiswa.Permissions(),
+	}
+	return s.login(ctx, claims, mahasiswa.ToResponse(), client)
+}
+
+// mfaChallenge answers a login of an admin with MFA on with a token for the TOTP step
+func (s *authService) mfaChallenge(admin *entity.AdminUser) (*dto.LoginResponse, error) {
+	token, expiresAt, err := s.tokens.GenerateChallengeToken(admin.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate MFA token: %w", err)
+	}
 	return &dto.LoginResponse{
-		Token:     token,
-		User:      admin.ToResponse(),
-		Role:      "admin",
-		ExpiresAt: expiresAt.Unix(),
+		MFAChallenge: &dto.MFAChallengeResponse{
+			MFAToken:  token,
+			ExpiresAt: expiresAt.Unix(),
+		},
 	}, nil
 }
 
-func (s *authService) ValidateToken(token string) (*service.JWTClaims, error) {
-	return s.jwtUtil.ValidateToken(token)
+// VerifyAdminMFA checks the TOTP or recovery code for an MFA challenge and issues the
+// tokens. Wrong codes count as failed logins of the admin, and a challenge is used once.
+func (s *authService) VerifyAdminMFA(ctx context.Context, req *dto.AdminMFAVerifyRequest) (*dto.LoginResponse, error) {
+	challenge, err := s.tokens.ValidateChallengeToken(req.MFAToken)
+	if err != nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+	revoked, err := s.revocations.IsTokenRevoked(ctx, challenge.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	admin, err := s.adminRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || !admin.IsActive || !admin.TOTPEnabled {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	attempt := newLoginAttempt("admin", admin.Username, req.IPAddress)
+	if err := s.attempts.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfa.VerifyCode(ctx, admin, req.Code); err != nil {
+		if !errors.Is(err, ErrInvalidMFACode) {
+			return nil, err
+		}
+		if err := s.attempts.RecordFailure(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := s.attempts.RecordSuccess(ctx, attempt); err != nil {
+		return nil, err
+	}
+	if err := s.revocations.RevokeToken(ctx, challenge.TokenID, challenge.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return s.login(ctx, s.adminClaims(admin), admin.ToResponse(), req.Client())
+}
+
+// ValidateToken checks the signature and expiry of an access token, then rejects
+// revoked tokens, tokens of ended sessions and tokens of accounts that were deleted or
+// disabled since. The role of a mahasiswa token follows the current status of the account.
+func (s *authService) ValidateToken(ctx context.Context, token string) (*service.JWTClaims, error) {
+
+	claims, err := s.tokens.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccountEnabled(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkImpersonator(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
 }
 
 // Legacy methods for backward compatibility
@@ -244,7 +416,7 @@ func (s *authService) ValidateAdminCredentials(ctx context.Context, username, pa
 	}
 
 	if !admin.IsActive {
-		return nil, errors.New("admin account is inactive")
+		return nil, ErrAdminInactive
 	}
 
 	if !s.bcryptUtil.CheckPasswordHash(password, admin.Password) {
@@ -262,19 +434,93 @@ func (s *authService) GenerateToken(ctx context.Context, user interface{}) (stri
 		claims = &service.JWTClaims{
 			UserID: u.ID,
 			Email:  u.Email,
-			Role:   "mahasiswa",
+			Role:   u.Role(),
+
+			Permissions: u.Permissions(),
 		}
 	case *entity.AdminUser:
-		claims = &service.JWTClaims{
-			UserID:   u.ID,
-			Email:    u.Email,
-			Role:     "admin",
-			Username: u.Username,
-		}
+		claims = s.adminClaims(u)
 	default:
 		return "", errors.New("unsupported user type")
 	}
 
-	token, _, err := s.jwtUtil.GenerateToken(claims)
+	token, _, err := s.tokens.GenerateToken(claims)
 	return token, err
-}
\ No newline at end of file
+}
+
+// newLoginAttempt describes a login for the attempt tracker; identifiers are compared case-insensitively
+func newLoginAttempt(userType, identifier, ipAddress string) *entity.LoginAttempt {
+	return &entity.LoginAttempt{
+		UserType:   userType,
+		Identifier: strings.ToLower(strings.TrimSpace(identifier)),
+		IPAddress:  ipAddress,
+	}
+}
+
+// checkPassword verifies password against the account found for a login, which is nil
+// for an unknown account, and records the attempt. Unknown accounts are checked against
+// a dummy hash so that they fail as slowly as a wrong password. A right password is
+// recorded as a successful login only when it completes the login.
+func (s *authService) checkPassword(ctx context.Context, attempt *entity.LoginAttempt, password string, account interface{}, completesLogin bool) error {
+	var hashedPassword string
+	switch a := account.(type) {
+	case *entity.Mahasiswa:
+		if a != nil {
+			hashedPassword = a.Password
+		}
+	case *entity.AdminUser:
+		if a != nil {
+			hashedPassword = a.Password
+		}
+	}
+
+	if hashedPassword == "" {
+		s.bcryptUtil.CheckPasswordHash(password, s.dummyPasswordHash())
+	} else if s.bcryptUtil.CheckPasswordHash(password, hashedPassword) {
+		s.rehashPassword(ctx, account, password, hashedPassword)
+		if !completesLogin {
+			return nil
+		}
+		return s.attempts.RecordSuccess(ctx, attempt)
+	}
+
+	if err := s.attempts.RecordFailure(ctx, attempt); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
+}
+
+// rehashPassword stores password hashed with the configured bcrypt cost when hashedPassword,
+// which it was just checked against, has another cost. The login goes on if that fails.
+func (s *authService) rehashPassword(ctx context.Context, account interface{}, password, hashedPassword string) {
+	if !s.bcryptUtil.NeedsRehash(hashedPassword) {
+		return
+	}
+	hash, err := s.bcryptUtil.HashPassword(password)
+	if err != nil {
+		log.Printf("failed to rehash password: %v", err)
+		return
+	}
+
+	switch a := account.(type) {
+	case *entity.Mahasiswa:
+		if err = s.mahasiswaRepo.Update(ctx, a.ID, &entity.Mahasiswa{Password: hash}); err != nil {
+			log.Printf("failed to rehash password of mahasiswa %d: %v", a.ID, err)
+		}
+	case *entity.AdminUser:
+		if err = s.adminRepo.RehashPassword(ctx, a.ID, hash); err != nil {
+			log.Printf("failed to rehash password of admin %d: %v", a.ID, err)
+		}
+	}
+}
+
+func (s *authService) dummyPasswordHash() string {
+	s.dummyHashOnce.Do(func() {
+		hash, err := s.bcryptUtil.HashPassword("dummy-password-for-unknown-accounts")
+		if err != nil {
+			log.Printf("Warning: failed to create dummy password hash: %v", err)
+		}
+		s.dummyHash = hash
+	})
+	return s.dummyHash
+}
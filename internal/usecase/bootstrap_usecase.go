@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var (
+	ErrSetupUnavailable       = errors.New("setup sudah selesai atau tidak tersedia")
+	ErrInvalidSetupToken      = errors.New("setup token tidak valid")
+	ErrDefaultAdminPassword   = errors.New("admin masih menggunakan password default")
+	ErrIncompleteInitialAdmin = errors.New("username, email, dan password admin awal harus diisi bersama")
+)
+
+// defaultAdminPasswords are passwords published with earlier versions or commonly used as defaults.
+// An admin using one of them is treated as unprotected.
+var defaultAdminPasswords = []string{"admin123", "password", "changeme"}
+
+// adminScanPageSize is the page size used when checking every admin for default passwords
+const adminScanPageSize = 100
+
+type bootstrapUsecase struct {
+	adminRepo  repository.AdminUserRepository
+	uow        repository.UnitOfWork
+	bcryptUtil *bcrypt.BcryptUtil
+	passwords  service.PasswordPolicyService
+	production bool
+
+	// setupTokenHash is the hash of the pending one-time setup token, empty when there is none
+	mu             sync.Mutex
+	setupTokenHash string
+}
+
+func NewBootstrapUsecase(
+	adminRepo repository.AdminUserRepository,
+	uow repository.UnitOfWork,
+	bcryptUtil *bcrypt.BcryptUtil,
+	passwords service.PasswordPolicyService,
+	production bool,
+) service.BootstrapService {
+	return &bootstrapUsecase{
+		adminRepo:  adminRepo,
+		uow:        uow,
+		bcryptUtil: bcryptUtil,
+		passwords:  passwords,
+		production: production,
+	}
+}
+
+func (u *bootstrapUsecase) Bootstrap(ctx context.Context, initial *dto.InitialAdmin) (string, error) {
+	_, total, err := u.adminRepo.GetAll(ctx, 1, 0)
+	if err != nil {
+		return "", err
+	}
+
+	if total > 0 {
+		if initial != nil && initial.Username != "" {
+			log.Println("Admins already exist, ignoring initial admin credentials")
+		}
+		return "", u.checkDefaultPasswords(ctx)
+	}
+
+	if initial == nil || (initial.Username == "" && initial.Email == "" && initial.Password == "") {
+		return u.newSetupToken()
+	}
+
+	if initial.Username == "" || initial.Email == "" || initial.Password == "" {
+		return "", ErrIncompleteInitialAdmin
+	}
+	if isDefaultAdminPassword(initial.Password) {
+		return "", ErrDefaultAdminPassword
+	}
+
+	// The password was handed over through the environment or shell history, so it is replaced on first login
+	admin := &entity.AdminUser{
+		Username: initial.Username,
+		Email:    initial.Email,
+		Role:     entity.AdminRoleSuperAdmin,
+		IsActive: true,
+
+		MustChangePassword: true,
+	}
+	if err := u.passwords.Check(ctx, initial.Password, adminPasswordSubject(admin)); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := u.bcryptUtil.HashPassword(initial.Password)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	admin.Password = hashedPassword
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.adminRepo.Create(ctx, admin); err != nil {
+			return err
+		}
+		return u.passwords.Remember(ctx, "admin", admin.ID, hashedPassword)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("Initial super admin %q created, the password must be changed on first login", admin.Username)
+	return "", nil
+}
+
+func (u *bootstrapUsecase) SetupAdmin(ctx context.Context, req *dto.SetupAdminRequest) (*entity.AdminUser, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.setupTokenHash == "" {
+		return nil, ErrSetupUnavailable
+	}
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(req.SetupToken)), []byte(u.setupTokenHash)) != 1 {
+		return nil, ErrInvalidSetupToken
+	}
+	if isDefaultAdminPassword(req.Password) {
+		return nil, ErrDefaultAdminPassword
+	}
+
+	admin := &entity.AdminUser{
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     entity.AdminRoleSuperAdmin,
+		IsActive: true,
+	}
+	if err := u.passwords.Check(ctx, req.Password, adminPasswordSubject(admin)); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := u.bcryptUtil.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	admin.Password = hashedPassword
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		// Another instance or a migration may have added an admin since startup
+		_, total, err := u.adminRepo.GetAll(ctx, 1, 0)
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return ErrSetupUnavailable
+		}
+		if err := u.adminRepo.Create(ctx, admin); err != nil {
+			return err
+		}
+		return u.passwords.Remember(ctx, "admin", admin.ID, hashedPassword)
+	})
+	if errors.Is(err, ErrSetupUnavailable) {
+		u.setupTokenHash = ""
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u.setupTokenHash = ""
+	return admin, nil
+}
+
+// newSetupToken replaces the pending setup token with a new one
+func (u *bootstrapUsecase) newSetupToken() (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate setup token: %w", err)
+	}
+
+	u.mu.Lock()
+	u.setupTokenHash = utils.HashToken(token)
+	u.mu.Unlock()
+
+	return token, nil
+}
+
+// checkDefaultPasswords finds admins that still use a default password. In production
+// that is fatal; elsewhere those admins have to change their password on next login.
+func (u *bootstrapUsecase) checkDefaultPasswords(ctx context.Context) error {
+	var exposed []*entity.AdminUser
+	for offset := 0; ; offset += adminScanPageSize {
+		admins, total, err := u.adminRepo.GetAll(ctx, adminScanPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, admin := range admins {
+			if u.hasDefaultPassword(admin) {
+				exposed = append(exposed, admin)
+			}
+		}
+		if int64(offset+adminScanPageSize) >= total {
+			break
+		}
+	}
+
+	if len(exposed) == 0 {
+		return nil
+	}
+
+	usernames := make([]string, 0, len(exposed))
+	for _, admin := range exposed {
+		usernames = append(usernames, admin.Username)
+	}
+
+	if u.production {
+		return fmt.Errorf("%w: %s", ErrDefaultAdminPassword, strings.Join(usernames, ", "))
+	}
+
+	for _, admin := range exposed {
+		if admin.MustChangePassword {
+			continue
+		}
+		if err := u.adminRepo.RequirePasswordChange(ctx, admin.ID); err != nil {
+			return err
+		}
+	}
+	log.Printf("Warning: admins with a default password must change it on next login: %s", strings.Join(usernames, ", "))
+	return nil
+}
+
+func (u *bootstrapUsecase) hasDefaultPassword(admin *entity.AdminUser) bool {
+	for _, password := range defaultAdminPasswords {
+		if u.bcryptUtil.CheckPasswordHash(password, admin.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDefaultAdminPassword(password string) bool {
+	for _, p := range defaultAdminPasswords {
+		if password == p {
+			return true
+		}
+	}
+	return false
+}
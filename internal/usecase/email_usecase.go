@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/mailer"
+)
+
+type emailService struct {
+	mailer  mailer.Mailer
+	baseURL string
+}
+
+// NewEmailService composes the application emails and delivers them through mailer.
+// baseURL is the public address of the API, used in links.
+func NewEmailService(mailer mailer.Mailer, baseURL string) service.EmailService {
+	return &emailService{
+		mailer:  mailer,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *emailService) SendWelcomeEmail(ctx context.Context, email, name, verificationToken string) error {
+	body := fmt.Sprintf(`Halo %s,
+
+Akun Anda berhasil dibuat. Selamat datang!
+
+Buka tautan berikut untuk memverifikasi alamat email Anda:
+%s/api/v1/auth/verify-email?token=%s
+
+Abaikan email ini jika Anda tidak merasa mendaftar.`,
+		name, s.baseURL, url.QueryEscape(verificationToken))
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Selamat datang",
+		Body:    body,
+	})
+}
+
+func (s *emailService) SendPasswordResetEmail(ctx context.Context, email, resetToken string) error {
+	body := fmt.Sprintf(`Kami menerima permintaan untuk mengatur ulang password akun Anda.
+
+Token reset password: %s
+
+Kirim token ini bersama password baru ke:
+POST %s/api/v1/auth/password/reset
+
+Token hanya dapat digunakan satu kali. Abaikan email ini jika Anda tidak meminta reset password.`,
+		resetToken, s.baseURL)
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Reset password",
+		Body:    body,
+	})
+}
+
+func (s *emailService) SendGraduationNotification(ctx context.Context, mahasiswa *entity.Mahasiswa, request *entity.GraduationRequest) error {
+	var subject, body string
+	switch request.Status {
+	case entity.GraduationRequestApproved:
+		subject = "Selamat atas kelulusan Anda"
+		body = fmt.Sprintf(`Halo %s,
+
+Selamat! Pengajuan kelulusan Anda disetujui dan status Anda telah berubah menjadi alumni. Anda sekarang dapat login sebagai alumni dan mengelola data pekerjaan Anda.
+
+Catatan admin: %s`,
+			mahasiswa.Nama, request.ReviewReason)
+	case entity.GraduationRequestRejected:
+		subject = "Pengajuan kelulusan ditolak"
+		body = fmt.Sprintf(`Halo %s,
+
+Pengajuan kelulusan Anda dengan tahun lulus %d ditolak.
+
+Alasan: %s
+
+Anda dapat mengajukan kembali setelah melengkapi persyaratan.`,
+			mahasiswa.Nama, request.TahunLulus, request.ReviewReason)
+	default:
+		subject = "Pengajuan kelulusan diterima"
+		body = fmt.Sprintf(`Halo %s,
+
+Pengajuan kelulusan Anda dengan tahun lulus %d telah diterima dan menunggu review admin. Kami akan mengabari Anda setelah pengajuan direview.`,
+			mahasiswa.Nama, request.TahunLulus)
+	}
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      mahasiswa.Email,
+		Subject: subject,
+		Body:    body,
+	})
+}
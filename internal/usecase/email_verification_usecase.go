@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+
+// verificationEmailTimeout bounds a verification email sent in the background
+const verificationEmailTimeout = 30 * time.Second
+
+type emailVerificationUsecase struct {
+	mahasiswaRepo  repository.MahasiswaRepository
+	tokenRepo      repository.EmailVerificationTokenRepository
+	uow            repository.UnitOfWork
+	emailService   service.EmailService
+	expire         time.Duration
+	resendInterval time.Duration
+}
+
+func NewEmailVerificationUsecase(
+	mahasiswaRepo repository.MahasiswaRepository,
+	tokenRepo repository.EmailVerificationTokenRepository,
+	uow repository.UnitOfWork,
+	emailService service.EmailService,
+	expire time.Duration,
+	resendInterval time.Duration,
+) service.EmailVerificationService {
+	return &emailVerificationUsecase{
+		mahasiswaRepo:  mahasiswaRepo,
+		tokenRepo:      tokenRepo,
+		uow:            uow,
+		emailService:   emailService,
+		expire:         expire,
+		resendInterval: resendInterval,
+	}
+}
+
+func (u *emailVerificationUsecase) SendVerification(ctx context.Context, mahasiswa *entity.Mahasiswa) error {
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.tokenRepo.InvalidateForMahasiswa(ctx, mahasiswa.ID); err != nil {
+			return err
+		}
+
+		return u.tokenRepo.Create(ctx, &entity.EmailVerificationToken{
+			TokenHash:   utils.HashToken(rawToken),
+			MahasiswaID: mahasiswa.ID,
+			ExpiresAt:   time.Now().Add(u.expire),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	email, name := mahasiswa.Email, mahasiswa.Nama
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), verificationEmailTimeout)
+		defer cancel()
+
+		if err := u.emailService.SendWelcomeEmail(ctx, email, name, rawToken); err != nil {
+			log.Printf("failed to send verification email to mahasiswa %d: %v", mahasiswa.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+func (u *emailVerificationUsecase) VerifyEmail(ctx context.Context, rawToken string) error {
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		token, err := u.tokenRepo.GetByHash(ctx, utils.HashToken(rawToken))
+		if err != nil {
+			return err
+		}
+		if token == nil || !token.IsUsable() {
+			return ErrInvalidVerificationToken
+		}
+
+		used, err := u.tokenRepo.MarkUsed(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidVerificationToken
+		}
+
+		return u.mahasiswaRepo.MarkEmailVerified(ctx, token.MahasiswaID)
+	})
+}
+
+func (u *emailVerificationUsecase) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error {
+	mahasiswa, err := u.mahasiswaRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if mahasiswa == nil || mahasiswa.IsEmailVerified() {
+		// Nothing to send; answer as if it was sent
+		return nil
+	}
+
+	latest, err := u.tokenRepo.GetLatestByMahasiswaID(ctx, mahasiswa.ID)
+	if err != nil {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < u.resendInterval {
+		// Throttled; answer as if it was sent so the response does not reveal the account
+		return nil
+	}
+
+	return u.SendVerification(ctx, mahasiswa)
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var (
+	ErrGraduationRequestNotFound = errors.New("pengajuan kelulusan tidak ditemukan")
+	ErrGraduationRequestPending  = errors.New("masih ada pengajuan kelulusan yang menunggu review")
+	ErrGraduationRequestReviewed = errors.New("pengajuan kelulusan sudah direview")
+	ErrNotEligibleForGraduation  = errors.New("hanya mahasiswa aktif yang dapat mengajukan kelulusan")
+)
+
+// graduationEmailTimeout bounds a graduation notification sent in the background
+const graduationEmailTimeout = 30 * time.Second
+
+type graduationUsecase struct {
+	requestRepo   repository.GraduationRequestRepository
+	mahasiswaRepo repository.MahasiswaRepository
+	lifecycle     service.MahasiswaLifecycleService
+	emailService  service.EmailService
+	uow           repository.UnitOfWork
+}
+
+func NewGraduationUsecase(
+	requestRepo repository.GraduationRequestRepository,
+	mahasiswaRepo repository.MahasiswaRepository,
+	lifecycle service.MahasiswaLifecycleService,
+	emailService service.EmailService,
+	uow repository.UnitOfWork,
+) service.GraduationService {
+	return &graduationUsecase{
+		requestRepo:   requestRepo,
+		mahasiswaRepo: mahasiswaRepo,
+		lifecycle:     lifecycle,
+		emailService:  emailService,
+		uow:           uow,
+	}
+}
+
+func (u *graduationUsecase) Submit(ctx context.Context, mahasiswaID uint, req *dto.SubmitGraduationRequest) (*entity.GraduationRequest, error) {
+	request := &entity.GraduationRequest{
+		MahasiswaID:  mahasiswaID,
+		TahunLulus:   req.TahunLulus,
+		NoTelepon:    req.NoTelepon,
+		AlamatAlumni: req.AlamatAlumni,
+		DocumentRef:  req.DocumentRef,
+		Status:       entity.GraduationRequestPending,
+	}
+
+	var mahasiswa *entity.Mahasiswa
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		// Locking the mahasiswa serializes concurrent submissions, so only one finds no pending request
+		var err error
+		mahasiswa, err = u.mahasiswaRepo.GetByIDForUpdate(ctx, mahasiswaID)
+		if err != nil {
+			return err
+		}
+		if mahasiswa == nil {
+			return ErrMahasiswaNotFound
+		}
+		if !mahasiswa.IsActive() {
+			return ErrNotEligibleForGraduation
+		}
+
+		pending, err := u.requestRepo.GetPendingByMahasiswa(ctx, mahasiswa.ID)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			return ErrGraduationRequestPending
+		}
+		return u.requestRepo.Create(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.notify(mahasiswa, request)
+	return request, nil
+}
+
+func (u *graduationUsecase) GetOwn(ctx context.Context, mahasiswaID uint) ([]*entity.GraduationRequest, error) {
+	return u.requestRepo.ListByMahasiswa(ctx, mahasiswaID)
+}
+
+func (u *graduationUsecase) List(ctx context.Context, status entity.GraduationRequestStatus, limit, offset int) ([]*entity.GraduationRequest, int64, error) {
+	return u.requestRepo.List(ctx, status, limit, offset)
+}
+
+func (u *graduationUsecase) GetByID(ctx context.Context, id uint) (*entity.GraduationRequest, error) {
+	request, err := u.requestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrGraduationRequestNotFound
+	}
+	return request, nil
+}
+
+func (u *graduationUsecase) Approve(ctx context.Context, id uint, req *dto.ReviewGraduationRequest, reviewer *service.JWTClaims) (*entity.GraduationRequest, error) {
+	var request *entity.GraduationRequest
+	var mahasiswa *entity.Mahasiswa
+
+	// The review is undone when the mahasiswa cannot graduate, e.g. after a suspension
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		request, err = u.review(ctx, id, entity.GraduationRequestApproved, req.Reason, reviewer)
+		if err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("pengajuan kelulusan #%d disetujui: %s", request.ID, req.Reason)
+		mahasiswa, _, err = u.lifecycle.Graduate(ctx, request, reason, reviewer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.notify(mahasiswa, request)
+	return request, nil
+}
+
+func (u *graduationUsecase) Reject(ctx context.Context, id uint, req *dto.ReviewGraduationRequest, reviewer *service.JWTClaims) (*entity.GraduationRequest, error) {
+	request, err := u.review(ctx, id, entity.GraduationRequestRejected, req.Reason, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, request.MahasiswaID)
+	if err != nil {
+		return nil, err
+	}
+	if mahasiswa != nil {
+		u.notify(mahasiswa, request)
+	}
+	return request, nil
+}
+
+// review records the decision on a pending request. Of two admins reviewing at once,
+// the second gets ErrGraduationRequestReviewed.
+func (u *graduationUsecase) review(ctx context.Context, id uint, status entity.GraduationRequestStatus, reason string, reviewer *service.JWTClaims) (*entity.GraduationRequest, error) {
+	request, err := u.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsPending() {
+		return nil, ErrGraduationRequestReviewed
+	}
+
+	reviewerID := reviewer.UserID
+	request.Status = status
+	request.ReviewReason = reason
+	request.ReviewedBy = &reviewerID
+
+	reviewed, err := u.requestRepo.Review(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		return nil, ErrGraduationRequestReviewed
+	}
+	return request, nil
+}
+
+// notify mails the mahasiswa about the request in the background, so a slow mail server
+// does not hold up the response. The step is done either way, so a failed mail is only logged.
+func (u *graduationUsecase) notify(mahasiswa *entity.Mahasiswa, request *entity.GraduationRequest) {
+	// Copies, as the caller goes on to use the originals
+	m, r := *mahasiswa, *request
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), graduationEmailTimeout)
+		defer cancel()
+
+		if err := u.emailService.SendGraduationNotification(ctx, &m, &r); err != nil {
+			log.Printf("failed to send graduation notification for request %d: %v", r.ID, err)
+		}
+	}()
+}
@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+// stalledEmailService is a mail server that does not answer until release is closed
+type stalledEmailService struct {
+	service.EmailService
+	release chan struct{}
+	sent    chan context.Context
+}
+
+func (s *stalledEmailService) SendGraduationNotification(ctx context.Context, _ *entity.Mahasiswa, _ *entity.GraduationRequest) error {
+	<-s.release
+	s.sent <- ctx
+	return nil
+}
+
+func TestGraduationNotificationDoesNotHoldUpTheRequest(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	uow := repository.NewUnitOfWork(db)
+	mahasiswaRepo := repository.NewMahasiswaRepository(db)
+	emails := &stalledEmailService{release: make(chan struct{}), sent: make(chan context.Context, 1)}
+	lifecycle := usecase.NewMahasiswaLifecycleUsecase(mahasiswaRepo, repository.NewMahasiswaStatusHistoryRepository(db), uow)
+	graduation := usecase.NewGraduationUsecase(repository.NewGraduationRequestRepository(db), mahasiswaRepo, lifecycle, emails, uow)
+
+	mahasiswa := &entity.Mahasiswa{NIM: "2201001", Nama: "Owner", Email: "owner@example.com", Jurusan: "Teknik Informatika", Angkatan: 2022, Password: "hash", Status: entity.StatusMahasiswaActive}
+	if err := mahasiswaRepo.Create(ctx, mahasiswa); err != nil {
+		t.Fatal(err)
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		_, err := graduation.Submit(ctx, mahasiswa.ID, &dto.SubmitGraduationRequest{TahunLulus: 2026, DocumentRef: "SK-001"})
+		submitted <- err
+	}()
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Submit() waited for the mail server")
+	}
+
+	close(emails.release)
+	select {
+	case mailCtx := <-emails.sent:
+		if _, ok := mailCtx.Deadline(); !ok {
+			t.Error("notification sent without a timeout")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification sent")
+	}
+}
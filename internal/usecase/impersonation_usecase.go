@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var ErrNestedImpersonation = errors.New("cannot impersonate while impersonating")
+
+type impersonationUsecase struct {
+	mahasiswaRepo repository.MahasiswaRepository
+	logRepo       repository.ImpersonationLogRepository
+	tokens        *TokenSigner
+	expire        time.Duration
+}
+
+func NewImpersonationUsecase(
+	mahasiswaRepo repository.MahasiswaRepository,
+	logRepo repository.ImpersonationLogRepository,
+	tokens *TokenSigner,
+	expire time.Duration,
+) service.ImpersonationService {
+	return &impersonationUsecase{
+		mahasiswaRepo: mahasiswaRepo,
+		logRepo:       logRepo,
+		tokens:        tokens,
+		expire:        expire,
+	}
+}
+
+func (u *impersonationUsecase) Impersonate(ctx context.Context, mahasiswaID uint, req *dto.ImpersonateRequest, actor *service.JWTClaims) (*dto.ImpersonationResponse, error) {
+	if actor.Impersonator != nil {
+		return nil, ErrNestedImpersonation
+	}
+
+	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, mahasiswaID)
+	if err != nil {
+		return nil, err
+	}
+	if mahasiswa == nil {
+		return nil, ErrMahasiswaNotFound
+	}
+
+	// The token sees what the mahasiswa sees, with the role of their current status
+	claims := &service.JWTClaims{
+		UserID: mahasiswa.ID,
+		Email:  mahasiswa.Email,
+		Role:   mahasiswa.Role(),
+
+		Permissions: mahasiswa.Permissions(),
+		Impersonator: &service.Impersonator{
+			AdminID:  actor.UserID,
+			Username: actor.Username,
+		},
+	}
+
+	token, expiresAt, err := u.tokens.GenerateTokenWithExpiry(claims, u.expire)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	// No token is handed out without its audit entry
+	entry := &entity.ImpersonationLog{
+		AdminID:       actor.UserID,
+		AdminUsername: actor.Username,
+		MahasiswaID:   mahasiswa.ID,
+		Role:          claims.Role,
+		Reason:        req.Reason,
+		TokenID:       claims.TokenID,
+		IPAddress:     req.IPAddress,
+		UserAgent:     utils.Truncate(req.UserAgent, 255),
+		ExpiresAt:     expiresAt,
+	}
+	if err := u.logRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &dto.ImpersonationResponse{
+		Token:     token,
+		User:      mahasiswa.ToResponse(),
+		Role:      claims.Role,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+func (u *impersonationUsecase) ListLogs(ctx context.Context, filter repository.ImpersonationLogFilter, limit, offset int) ([]*entity.ImpersonationLog, int64, error) {
+	return u.logRepo.List(ctx, filter, limit, offset)
+}
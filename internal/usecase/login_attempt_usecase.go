@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var (
+	ErrLoginLocked     = errors.New("login is temporarily blocked after failed attempts")
+	ErrLockoutNotFound = errors.New("lockout tidak ditemukan")
+)
+
+// LoginLockedError is returned while an account or client address has to wait before the next login
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, try again in %d seconds", ErrLoginLocked, e.RetryAfterSeconds())
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds for the Retry-After header
+func (e *LoginLockedError) RetryAfterSeconds() int {
+	seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// LoginAttemptPolicy configures how failed logins are slowed down and locked out
+type LoginAttemptPolicy struct {
+	// MaxAttempts is the number of failures of an account before it is locked out
+	MaxAttempts int
+	// DelayStep is the wait after the first failure; it doubles with every failure up to MaxAttempts
+	DelayStep time.Duration
+	// Lockout is the first lockout; it doubles with every failure after it, up to MaxLockout
+	Lockout    time.Duration
+	MaxLockout time.Duration
+	// IPMaxAttempts is the number of failures from one address, across accounts, before it is locked out for Lockout
+	IPMaxAttempts int
+	// Window is how long a failure counts; a failure after a quiet Window starts the count over
+	Window time.Duration
+}
+
+// maxBackoffShift keeps the doubling of delays from overflowing
+const maxBackoffShift = 20
+
+type loginAttemptUsecase struct {
+	attemptRepo repository.LoginAttemptRepository
+	lockoutRepo repository.LoginLockoutRepository
+	uow         repository.UnitOfWork
+	policy      LoginAttemptPolicy
+}
+
+func NewLoginAttemptUsecase(
+	attemptRepo repository.LoginAttemptRepository,
+	lockoutRepo repository.LoginLockoutRepository,
+	uow repository.UnitOfWork,
+	policy LoginAttemptPolicy,
+) service.LoginAttemptService {
+	return &loginAttemptUsecase{
+		attemptRepo: attemptRepo,
+		lockoutRepo: lockoutRepo,
+		uow:         uow,
+		policy:      policy,
+	}
+}
+
+func (u *loginAttemptUsecase) Check(ctx context.Context, attempt *entity.LoginAttempt) error {
+	now := time.Now()
+
+	var wait time.Duration
+	for _, lockout := range []struct{ scope, subject string }{
+		{accountScope(attempt), attempt.Identifier},
+		{entity.LoginLockoutScopeIP, attempt.IPAddress},
+	} {
+		if lockout.subject == "" {
+			continue
+		}
+		current, err := u.lockoutRepo.Get(ctx, lockout.scope, lockout.subject)
+		if err != nil {
+			return err
+		}
+		if current != nil && current.IsLocked(now) && current.LockedUntil.Sub(now) > wait {
+			wait = current.LockedUntil.Sub(now)
+		}
+	}
+
+	if wait == 0 {
+		return nil
+	}
+
+	attempt.Outcome = entity.LoginOutcomeLocked
+	if err := u.attemptRepo.Create(ctx, attempt); err != nil {
+		return err
+	}
+	return &LoginLockedError{RetryAfter: wait}
+}
+
+func (u *loginAttemptUsecase) RecordFailure(ctx context.Context, attempt *entity.LoginAttempt) error {
+	now := time.Now()
+	attempt.Outcome = entity.LoginOutcomeFailure
+
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.attemptRepo.Create(ctx, attempt); err != nil {
+			return err
+		}
+
+		account, err := u.registerFailure(ctx, accountScope(attempt), attempt.Identifier, now)
+		if err != nil {
+			return err
+		}
+		if wait := u.accountWait(account.FailedCount); wait > 0 {
+			lockedUntil := now.Add(wait)
+			if err := u.lockoutRepo.SetLockedUntil(ctx, account.ID, &lockedUntil); err != nil {
+				return err
+			}
+		}
+
+		if attempt.IPAddress == "" {
+			return nil
+		}
+		address, err := u.registerFailure(ctx, entity.LoginLockoutScopeIP, attempt.IPAddress, now)
+		if err != nil {
+			return err
+		}
+		if u.policy.IPMaxAttempts > 0 && address.FailedCount >= u.policy.IPMaxAttempts {
+			lockedUntil := now.Add(u.policy.Lockout)
+			return u.lockoutRepo.SetLockedUntil(ctx, address.ID, &lockedUntil)
+		}
+		return nil
+	})
+}
+
+func (u *loginAttemptUsecase) RecordSuccess(ctx context.Context, attempt *entity.LoginAttempt) error {
+	attempt.Outcome = entity.LoginOutcomeSuccess
+
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.attemptRepo.Create(ctx, attempt); err != nil {
+			return err
+		}
+		// Failures from the address still count: it may be guessing other accounts
+		return u.lockoutRepo.DeleteBySubject(ctx, accountScope(attempt), attempt.Identifier)
+	})
+}
+
+func (u *loginAttemptUsecase) ListLockouts(ctx context.Context, limit, offset int) ([]*entity.LoginLockout, int64, error) {
+	return u.lockoutRepo.ListLocked(ctx, time.Now(), limit, offset)
+}
+
+func (u *loginAttemptUsecase) ClearLockout(ctx context.Context, id uint) error {
+	lockout, err := u.lockoutRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if lockout == nil {
+		return ErrLockoutNotFound
+	}
+	return u.lockoutRepo.Delete(ctx, lockout.ID)
+}
+
+func (u *loginAttemptUsecase) ListAttempts(ctx context.Context, filter repository.LoginAttemptFilter, limit, offset int) ([]*entity.LoginAttempt, int64, error) {
+	return u.attemptRepo.List(ctx, filter, limit, offset)
+}
+
+// registerFailure counts a failure of the subject. The first failures of a subject can
+// race to create its row, so a failed attempt is rolled back to a savepoint and retried once.
+func (u *loginAttemptUsecase) registerFailure(ctx context.Context, scope, subject string, now time.Time) (*entity.LoginLockout, error) {
+	var lockout *entity.LoginLockout
+	register := func(ctx context.Context) error {
+		var err error
+		lockout, err = u.lockoutRepo.RegisterFailure(ctx, scope, subject, now, now.Add(-u.policy.Window))
+		return err
+	}
+
+	if err := u.uow.Do(ctx, register); err != nil {
+		if err := u.uow.Do(ctx, register); err != nil {
+			return nil, err
+		}
+	}
+	return lockout, nil
+}
+
+// accountWait is how long an account has to wait after its failures-th failure: a delay
+// that doubles up to MaxAttempts, then a lockout that doubles up to MaxLockout. A
+// MaxAttempts of 0 keeps the delays and never locks out.
+func (u *loginAttemptUsecase) accountWait(failures int) time.Duration {
+	if u.policy.MaxAttempts <= 0 || failures < u.policy.MaxAttempts {
+		return backoff(u.policy.DelayStep, failures-1, u.policy.Lockout)
+	}
+	return backoff(u.policy.Lockout, failures-u.policy.MaxAttempts, u.policy.MaxLockout)
+}
+
+// backoff doubles base shift times, capped at max
+func backoff(base time.Duration, shift int, max time.Duration) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	wait := base << uint(shift)
+	if max > 0 && wait > max {
+		return max
+	}
+	return wait
+}
+
+// accountScope is the lockout scope of the account an attempt targets. Mahasiswa and
+// alumni share credentials, so they share lockouts too.
+func accountScope(attempt *entity.LoginAttempt) string {
+	return userTypeForRole(attempt.UserType)
+}
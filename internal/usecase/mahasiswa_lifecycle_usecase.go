@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var (
+	ErrMahasiswaNotFound          = errors.New("mahasiswa tidak ditemukan")
+	ErrGraduationRequiresApproval = errors.New("mahasiswa hanya dapat diluluskan dengan menyetujui pengajuan kelulusan")
+)
+
+type MahasiswaLifecycleUsecase struct {
+	mahasiswaRepo repository.MahasiswaRepository
+	historyRepo   repository.MahasiswaStatusHistoryRepository
+	uow           repository.UnitOfWork
+}
+
+func NewMahasiswaLifecycleUsecase(
+	mahasiswaRepo repository.MahasiswaRepository,
+	historyRepo repository.MahasiswaStatusHistoryRepository,
+	uow repository.UnitOfWork,
+) service.MahasiswaLifecycleService {
+	return &MahasiswaLifecycleUsecase{
+		mahasiswaRepo: mahasiswaRepo,
+		historyRepo:   historyRepo,
+		uow:           uow,
+	}
+}
+
+func (u *MahasiswaLifecycleUsecase) ChangeStatus(ctx context.Context, mahasiswaID uint, req *dto.ChangeStatusRequest, actor *service.JWTClaims) (*entity.Mahasiswa, *entity.MahasiswaStatusHistory, error) {
+	transition := entity.StatusTransition(req.Transition)
+	// Kelulusan hanya lewat Graduate, setelah pengajuan kelulusan disetujui
+	if transition == entity.TransitionGraduate {
+		return nil, nil, ErrGraduationRequiresApproval
+	}
+
+	return u.changeStatus(ctx, mahasiswaID, transition, req.Reason, actor, nil)
+}
+
+func (u *MahasiswaLifecycleUsecase) Graduate(ctx context.Context, request *entity.GraduationRequest, reason string, actor *service.JWTClaims) (*entity.Mahasiswa, *entity.MahasiswaStatusHistory, error) {
+	return u.changeStatus(ctx, request.MahasiswaID, entity.TransitionGraduate, reason, actor, func(mahasiswa *entity.Mahasiswa) {
+		// Data kontak lama tetap dipakai jika tidak diisi
+		noTelepon, alamat := request.NoTelepon, request.AlamatAlumni
+		if noTelepon == "" {
+			noTelepon = mahasiswa.NoTelepon
+		}
+		if alamat == "" {
+			alamat = mahasiswa.AlamatAlumni
+		}
+		mahasiswa.Graduate(request.TahunLulus, noTelepon, alamat)
+	})
+}
+
+// changeStatus applies the transition and records it. apply, when not nil, fills in the
+// data that comes with the new status.
+func (u *MahasiswaLifecycleUsecase) changeStatus(ctx context.Context, mahasiswaID uint, transition entity.StatusTransition, reason string, actor *service.JWTClaims, apply func(*entity.Mahasiswa)) (*entity.Mahasiswa, *entity.MahasiswaStatusHistory, error) {
+	var mahasiswa *entity.Mahasiswa
+	var history *entity.MahasiswaStatusHistory
+
+	// Status dan riwayat disimpan dalam satu transaksi
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		mahasiswa, err = u.mahasiswaRepo.GetByID(ctx, mahasiswaID)
+		if err != nil {
+			return err
+		}
+		if mahasiswa == nil {
+			return ErrMahasiswaNotFound
+		}
+
+		from := mahasiswa.Status
+		if err := mahasiswa.ApplyTransition(transition); err != nil {
+			return err
+		}
+
+		if apply != nil {
+			apply(mahasiswa)
+		}
+
+		if err := u.mahasiswaRepo.UpdateStatus(ctx, mahasiswa, from); err != nil {
+			return err
+		}
+
+		history = &entity.MahasiswaStatusHistory{
+			MahasiswaID: mahasiswa.ID,
+			Transition:  transition,
+			FromStatus:  from,
+			ToStatus:    mahasiswa.Status,
+			Reason:      reason,
+			ActorRole:   "system",
+		}
+		if actor != nil {
+			actorID := actor.UserID
+			history.ActorID = &actorID
+			history.ActorRole = actor.Role
+		}
+
+		return u.historyRepo.Create(ctx, history)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mahasiswa, history, nil
+}
+
+func (u *MahasiswaLifecycleUsecase) GetStatusHistory(ctx context.Context, mahasiswaID uint) (*entity.Mahasiswa, []*entity.MahasiswaStatusHistory, error) {
+	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, mahasiswaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mahasiswa == nil {
+		return nil, nil, ErrMahasiswaNotFound
+	}
+
+	histories, err := u.historyRepo.GetByMahasiswaID(ctx, mahasiswaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mahasiswa, histories, nil
+}
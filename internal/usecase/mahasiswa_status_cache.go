@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+)
+
+// mahasiswaStatusCache remembers the status of mahasiswa accounts for a short time, so
+// that checking the role of every request does not read the account every time. A
+// status change or deletion reaches tokens once the entry expires.
+type mahasiswaStatusCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[uint]cachedMahasiswaStatus
+	nextSweep time.Time
+}
+
+type cachedMahasiswaStatus struct {
+	// found is false for a deleted account
+	found     bool
+	status    entity.StatusMahasiswa
+	expiresAt time.Time
+}
+
+func newMahasiswaStatusCache(ttl time.Duration) *mahasiswaStatusCache {
+	return &mahasiswaStatusCache{
+		ttl:     ttl,
+		entries: map[uint]cachedMahasiswaStatus{},
+	}
+}
+
+// get returns the cached status of the mahasiswa; ok is false when it has to be read again
+func (c *mahasiswaStatusCache) get(id uint) (status cachedMahasiswaStatus, ok bool) {
+	if c.ttl <= 0 {
+		return status, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status, ok = c.entries[id]
+	if !ok || time.Now().After(status.expiresAt) {
+		return status, false
+	}
+	return status, true
+}
+
+func (c *mahasiswaStatusCache) put(id uint, mahasiswa *entity.Mahasiswa) cachedMahasiswaStatus {
+	status := cachedMahasiswaStatus{found: mahasiswa != nil}
+	if mahasiswa != nil {
+		status.status = mahasiswa.Status
+	}
+	if c.ttl <= 0 {
+		return status
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Forget expired entries now and then so the map does not grow forever
+	now := time.Now()
+	if now.After(c.nextSweep) {
+		for key, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	status.expiresAt = now.Add(c.ttl)
+	c.entries[id] = status
+	return status
+}
@@ -4,22 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
 	"Fix-Go-Fiber-Backend/pkg/bcrypt"
 )
 
 type MahasiswaUsecase struct {
 	mahasiswaRepo repository.MahasiswaRepository
 	bcryptHelper  bcrypt.BcryptHelper
+	passwords     service.PasswordPolicyService
+	verification  service.EmailVerificationService
 }
 
-func NewMahasiswaUsecase(mahasiswaRepo repository.MahasiswaRepository, bcryptHelper bcrypt.BcryptHelper) *MahasiswaUsecase {
+func NewMahasiswaUsecase(mahasiswaRepo repository.MahasiswaRepository, bcryptHelper bcrypt.BcryptHelper, passwords service.PasswordPolicyService, verification service.EmailVerificationService) *MahasiswaUsecase {
 	return &MahasiswaUsecase{
 		mahasiswaRepo: mahasiswaRepo,
 		bcryptHelper:  bcryptHelper,
+		passwords:     passwords,
+		verification:  verification,
 	}
 }
 
@@ -41,6 +48,14 @@ func (u *MahasiswaUsecase) Create(ctx context.Context, mahasiswa *entity.Mahasis
 		return errors.New("email sudah terdaftar")
 	}
 
+	subject := &service.PasswordSubject{
+		UserType:    "mahasiswa",
+		Identifiers: []string{mahasiswa.NIM, mahasiswa.Nama, mahasiswa.Email},
+	}
+	if err := u.passwords.Check(ctx, mahasiswa.Password, subject); err != nil {
+		return err
+	}
+
 	// Hash password
 	hashedPassword, err := u.bcryptHelper.HashPassword(mahasiswa.Password)
 	if err != nil {
@@ -48,13 +63,19 @@ func (u *MahasiswaUsecase) Create(ctx context.Context, mahasiswa *entity.Mahasis
 	}
 	mahasiswa.Password = hashedPassword
 
-	return u.mahasiswaRepo.Create(ctx, mahasiswa)
+	if err := u.mahasiswaRepo.Create(ctx, mahasiswa); err != nil {
+		return err
+	}
+	return u.passwords.Remember(ctx, "mahasiswa", mahasiswa.ID, hashedPassword)
 }
 
-func (u *MahasiswaUsecase) GetByID(ctx context.Context, id uint) (*entity.Mahasiswa, error) {
+func (u *MahasiswaUsecase) GetByID(ctx context.Context, id uint, actor *service.JWTClaims) (*entity.Mahasiswa, error) {
 	if id == 0 {
 		return nil, errors.New("ID tidak valid")
 	}
+	if err := policy.Authorize(actor, entity.PermissionMahasiswaRead, policy.Mahasiswa(id)); err != nil {
+		return nil, err
+	}
 
 	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, id)
 	if err != nil {
@@ -78,10 +99,13 @@ func (u *MahasiswaUsecase) GetAll(ctx context.Context, limit, offset int) ([]*en
 	return u.mahasiswaRepo.GetAll(ctx, limit, offset)
 }
 
-func (u *MahasiswaUsecase) Update(ctx context.Context, id uint, mahasiswa *entity.Mahasiswa) error {
+func (u *MahasiswaUsecase) Update(ctx context.Context, id uint, mahasiswa *entity.Mahasiswa, actor *service.JWTClaims) error {
 	if id == 0 {
 		return errors.New("ID tidak valid")
 	}
+	if err := policy.Authorize(actor, entity.PermissionMahasiswaUpdate, policy.Mahasiswa(id)); err != nil {
+		return err
+	}
 
 	// Check if mahasiswa exists
 	existing, err := u.mahasiswaRepo.GetByID(ctx, id)
@@ -105,6 +129,13 @@ func (u *MahasiswaUsecase) Update(ctx context.Context, id uint, mahasiswa *entit
 		}
 	}
 
+	// An admin acting as the mahasiswa may not change how they log in
+	if mahasiswa.Password != "" || (mahasiswa.Email != "" && mahasiswa.Email != existing.Email) {
+		if err := policy.AuthorizeCredentialChange(actor); err != nil {
+			return err
+		}
+	}
+
 	// Check email uniqueness if changed
 	if mahasiswa.Email != "" && mahasiswa.Email != existing.Email {
 		existingByEmail, _ := u.mahasiswaRepo.GetByEmail(ctx, mahasiswa.Email)
@@ -115,6 +146,13 @@ func (u *MahasiswaUsecase) Update(ctx context.Context, id uint, mahasiswa *entit
 
 	// Hash password if provided
 	if mahasiswa.Password != "" {
+		// The password may not contain the NIM, name or email from before or after the update
+		subject := mahasiswaPasswordSubject(existing)
+		subject.Identifiers = append(subject.Identifiers, mahasiswa.NIM, mahasiswa.Nama, mahasiswa.Email)
+		if err := u.passwords.Check(ctx, mahasiswa.Password, subject); err != nil {
+			return err
+		}
+
 		hashedPassword, err := u.bcryptHelper.HashPassword(mahasiswa.Password)
 		if err != nil {
 			return fmt.Errorf("gagal hash password: %w", err)
@@ -122,7 +160,28 @@ func (u *MahasiswaUsecase) Update(ctx context.Context, id uint, mahasiswa *entit
 		mahasiswa.Password = hashedPassword
 	}
 
-	return u.mahasiswaRepo.Update(ctx, id, mahasiswa)
+	// The repository clears email_verified_at along with a new email
+	if err := u.mahasiswaRepo.Update(ctx, id, mahasiswa); err != nil {
+		return err
+	}
+	if mahasiswa.Password != "" {
+		if err := u.passwords.Remember(ctx, "mahasiswa", id, mahasiswa.Password); err != nil {
+			return err
+		}
+	}
+
+	if mahasiswa.Email != "" && mahasiswa.Email != existing.Email {
+		// Tokens mailed to the old address stop working; a failed email can be requested again via the resend endpoint
+		updated := *existing
+		updated.Email = mahasiswa.Email
+		if mahasiswa.Nama != "" {
+			updated.Nama = mahasiswa.Nama
+		}
+		if err := u.verification.SendVerification(ctx, &updated); err != nil {
+			log.Printf("failed to start email verification for mahasiswa %d: %v", id, err)
+		}
+	}
+	return nil
 }
 
 func (u *MahasiswaUsecase) Delete(ctx context.Context, id uint) error {
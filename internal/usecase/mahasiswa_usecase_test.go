@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+// verificationMail is a verification email the fake email service was asked to send
+type verificationMail struct {
+	email, token string
+}
+
+type fakeEmailService struct {
+	service.EmailService
+	verifications chan verificationMail
+}
+
+func (f *fakeEmailService) SendWelcomeEmail(_ context.Context, email, _, token string) error {
+	f.verifications <- verificationMail{email: email, token: token}
+	return nil
+}
+
+func TestUpdateEmailRequiresVerification(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	uow := repository.NewUnitOfWork(db)
+	mahasiswaRepo := repository.NewMahasiswaRepository(db)
+	adminRepo := repository.NewAdminUserRepository(db)
+	refreshRepo := repository.NewRefreshTokenRepository(db)
+	revocations := repository.NewMemoryTokenRevocationStore()
+	bcryptUtil := bcrypt.NewBcryptUtil(4)
+
+	emails := &fakeEmailService{verifications: make(chan verificationMail, 1)}
+	verification := usecase.NewEmailVerificationUsecase(mahasiswaRepo, repository.NewEmailVerificationTokenRepository(db), uow, emails, time.Hour, time.Minute)
+	passwords := usecase.NewPasswordPolicyUsecase(repository.NewPasswordHistoryRepository(db), bcryptUtil, usecase.PasswordPolicy{MinLength: 8})
+	attempts := usecase.NewLoginAttemptUsecase(repository.NewLoginAttemptRepository(db), repository.NewLoginLockoutRepository(db), uow, usecase.LoginAttemptPolicy{
+		MaxAttempts: 5, DelayStep: time.Second, Lockout: time.Minute, MaxLockout: time.Hour, IPMaxAttempts: 20, Window: time.Hour,
+	})
+	mfa := usecase.NewMFAUsecase(adminRepo, repository.NewAdminRecoveryCodeRepository(db), refreshRepo, revocations, attempts, uow, bcryptUtil, usecase.MFAPolicy{Issuer: "test"})
+	auth := usecase.NewAuthService(mahasiswaRepo, adminRepo, refreshRepo, repository.NewSessionRepository(db), revocations, verification, attempts, mfa, passwords, uow, newTestTokenSigner(t), bcryptUtil, true, time.Minute)
+	mahasiswas := usecase.NewMahasiswaUsecase(mahasiswaRepo, bcrypt.NewBcryptHelper(4), passwords, verification)
+
+	hash, err := bcryptUtil.HashPassword("Kopi-hangat7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mahasiswa := &entity.Mahasiswa{NIM: "2201001", Nama: "Owner", Email: "owner@example.com", Jurusan: "Teknik Informatika", Angkatan: 2022, Password: hash, Status: entity.StatusMahasiswaActive}
+	if err := mahasiswaRepo.Create(ctx, mahasiswa); err != nil {
+		t.Fatal(err)
+	}
+	if err := mahasiswaRepo.MarkEmailVerified(ctx, mahasiswa.ID); err != nil {
+		t.Fatal(err)
+	}
+	owner := &service.JWTClaims{UserID: mahasiswa.ID, Role: "mahasiswa", Permissions: entity.RolePermissions("mahasiswa")}
+
+	login := func(email string) error {
+		_, err := auth.Login(ctx, &dto.LoginRequest{Identifier: email, Password: "Kopi-hangat7"})
+		return err
+	}
+
+	// Sending the unchanged email keeps it verified
+	if err := mahasiswas.Update(ctx, mahasiswa.ID, &entity.Mahasiswa{Nama: "Owner Baru", Email: "owner@example.com"}, owner); err != nil {
+		t.Fatal(err)
+	}
+	if err := login("owner@example.com"); err != nil {
+		t.Fatalf("login with the unchanged email: %v", err)
+	}
+
+	if err := mahasiswas.Update(ctx, mahasiswa.ID, &entity.Mahasiswa{Email: "new@example.com"}, owner); err != nil {
+		t.Fatal(err)
+	}
+	if err := login("new@example.com"); !errors.Is(err, usecase.ErrEmailNotVerified) {
+		t.Fatalf("login after the email changed = %v, want %v", err, usecase.ErrEmailNotVerified)
+	}
+
+	var mail verificationMail
+	select {
+	case mail = <-emails.verifications:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no verification email sent to the new address")
+	}
+	if mail.email != "new@example.com" {
+		t.Fatalf("verification email sent to %s, want new@example.com", mail.email)
+	}
+
+	if err := verification.VerifyEmail(ctx, mail.token); err != nil {
+		t.Fatal(err)
+	}
+	if err := login("new@example.com"); err != nil {
+		t.Fatalf("login after verifying the new email: %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/totp"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var (
+	ErrMFAAlreadyEnabled   = errors.New("MFA sudah aktif")
+	ErrMFANotEnrolled      = errors.New("MFA belum didaftarkan")
+	ErrMFARequiredForRole  = errors.New("MFA wajib untuk role admin ini")
+	ErrInvalidMFACode      = errors.New("invalid MFA code")
+	ErrInvalidMFAChallenge = errors.New("invalid or expired MFA token, please log in again")
+)
+
+const (
+	// totpSkew is the number of time steps a code may be off, allowing for clock drift
+	totpSkew = 1
+
+	recoveryCodeCount = 10
+	// recoveryCodeBytes gives 8 base32 characters per code
+	recoveryCodeBytes = 5
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAPolicy configures TOTP enrollment
+type MFAPolicy struct {
+	// Issuer is the account name authenticator apps show
+	Issuer string
+	// RequiredRoles are the admin roles that may do nothing but enroll until MFA is on
+	RequiredRoles []entity.AdminRole
+}
+
+type mfaUsecase struct {
+	adminRepo    repository.AdminUserRepository
+	recoveryRepo repository.AdminRecoveryCodeRepository
+	refreshRepo  repository.RefreshTokenRepository
+	revocations  repository.TokenRevocationStore
+	attempts     service.LoginAttemptService
+	uow          repository.UnitOfWork
+	bcryptUtil   *bcrypt.BcryptUtil
+	policy       MFAPolicy
+}
+
+func NewMFAUsecase(
+	adminRepo repository.AdminUserRepository,
+	recoveryRepo repository.AdminRecoveryCodeRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationStore,
+	attempts service.LoginAttemptService,
+	uow repository.UnitOfWork,
+	bcryptUtil *bcrypt.BcryptUtil,
+	policy MFAPolicy,
+) service.MFAService {
+	return &mfaUsecase{
+		adminRepo:    adminRepo,
+		recoveryRepo: recoveryRepo,
+		refreshRepo:  refreshRepo,
+		revocations:  revocations,
+		attempts:     attempts,
+		uow:          uow,
+		bcryptUtil:   bcryptUtil,
+		policy:       policy,
+	}
+}
+
+func (u *mfaUsecase) Status(ctx context.Context, adminID uint) (*dto.MFAStatusResponse, error) {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := u.recoveryRepo.CountUnused(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAStatusResponse{
+		Enabled:                admin.TOTPEnabled,
+		Required:               u.IsRequired(admin.Role),
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+func (u *mfaUsecase) Enroll(ctx context.Context, adminID uint) (*dto.MFAEnrollResponse, error) {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	// Replacing an active secret would turn MFA off without a code
+	if admin.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.adminRepo.SetTOTPSecret(ctx, admin.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(u.policy.Issuer, admin.Username, secret),
+	}, nil
+}
+
+func (u *mfaUsecase) Confirm(ctx context.Context, adminID uint, req *dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error) {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	err = u.limitAttempts(ctx, admin, req.IPAddress, func() error {
+		return u.verifyTOTP(ctx, admin, req.Code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.adminRepo.EnableTOTP(ctx, admin.ID); err != nil {
+			return err
+		}
+
+		var err error
+		codes, err = u.replaceRecoveryCodes(ctx, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions started with the password alone end, so every session has passed MFA
+	if err := endSessions(ctx, u.refreshRepo, u.revocations, "admin", admin.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (u *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, adminID uint, req *dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error) {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.TOTPEnabled {
+		return nil, ErrMFANotEnrolled
+	}
+
+	err = u.limitAttempts(ctx, admin, req.IPAddress, func() error {
+		return u.verifyTOTP(ctx, admin, req.Code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		codes, err = u.replaceRecoveryCodes(ctx, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (u *mfaUsecase) Disable(ctx context.Context, adminID uint, req *dto.DisableMFARequest) error {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+
+	err = u.limitAttempts(ctx, admin, req.IPAddress, func() error {
+		if !u.bcryptUtil.CheckPasswordHash(req.Password, admin.Password) {
+			return ErrInvalidCurrentPassword
+		}
+		if !admin.TOTPEnabled {
+			return ErrMFANotEnrolled
+		}
+		if u.IsRequired(admin.Role) {
+			return ErrMFARequiredForRole
+		}
+		return u.VerifyCode(ctx, admin, req.Code)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.disable(ctx, admin.ID)
+}
+
+func (u *mfaUsecase) Reset(ctx context.Context, adminID uint) error {
+	admin, err := u.getAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.disable(ctx, admin.ID); err != nil {
+		return err
+	}
+
+	// Whoever has the lost device may also hold a session
+	return endSessions(ctx, u.refreshRepo, u.revocations, "admin", admin.ID)
+}
+
+func (u *mfaUsecase) VerifyCode(ctx context.Context, admin *entity.AdminUser, code string) error {
+	if !admin.TOTPEnabled {
+		return ErrInvalidMFACode
+	}
+
+	code = normalizeMFACode(code)
+	if len(code) == totp.Digits {
+		return u.verifyTOTP(ctx, admin, code)
+	}
+
+	used, err := u.recoveryRepo.Use(ctx, admin.ID, utils.HashToken(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (u *mfaUsecase) IsRequired(role entity.AdminRole) bool {
+	for _, r := range u.policy.RequiredRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// limitAttempts runs verify as a login attempt of the admin, like the MFA step of a login.
+// It is refused while the account or address is locked out, and a wrong code or password
+// counts as a failed login, so a stolen session cannot be used to guess codes.
+func (u *mfaUsecase) limitAttempts(ctx context.Context, admin *entity.AdminUser, ipAddress string, verify func() error) error {
+	attempt := newLoginAttempt("admin", admin.Username, ipAddress)
+	if err := u.attempts.Check(ctx, attempt); err != nil {
+		return err
+	}
+
+	if err := verify(); err != nil {
+		if !errors.Is(err, ErrInvalidMFACode) && !errors.Is(err, ErrInvalidCurrentPassword) {
+			return err
+		}
+		if err := u.attempts.RecordFailure(ctx, attempt); err != nil {
+			return err
+		}
+		return err
+	}
+
+	return u.attempts.RecordSuccess(ctx, attempt)
+}
+
+func (u *mfaUsecase) getAdmin(ctx context.Context, id uint) (*entity.AdminUser, error) {
+	admin, err := u.adminRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+// verifyTOTP checks a code against the admin's secret. A code is accepted only once,
+// and not after a later one, so an observed code cannot be replayed.
+func (u *mfaUsecase) verifyTOTP(ctx context.Context, admin *entity.AdminUser, code string) error {
+	step, ok := totp.Validate(admin.TOTPSecret, normalizeMFACode(code), time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	used, err := u.adminRepo.UseTOTPStep(ctx, admin.ID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (u *mfaUsecase) disable(ctx context.Context, adminID uint) error {
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.adminRepo.DisableTOTP(ctx, adminID); err != nil {
+			return err
+		}
+		return u.recoveryRepo.DeleteByAdmin(ctx, adminID)
+	})
+}
+
+// replaceRecoveryCodes stores a new set of recovery codes and returns them formatted for display
+func (u *mfaUsecase) replaceRecoveryCodes(ctx context.Context, adminID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, utils.HashToken(code))
+	}
+
+	if err := u.recoveryRepo.Replace(ctx, adminID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeMFACode drops the separators people type or copy along with a code
+func normalizeMFACode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
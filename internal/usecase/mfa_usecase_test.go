@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+	"Fix-Go-Fiber-Backend/pkg/totp"
+)
+
+// TestMFACodeChecksAreRateLimited covers the endpoints of a logged in admin that take a
+// TOTP code: a wrong code counts as a failed login, so the next try has to wait
+func TestMFACodeChecksAreRateLimited(t *testing.T) {
+	tests := []struct {
+		name string
+		// enabled enrolls the admin and turns MFA on before the attempts
+		enabled bool
+		attempt func(ctx context.Context, mfa service.MFAService, adminID uint, code string) error
+	}{
+		{"confirm", false, func(ctx context.Context, mfa service.MFAService, adminID uint, code string) error {
+			_, err := mfa.Confirm(ctx, adminID, &dto.MFACodeRequest{Code: code, IPAddress: "192.0.2.1"})
+			return err
+		}},
+		{"regenerate recovery codes", true, func(ctx context.Context, mfa service.MFAService, adminID uint, code string) error {
+			_, err := mfa.RegenerateRecoveryCodes(ctx, adminID, &dto.MFACodeRequest{Code: code, IPAddress: "192.0.2.1"})
+			return err
+		}},
+		{"disable", true, func(ctx context.Context, mfa service.MFAService, adminID uint, code string) error {
+			return mfa.Disable(ctx, adminID, &dto.DisableMFARequest{Password: "Kopi-hangat7", Code: code, IPAddress: "192.0.2.1"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := dbtest.New(t)
+			uow := repository.NewUnitOfWork(db)
+			adminRepo := repository.NewAdminUserRepository(db)
+			bcryptUtil := bcrypt.NewBcryptUtil(4)
+			attempts := usecase.NewLoginAttemptUsecase(repository.NewLoginAttemptRepository(db), repository.NewLoginLockoutRepository(db), uow, usecase.LoginAttemptPolicy{
+				MaxAttempts: 5, DelayStep: time.Minute, Lockout: time.Hour, MaxLockout: time.Hour, IPMaxAttempts: 20, Window: time.Hour,
+			})
+			mfa := usecase.NewMFAUsecase(adminRepo, repository.NewAdminRecoveryCodeRepository(db), repository.NewRefreshTokenRepository(db), repository.NewMemoryTokenRevocationStore(), attempts, uow, bcryptUtil, usecase.MFAPolicy{Issuer: "test"})
+
+			hash, err := bcryptUtil.HashPassword("Kopi-hangat7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			admin := &entity.AdminUser{Username: "budi", Email: "budi@example.com", Password: hash, Role: entity.AdminRoleAdmin, IsActive: true}
+			if err := adminRepo.Create(ctx, admin); err != nil {
+				t.Fatal(err)
+			}
+			enrollment, err := mfa.Enroll(ctx, admin.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.enabled {
+				if err := adminRepo.EnableTOTP(ctx, admin.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := tt.attempt(ctx, mfa, admin.ID, "000000"); !errors.Is(err, usecase.ErrInvalidMFACode) {
+				t.Fatalf("wrong code: %v, want %v", err, usecase.ErrInvalidMFACode)
+			}
+
+			// Even the right code waits for the delay the failure started
+			code, err := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tt.attempt(ctx, mfa, admin.ID, code)
+			var locked *usecase.LoginLockedError
+			if !errors.As(err, &locked) {
+				t.Fatalf("right code after a wrong one: %v, want %v", err, usecase.ErrLoginLocked)
+			}
+
+			lockouts, _, err := attempts.ListLockouts(ctx, 10, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lockouts) != 1 || lockouts[0].Subject != "budi" {
+				t.Errorf("lockouts = %+v, want the account budi held back", lockouts)
+			}
+		})
+	}
+}
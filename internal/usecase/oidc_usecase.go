@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/oidc"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var (
+	ErrOIDCLoginExpired = errors.New("SSO login expired or was already completed, please start again")
+	ErrOIDCWrongBrowser = errors.New("SSO login was started in another browser, please start again")
+	ErrOIDCRefused      = errors.New("SSO login was refused by the identity provider")
+	ErrOIDCNotLinked    = errors.New("no account matches the SSO identity")
+	ErrOIDCLinkConflict = errors.New("account is already linked to another SSO identity")
+)
+
+// OIDCPolicy configures how SSO identities are matched to accounts
+type OIDCPolicy struct {
+	// Issuer identifies the provider identities are linked for
+	Issuer string
+	// NIMClaim is the ID token claim holding the NIM of a mahasiswa; empty matches by email only
+	NIMClaim string
+	// StateExpire is how long the user has to sign in at the provider
+	StateExpire time.Duration
+}
+
+type oidcUsecase struct {
+	client        *oidc.Client
+	identityRepo  repository.ExternalIdentityRepository
+	stateRepo     repository.OIDCLoginStateRepository
+	mahasiswaRepo repository.MahasiswaRepository
+	adminRepo     repository.AdminUserRepository
+	authService   service.AuthService
+	policy        OIDCPolicy
+}
+
+func NewOIDCUsecase(
+	client *oidc.Client,
+	identityRepo repository.ExternalIdentityRepository,
+	stateRepo repository.OIDCLoginStateRepository,
+	mahasiswaRepo repository.MahasiswaRepository,
+	adminRepo repository.AdminUserRepository,
+	authService service.AuthService,
+	policy OIDCPolicy,
+) service.OIDCService {
+	return &oidcUsecase{
+		client:        client,
+		identityRepo:  identityRepo,
+		stateRepo:     stateRepo,
+		mahasiswaRepo: mahasiswaRepo,
+		adminRepo:     adminRepo,
+		authService:   authService,
+		policy:        policy,
+	}
+}
+
+func (u *oidcUsecase) Start(ctx context.Context, query *dto.OIDCLoginQuery) (*dto.OIDCRedirect, error) {
+	// Logins the user abandoned at the provider are cleaned up here
+	if err := u.stateRepo.DeleteExpired(ctx, time.Now()); err != nil {
+		log.Printf("failed to delete expired SSO logins: %v", err)
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	verifier, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	login := &entity.OIDCLoginState{
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		DeviceID:     query.DeviceID,
+		ExpiresAt:    time.Now().Add(u.policy.StateExpire),
+	}
+	if err := u.stateRepo.Create(ctx, login); err != nil {
+		return nil, err
+	}
+
+	authURL, err := u.client.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OIDCRedirect{AuthURL: authURL, State: state, ExpiresAt: login.ExpiresAt}, nil
+}
+
+func (u *oidcUsecase) Callback(ctx context.Context, query *dto.OIDCCallbackQuery) (*dto.LoginResponse, error) {
+	// Without its cookie, a callback may be an attacker's own login planted in the victim's
+	// browser. The state stays usable for the browser that holds it.
+	if query.StateCookie == "" || subtle.ConstantTimeCompare([]byte(query.StateCookie), []byte(query.State)) != 1 {
+		return nil, ErrOIDCWrongBrowser
+	}
+
+	// The state is used once, whatever the provider answered
+	login, err := u.stateRepo.Consume(ctx, utils.HashToken(query.State))
+	if err != nil {
+		return nil, err
+	}
+	if login == nil || login.IsExpired() {
+		return nil, ErrOIDCLoginExpired
+	}
+
+	if query.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrOIDCRefused, query.Error)
+	}
+
+	token, err := u.client.Exchange(ctx, query.Code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		log.Printf("SSO code exchange failed: %v", err)
+		return nil, ErrOIDCRefused
+	}
+
+	identity, err := u.link(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dto.ClientInfo{
+		DeviceID:  login.DeviceID,
+		IPAddress: query.IPAddress,
+		UserAgent: query.UserAgent,
+	}
+	response, err := u.authService.LoginExternal(ctx, identity, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.identityRepo.TouchLogin(ctx, identity.ID, time.Now()); err != nil {
+		log.Printf("failed to record SSO login of identity %d: %v", identity.ID, err)
+	}
+	return response, nil
+}
+
+// link returns the identity linked to the token's subject, linking it on first use to
+// the mahasiswa with its NIM or to the mahasiswa or admin with its verified email
+func (u *oidcUsecase) link(ctx context.Context, token *oidc.IDToken) (*entity.ExternalIdentity, error) {
+	identity, err := u.identityRepo.GetBySubject(ctx, u.policy.Issuer, token.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		exists, err := u.accountExists(ctx, identity.UserType, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return identity, nil
+		}
+		// The account was deleted; the identity may match another one now
+		if err := u.identityRepo.Delete(ctx, identity.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	userType, userID, err := u.match(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if userID == 0 {
+		return nil, ErrOIDCNotLinked
+	}
+
+	// One identity per account and provider, so a second provider account cannot take it over
+	linked, err := u.identityRepo.GetByUser(ctx, u.policy.Issuer, userType, userID)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		return nil, ErrOIDCLinkConflict
+	}
+
+	identity = &entity.ExternalIdentity{
+		Issuer:   u.policy.Issuer,
+		Subject:  token.Subject,
+		UserType: userType,
+		UserID:   userID,
+		Email:    token.Email,
+	}
+	if err := u.identityRepo.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// match finds the account an unlinked identity belongs to; userID is 0 when there is none
+func (u *oidcUsecase) match(ctx context.Context, token *oidc.IDToken) (userType string, userID uint, err error) {
+	if u.policy.NIMClaim != "" {
+		if nim := token.StringClaim(u.policy.NIMClaim); nim != "" {
+			mahasiswa, err := u.mahasiswaRepo.GetByNIM(ctx, nim)
+			if err != nil {
+				return "", 0, err
+			}
+			if mahasiswa != nil {
+				return "mahasiswa", mahasiswa.ID, nil
+			}
+		}
+	}
+
+	// An email the provider has not verified proves nothing
+	if token.Email == "" || !token.EmailVerified {
+		return "", 0, nil
+	}
+
+	mahasiswa, err := u.mahasiswaRepo.GetByEmail(ctx, token.Email)
+	if err != nil {
+		return "", 0, err
+	}
+	if mahasiswa != nil {
+		return "mahasiswa", mahasiswa.ID, nil
+	}
+
+	admin, err := u.adminRepo.GetByEmail(ctx, token.Email)
+	if err != nil {
+		return "", 0, err
+	}
+	if admin != nil {
+		return "admin", admin.ID, nil
+	}
+	return "", 0, nil
+}
+
+func (u *oidcUsecase) accountExists(ctx context.Context, userType string, userID uint) (bool, error) {
+	if userType == "admin" {
+		admin, err := u.adminRepo.GetByID(ctx, userID)
+		return admin != nil, err
+	}
+	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, userID)
+	return mahasiswa != nil, err
+}
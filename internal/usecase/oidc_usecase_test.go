@@ -0,0 +1,315 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	domainrepo "Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/repository"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+	"Fix-Go-Fiber-Backend/pkg/oidc"
+	"Fix-Go-Fiber-Backend/pkg/oidc/mockidp"
+)
+
+const (
+	oidcClientID     = "fix-go"
+	oidcClientSecret = "secret"
+	oidcRedirectURL  = "http://app.example/api/v1/auth/oidc/callback"
+)
+
+// fakeAuthService records the identity an SSO login signs in
+type fakeAuthService struct {
+	service.AuthService
+	identity *entity.ExternalIdentity
+}
+
+func (f *fakeAuthService) LoginExternal(_ context.Context, identity *entity.ExternalIdentity, _ dto.ClientInfo) (*dto.LoginResponse, error) {
+	f.identity = identity
+	return &dto.LoginResponse{}, nil
+}
+
+// oidcTest is the SSO login against a mock provider served over HTTP, on a database with
+// mahasiswa 1 and 2 and admin 1
+type oidcTest struct {
+	service    service.OIDCService
+	identities domainrepo.ExternalIdentityRepository
+	auth       *fakeAuthService
+	issuer     string
+}
+
+func newOIDCTest(t *testing.T) *oidcTest {
+	t.Helper()
+	ctx := context.Background()
+	db := dbtest.New(t)
+
+	mahasiswaRepo := repository.NewMahasiswaRepository(db)
+	adminRepo := repository.NewAdminUserRepository(db)
+	for _, m := range []*entity.Mahasiswa{
+		{NIM: "2201001", Nama: "Owner", Email: "owner@example.com"},
+		{NIM: "2201002", Nama: "Other", Email: "other@example.com"},
+	} {
+		m.Jurusan, m.Angkatan, m.Password, m.Status = "Teknik Informatika", 2022, "hash", entity.StatusMahasiswaActive
+		if err := mahasiswaRepo.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	admin := &entity.AdminUser{Username: "root", Email: "root@example.com", Password: "hash", Role: entity.AdminRoleSuperAdmin, IsActive: true}
+	if err := adminRepo.Create(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	// The provider needs its own URL as issuer, which is only known once the server runs
+	var provider http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	provider, err := mockidp.New(server.URL, oidcClientID, oidcClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := oidc.NewClient(oidc.Config{
+		Issuer:       server.URL,
+		ClientID:     oidcClientID,
+		ClientSecret: oidcClientSecret,
+		RedirectURL:  oidcRedirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+	identities := repository.NewExternalIdentityRepository(db)
+	auth := &fakeAuthService{}
+	oidcService := usecase.NewOIDCUsecase(
+		client,
+		identities,
+		repository.NewOIDCLoginStateRepository(db),
+		mahasiswaRepo,
+		adminRepo,
+		auth,
+		usecase.OIDCPolicy{Issuer: server.URL, NIMClaim: "nim", StateExpire: time.Minute},
+	)
+
+	return &oidcTest{service: oidcService, identities: identities, auth: auth, issuer: server.URL}
+}
+
+// signIn starts a login, lets edit change the authorization request the browser sends to
+// the provider and returns the callback the provider redirects back with
+func (o *oidcTest) signIn(t *testing.T, edit func(url.Values)) *dto.OIDCCallbackQuery {
+	t.Helper()
+
+	redirect, err := o.service.Start(context.Background(), &dto.OIDCLoginQuery{DeviceID: "browser"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := url.Parse(redirect.AuthURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := target.Query()
+	edit(query)
+	target.RawQuery = query.Encode()
+
+	browser := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := browser.Get(target.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("provider answered %d, want a redirect", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback := location.Query()
+	return &dto.OIDCCallbackQuery{
+		State:       callback.Get("state"),
+		Code:        callback.Get("code"),
+		Error:       callback.Get("error"),
+		StateCookie: redirect.State,
+	}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		request url.Values
+		// tamper changes the authorization request like an attacker injecting their own
+		tamper   func(url.Values)
+		wantErr  error
+		userType string
+		userID   uint
+	}{
+		{
+			name:     "mahasiswa by verified email",
+			request:  url.Values{"login_hint": {"owner@example.com"}},
+			userType: "mahasiswa", userID: 1,
+		},
+		{
+			name:     "admin by verified email",
+			request:  url.Values{"login_hint": {"root@example.com"}},
+			userType: "admin", userID: 1,
+		},
+		{
+			name:     "mahasiswa by NIM claim",
+			request:  url.Values{"login_hint": {"someone@provider.example"}, "nim": {"2201002"}},
+			userType: "mahasiswa", userID: 2,
+		},
+		{
+			name:     "NIM claim before email",
+			request:  url.Values{"login_hint": {"owner@example.com"}, "nim": {"2201002"}},
+			userType: "mahasiswa", userID: 2,
+		},
+		{
+			name:    "unverified email is not linked",
+			request: url.Values{"login_hint": {"owner@example.com"}, "email_verified": {"false"}},
+			wantErr: usecase.ErrOIDCNotLinked,
+		},
+		{
+			name:    "unknown email",
+			request: url.Values{"login_hint": {"nobody@example.com"}},
+			wantErr: usecase.ErrOIDCNotLinked,
+		},
+		{
+			name:    "wrong code verifier",
+			request: url.Values{"login_hint": {"owner@example.com"}},
+			tamper: func(query url.Values) {
+				query.Set("code_challenge", oidc.CodeChallenge("another verifier"))
+			},
+			wantErr: usecase.ErrOIDCRefused,
+		},
+		{
+			name:    "nonce mismatch",
+			request: url.Values{"login_hint": {"owner@example.com"}},
+			tamper: func(query url.Values) {
+				query.Set("nonce", "another nonce")
+			},
+			wantErr: usecase.ErrOIDCRefused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o := newOIDCTest(t)
+
+			callback := o.signIn(t, func(query url.Values) {
+				for key, values := range tt.request {
+					query[key] = values
+				}
+				if tt.tamper != nil {
+					tt.tamper(query)
+				}
+			})
+			_, err := o.service.Callback(ctx, callback)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Callback() = %v, want %v", err, tt.wantErr)
+				}
+				if o.auth.identity != nil {
+					t.Errorf("signed in identity %+v after a refused login", o.auth.identity)
+				}
+				for _, account := range []struct {
+					userType string
+					userID   uint
+				}{{"mahasiswa", 1}, {"mahasiswa", 2}, {"admin", 1}} {
+					linked, err := o.identities.GetByUser(ctx, o.issuer, account.userType, account.userID)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if linked != nil {
+						t.Errorf("%s %d linked to %s after a refused login", account.userType, account.userID, linked.Subject)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Callback() = %v", err)
+			}
+			signedIn := o.auth.identity
+			if signedIn == nil || signedIn.UserType != tt.userType || signedIn.UserID != tt.userID {
+				t.Fatalf("signed in %+v, want %s %d", signedIn, tt.userType, tt.userID)
+			}
+
+			// The next login uses the link, even when the claims that made it are gone
+			o.auth.identity = nil
+			callback = o.signIn(t, func(query url.Values) {
+				query.Set("login_hint", tt.request.Get("login_hint"))
+			})
+			if _, err := o.service.Callback(ctx, callback); err != nil {
+				t.Fatalf("second Callback() = %v", err)
+			}
+			if o.auth.identity == nil || o.auth.identity.ID != signedIn.ID {
+				t.Errorf("second login signed in %+v, want identity %d", o.auth.identity, signedIn.ID)
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackRefusesReplayedState(t *testing.T) {
+	ctx := context.Background()
+	o := newOIDCTest(t)
+
+	callback := o.signIn(t, func(query url.Values) {
+		query.Set("login_hint", "owner@example.com")
+	})
+	if _, err := o.service.Callback(ctx, callback); err != nil {
+		t.Fatalf("first Callback() = %v", err)
+	}
+
+	o.auth.identity = nil
+	if _, err := o.service.Callback(ctx, callback); !errors.Is(err, usecase.ErrOIDCLoginExpired) {
+		t.Errorf("replayed Callback() = %v, want %v", err, usecase.ErrOIDCLoginExpired)
+	}
+	if o.auth.identity != nil {
+		t.Errorf("replayed state signed in %+v", o.auth.identity)
+	}
+
+	// A state the server never issued is refused the same way
+	callback.State, callback.StateCookie = "unknown", "unknown"
+	if _, err := o.service.Callback(ctx, callback); !errors.Is(err, usecase.ErrOIDCLoginExpired) {
+		t.Errorf("Callback() with unknown state = %v, want %v", err, usecase.ErrOIDCLoginExpired)
+	}
+}
+
+// TestOIDCCallbackRequiresStateCookie covers login CSRF: a callback with an attacker's
+// state, opened in the victim's browser, lacks the cookie the attacker's browser got
+func TestOIDCCallbackRequiresStateCookie(t *testing.T) {
+	ctx := context.Background()
+	o := newOIDCTest(t)
+
+	callback := o.signIn(t, func(query url.Values) {
+		query.Set("login_hint", "owner@example.com")
+	})
+	state := callback.StateCookie
+
+	for _, cookie := range []string{"", "another-login"} {
+		callback.StateCookie = cookie
+		if _, err := o.service.Callback(ctx, callback); !errors.Is(err, usecase.ErrOIDCWrongBrowser) {
+			t.Errorf("Callback() with state cookie %q = %v, want %v", cookie, err, usecase.ErrOIDCWrongBrowser)
+		}
+	}
+	if o.auth.identity != nil {
+		t.Fatalf("callback without the state cookie signed in %+v", o.auth.identity)
+	}
+
+	// The refused callbacks leave the login to the browser that started it
+	callback.StateCookie = state
+	if _, err := o.service.Callback(ctx, callback); err != nil {
+		t.Fatalf("Callback() with the state cookie = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/breach"
+)
+
+var (
+	ErrWeakPassword     = errors.New("password does not meet the password policy")
+	ErrPasswordBreached = fmt.Errorf("%w: it appears in a list of breached passwords", ErrWeakPassword)
+	ErrPasswordReused   = fmt.Errorf("%w: it was used recently", ErrWeakPassword)
+)
+
+const (
+	// maxPasswordBytes is the longest password bcrypt hashes
+	maxPasswordBytes = 72
+	// minIdentifierLength is the shortest identifier or part of one that may not appear in a password
+	minIdentifierLength = 3
+)
+
+// PasswordPolicy is the policy for new passwords. Passwords set before it changed keep working.
+type PasswordPolicy struct {
+	MinLength int
+	// MinClasses is how many of lowercase letters, uppercase letters, digits and symbols a password mixes
+	MinClasses int
+	// History is how many recent passwords of an account cannot be chosen again, the current one included
+	History int
+	// AdminMaxAge is how long an admin password lasts before it has to be changed; 0 for ever
+	AdminMaxAge time.Duration
+	// Breached lists refused passwords; nil turns the check off
+	Breached *breach.List
+}
+
+type passwordPolicyUsecase struct {
+	historyRepo repository.PasswordHistoryRepository
+	bcryptUtil  *bcrypt.BcryptUtil
+	policy      PasswordPolicy
+}
+
+func NewPasswordPolicyUsecase(historyRepo repository.PasswordHistoryRepository, bcryptUtil *bcrypt.BcryptUtil, policy PasswordPolicy) service.PasswordPolicyService {
+	return &passwordPolicyUsecase{
+		historyRepo: historyRepo,
+		bcryptUtil:  bcryptUtil,
+		policy:      policy,
+	}
+}
+
+func (u *passwordPolicyUsecase) Check(ctx context.Context, password string, subject *service.PasswordSubject) error {
+	var problems []string
+	if utf8.RuneCountInString(password) < u.policy.MinLength {
+		problems = append(problems, fmt.Sprintf("it must be at least %d characters long", u.policy.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		problems = append(problems, fmt.Sprintf("it must be at most %d bytes long", maxPasswordBytes))
+	}
+	if characterClasses(password) < u.policy.MinClasses {
+		problems = append(problems, fmt.Sprintf("it must mix at least %d of lowercase letters, uppercase letters, digits and symbols", u.policy.MinClasses))
+	}
+	if containsIdentifier(password, subject.Identifiers) {
+		problems = append(problems, "it must not contain your NIM, name, email or username")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrWeakPassword, strings.Join(problems, "; "))
+	}
+
+	if u.policy.Breached != nil {
+		breached, err := u.policy.Breached.Contains(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return ErrPasswordBreached
+		}
+	}
+
+	return u.checkHistory(ctx, password, subject)
+}
+
+// checkHistory refuses the current password and the ones in the history of the account
+func (u *passwordPolicyUsecase) checkHistory(ctx context.Context, password string, subject *service.PasswordSubject) error {
+	if subject.CurrentHash != "" && u.bcryptUtil.CheckPasswordHash(password, subject.CurrentHash) {
+		return ErrPasswordReused
+	}
+	if subject.UserID == 0 || u.policy.History <= 0 {
+		return nil
+	}
+
+	entries, err := u.historyRepo.ListRecent(ctx, subject.UserType, subject.UserID, u.policy.History)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if u.bcryptUtil.CheckPasswordHash(password, entry.PasswordHash) {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+func (u *passwordPolicyUsecase) Remember(ctx context.Context, userType string, userID uint, hashedPassword string) error {
+	if u.policy.History <= 0 {
+		return nil
+	}
+
+	entry := &entity.PasswordHistory{UserType: userType, UserID: userID, PasswordHash: hashedPassword}
+	if err := u.historyRepo.Create(ctx, entry); err != nil {
+		return err
+	}
+
+	// Only the entries the policy checks are kept
+	entries, err := u.historyRepo.ListRecent(ctx, userType, userID, u.policy.History)
+	if err != nil {
+		return err
+	}
+	if len(entries) < u.policy.History {
+		return nil
+	}
+	return u.historyRepo.DeleteBefore(ctx, userType, userID, entries[len(entries)-1].ID)
+}
+
+func (u *passwordPolicyUsecase) IsExpired(admin *entity.AdminUser) bool {
+	if u.policy.AdminMaxAge <= 0 || admin.PasswordChangedAt == nil {
+		return false
+	}
+	return time.Since(*admin.PasswordChangedAt) > u.policy.AdminMaxAge
+}
+
+// characterClasses counts which of lowercase letters, uppercase letters, digits and other characters password has
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// containsIdentifier reports whether password contains one of identifiers, the local
+// part of an email or a word of a name, ignoring case
+func containsIdentifier(password string, identifiers []string) bool {
+	password = strings.ToLower(password)
+	for _, identifier := range identifiers {
+		identifier = strings.ToLower(strings.TrimSpace(identifier))
+		parts := []string{identifier}
+		if at := strings.IndexByte(identifier, '@'); at >= 0 {
+			parts = append(parts, identifier[:at])
+		}
+		parts = append(parts, strings.FieldsFunc(identifier, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+
+		for _, part := range parts {
+			if utf8.RuneCountInString(part) >= minIdentifierLength && strings.Contains(password, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mahasiswaPasswordSubject(mahasiswa *entity.Mahasiswa) *service.PasswordSubject {
+	return &service.PasswordSubject{
+		UserType:    "mahasiswa",
+		UserID:      mahasiswa.ID,
+		CurrentHash: mahasiswa.Password,
+		Identifiers: []string{mahasiswa.NIM, mahasiswa.Nama, mahasiswa.Email},
+	}
+}
+
+func adminPasswordSubject(admin *entity.AdminUser) *service.PasswordSubject {
+	return &service.PasswordSubject{
+		UserType:    "admin",
+		UserID:      admin.ID,
+		CurrentHash: admin.Password,
+		Identifiers: []string{admin.Username, admin.Email},
+	}
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/dto"
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/bcrypt"
+	"Fix-Go-Fiber-Backend/pkg/utils"
+)
+
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// resetEmailTimeout bounds a reset email sent in the background
+const resetEmailTimeout = 30 * time.Second
+
+type passwordUsecase struct {
+	mahasiswaRepo  repository.MahasiswaRepository
+	adminRepo      repository.AdminUserRepository
+	resetRepo      repository.PasswordResetTokenRepository
+	refreshRepo    repository.RefreshTokenRepository
+	revocations    repository.TokenRevocationStore
+	uow            repository.UnitOfWork
+	bcryptUtil     *bcrypt.BcryptUtil
+	passwords      service.PasswordPolicyService
+	emailService   service.EmailService
+	resetExpire    time.Duration
+	resendInterval time.Duration
+}
+
+func NewPasswordUsecase(
+	mahasiswaRepo repository.MahasiswaRepository,
+	adminRepo repository.AdminUserRepository,
+	resetRepo repository.PasswordResetTokenRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationStore,
+	uow repository.UnitOfWork,
+	bcryptUtil *bcrypt.BcryptUtil,
+	passwords service.PasswordPolicyService,
+	emailService service.EmailService,
+	resetExpire time.Duration,
+	resendInterval time.Duration,
+) service.PasswordService {
+	return &passwordUsecase{
+		mahasiswaRepo:  mahasiswaRepo,
+		adminRepo:      adminRepo,
+		resetRepo:      resetRepo,
+		refreshRepo:    refreshRepo,
+		revocations:    revocations,
+		uow:            uow,
+		bcryptUtil:     bcryptUtil,
+		passwords:      passwords,
+		emailService:   emailService,
+		resetExpire:    resetExpire,
+		resendInterval: resendInterval,
+	}
+}
+
+func (u *passwordUsecase) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	mahasiswa, err := u.mahasiswaRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if mahasiswa != nil {
+		if err := u.sendResetToken(ctx, "mahasiswa", mahasiswa.ID, mahasiswa.Email); err != nil {
+			return err
+		}
+	}
+
+	admin, err := u.adminRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if admin != nil && admin.IsActive {
+		if err := u.sendResetToken(ctx, "admin", admin.ID, admin.Email); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendResetToken replaces the user's outstanding reset tokens with a new one and mails it.
+// The email goes out in the background so the response time does not reveal whether the account exists.
+// Within the resend interval of the last token nothing is sent and the caller answers as usual,
+// so the throttle does not reveal the account either.
+func (u *passwordUsecase) sendResetToken(ctx context.Context, userType string, userID uint, email string) error {
+	latest, err := u.resetRepo.GetLatestByUser(ctx, userType, userID)
+	if err != nil {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < u.resendInterval {
+		return nil
+	}
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.resetRepo.InvalidateForUser(ctx, userType, userID); err != nil {
+			return err
+		}
+
+		return u.resetRepo.Create(ctx, &entity.PasswordResetToken{
+			TokenHash: utils.HashToken(rawToken),
+			UserType:  userType,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(u.resetExpire),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), resetEmailTimeout)
+		defer cancel()
+
+		if err := u.emailService.SendPasswordResetEmail(ctx, email, rawToken); err != nil {
+			log.Printf("failed to send password reset email to %s %d: %v", userType, userID, err)
+		}
+	}()
+
+	return nil
+}
+
+func (u *passwordUsecase) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	var token *entity.PasswordResetToken
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		token, err = u.resetRepo.GetByHash(ctx, utils.HashToken(req.Token))
+		if err != nil {
+			return err
+		}
+		if token == nil || !token.IsUsable() {
+			return ErrInvalidResetToken
+		}
+
+		// Only the request that marks the token used may go on, so a token resets at most once
+		used, err := u.resetRepo.MarkUsed(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidResetToken
+		}
+
+		// A password the policy refuses rolls the transaction back, so the token can be used again
+		if err := u.updatePassword(ctx, token, req.NewPassword); err != nil {
+			return err
+		}
+
+		return u.refreshRepo.RevokeUser(ctx, token.UserType, token.UserID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Access tokens issued before the reset stop working as well
+	return u.revocations.RevokeUserTokens(ctx, token.UserType, token.UserID, time.Now())
+}
+
+// updatePassword checks password against the policy for the account token belongs to and sets it
+func (u *passwordUsecase) updatePassword(ctx context.Context, token *entity.PasswordResetToken, password string) error {
+	if token.UserType == "admin" {
+		admin, err := u.adminRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			return err
+		}
+		if admin == nil || !admin.IsActive {
+			return ErrInvalidResetToken
+		}
+		hashedPassword, err := u.hashPassword(ctx, password, adminPasswordSubject(admin))
+		if err != nil {
+			return err
+		}
+		if err := u.adminRepo.UpdatePassword(ctx, admin.ID, hashedPassword); err != nil {
+			return err
+		}
+		return u.passwords.Remember(ctx, "admin", admin.ID, hashedPassword)
+	}
+
+	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+	if mahasiswa == nil {
+		return ErrInvalidResetToken
+	}
+	hashedPassword, err := u.hashPassword(ctx, password, mahasiswaPasswordSubject(mahasiswa))
+	if err != nil {
+		return err
+	}
+	if err := u.mahasiswaRepo.Update(ctx, mahasiswa.ID, &entity.Mahasiswa{Password: hashedPassword}); err != nil {
+		return err
+	}
+	return u.passwords.Remember(ctx, "mahasiswa", mahasiswa.ID, hashedPassword)
+}
+
+func (u *passwordUsecase) hashPassword(ctx context.Context, password string, subject *service.PasswordSubject) (string, error) {
+	if err := u.passwords.Check(ctx, password, subject); err != nil {
+		return "", err
+	}
+	hashedPassword, err := u.bcryptUtil.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return hashedPassword, nil
+}
@@ -7,31 +7,56 @@ import (
 
 	"Fix-Go-Fiber-Backend/internal/domain/dto"
 	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
 	"Fix-Go-Fiber-Backend/internal/domain/repository"
 	"Fix-Go-Fiber-Backend/internal/domain/service"
 )
 
+var ErrPekerjaanNotFound = errors.New("pekerjaan tidak ditemukan")
+
 type PekerjaanAlumniUsecase struct {
 	pekerjaanRepo  repository.PekerjaanAlumniRepository
 	mahasiswaRepo  repository.MahasiswaRepository
+	uow            repository.UnitOfWork
 }
 
 func NewPekerjaanAlumniUsecase(
 	pekerjaanRepo repository.PekerjaanAlumniRepository,
 	mahasiswaRepo repository.MahasiswaRepository,
+	uow repository.UnitOfWork,
 ) service.PekerjaanAlumniService {
 	return &PekerjaanAlumniUsecase{
 		pekerjaanRepo: pekerjaanRepo,
 		mahasiswaRepo: mahasiswaRepo,
+		uow:           uow,
 	}
 }
 
 // Implement service.PekerjaanAlumniService interface
-func (u *PekerjaanAlumniUsecase) CreatePekerjaan(ctx context.Context, req *dto.CreatePekerjaanRequest) (*entity.PekerjaanAlumni, error) {
+func (u *PekerjaanAlumniUsecase) CreatePekerjaan(ctx context.Context, req *dto.CreatePekerjaanRequest, actor *service.JWTClaims) (*entity.PekerjaanAlumni, error) {
+	var pekerjaan *entity.PekerjaanAlumni
+
+	// Cek status alumni dan simpan pekerjaan dalam satu transaksi
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		pekerjaan, err = u.createPekerjaan(ctx, req, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pekerjaan, nil
+}
+
+func (u *PekerjaanAlumniUsecase) createPekerjaan(ctx context.Context, req *dto.CreatePekerjaanRequest, actor *service.JWTClaims) (*entity.PekerjaanAlumni, error) {
 	var mahasiswaID uint
 	
 	// Jika mahasiswa_id disediakan, gunakan itu
 	if req.MahasiswaID != nil && *req.MahasiswaID > 0 {
+		if err := policy.Authorize(actor, entity.PermissionPekerjaanCreate, policy.Mahasiswa(*req.MahasiswaID)); err != nil {
+			return nil, err
+		}
 		mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, *req.MahasiswaID)
 		if err != nil {
 			return nil, err
@@ -47,7 +72,20 @@ func (u *PekerjaanAlumniUsecase) CreatePekerjaan(ctx context.Context, req *dto.C
 	} else if req.NIM != "" {
 		// Jika NIM disediakan, cari mahasiswa
 		mahasiswa, err := u.mahasiswaRepo.GetByNIM(ctx, req.NIM)
-		if err != nil || mahasiswa == nil {
+		if err != nil {
+			return nil, err
+		}
+		// NIM milik orang lain ditolak sama seperti mahasiswa_id milik orang lain. Otorisasi
+		// dicek sebelum NIM yang tidak ada dilaporkan: NIM tanpa pemilik hanya lolos untuk admin,
+		// sehingga non-admin tidak bisa menebak NIM mana yang terdaftar.
+		var ownerID uint
+		if mahasiswa != nil {
+			ownerID = mahasiswa.ID
+		}
+		if err := policy.Authorize(actor, entity.PermissionPekerjaanCreate, policy.Mahasiswa(ownerID)); err != nil {
+			return nil, err
+		}
+		if mahasiswa == nil {
 			return nil, errors.New("mahasiswa dengan NIM tersebut tidak ditemukan")
 		}
 		
@@ -92,18 +130,17 @@ func (u *PekerjaanAlumniUsecase) CreatePekerjaan(ctx context.Context, req *dto.C
 	return pekerjaan, nil
 }
 
-func (u *PekerjaanAlumniUsecase) GetPekerjaanByID(ctx context.Context, id uint) (*entity.PekerjaanAlumni, error) {
-	if id == 0 {
-		return nil, errors.New("ID pekerjaan tidak valid")
-	}
-
-	return u.pekerjaanRepo.GetByID(ctx, id)
+func (u *PekerjaanAlumniUsecase) GetPekerjaanByID(ctx context.Context, id uint, actor *service.JWTClaims) (*entity.PekerjaanAlumni, error) {
+	return u.getPekerjaan(ctx, id, entity.PermissionPekerjaanRead, actor)
 }
 
-func (u *PekerjaanAlumniUsecase) GetPekerjaanByMahasiswaID(ctx context.Context, mahasiswaID uint) ([]*entity.PekerjaanAlumni, error) {
+func (u *PekerjaanAlumniUsecase) GetPekerjaanByMahasiswaID(ctx context.Context, mahasiswaID uint, actor *service.JWTClaims) ([]*entity.PekerjaanAlumni, error) {
 	if mahasiswaID == 0 {
 		return nil, errors.New("ID mahasiswa tidak valid")
 	}
+	if err := policy.Authorize(actor, entity.PermissionPekerjaanRead, policy.Mahasiswa(mahasiswaID)); err != nil {
+		return nil, err
+	}
 
 	// Verifikasi mahasiswa exists dan alumni
 	mahasiswa, err := u.mahasiswaRepo.GetByID(ctx, mahasiswaID)
@@ -143,19 +180,12 @@ func (u *PekerjaanAlumniUsecase) GetAllPekerjaan(ctx context.Context, search str
 	return u.pekerjaanRepo.GetWithPagination(ctx, limit, offset)
 }
 
-func (u *PekerjaanAlumniUsecase) UpdatePekerjaan(ctx context.Context, id uint, req *dto.UpdatePekerjaanRequest) (*entity.PekerjaanAlumni, error) {
-	if id == 0 {
-		return nil, errors.New("ID pekerjaan tidak valid")
-	}
-
+func (u *PekerjaanAlumniUsecase) UpdatePekerjaan(ctx context.Context, id uint, req *dto.UpdatePekerjaanRequest, actor *service.JWTClaims) (*entity.PekerjaanAlumni, error) {
 	// Get existing pekerjaan
-	existing, err := u.pekerjaanRepo.GetByID(ctx, id)
+	existing, err := u.getPekerjaan(ctx, id, entity.PermissionPekerjaanUpdate, actor)
 	if err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return nil, errors.New("pekerjaan tidak ditemukan")
-	}
 
 	// Update fields if provided
 	if req.NamaCompany != "" {
@@ -186,19 +216,31 @@ func (u *PekerjaanAlumniUsecase) UpdatePekerjaan(ctx context.Context, id uint, r
 	return existing, nil
 }
 
-func (u *PekerjaanAlumniUsecase) DeletePekerjaan(ctx context.Context, id uint) error {
+func (u *PekerjaanAlumniUsecase) DeletePekerjaan(ctx context.Context, id uint, actor *service.JWTClaims) error {
+	// Check if exists
+	if _, err := u.getPekerjaan(ctx, id, entity.PermissionPekerjaanDelete, actor); err != nil {
+		return err
+	}
+
+	return u.pekerjaanRepo.Delete(ctx, id)
+}
+
+// getPekerjaan loads a pekerjaan the actor may perform the action on
+func (u *PekerjaanAlumniUsecase) getPekerjaan(ctx context.Context, id uint, action entity.Permission, actor *service.JWTClaims) (*entity.PekerjaanAlumni, error) {
 	if id == 0 {
-		return errors.New("ID pekerjaan tidak valid")
+		return nil, errors.New("ID pekerjaan tidak valid")
 	}
 
-	// Check if exists
-	existing, err := u.pekerjaanRepo.GetByID(ctx, id)
+	pekerjaan, err := u.pekerjaanRepo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if existing == nil {
-		return errors.New("pekerjaan tidak ditemukan")
+	if pekerjaan == nil {
+		return nil, ErrPekerjaanNotFound
 	}
 
-	return u.pekerjaanRepo.Delete(ctx, id)
+	if err := policy.Authorize(actor, action, policy.PekerjaanAlumni(pekerjaan)); err != nil {
+		return nil, err
+	}
+	return pekerjaan, nil
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/policy"
+	"Fix-Go-Fiber-Backend/internal/domain/repository"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type sessionUsecase struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+}
+
+func NewSessionUsecase(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository) service.SessionService {
+	return &sessionUsecase{
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+	}
+}
+
+func (u *sessionUsecase) ListSessions(ctx context.Context, claims *service.JWTClaims) ([]*entity.Session, error) {
+	sessions, err := u.ListUserSessions(ctx, userTypeForRole(claims.Role), claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == claims.SessionID
+	}
+	return sessions, nil
+}
+
+func (u *sessionUsecase) EndSession(ctx context.Context, id uint, claims *service.JWTClaims) error {
+	// An admin acting as the user may not end the user's own sessions
+	if err := policy.AuthorizeCredentialChange(claims); err != nil {
+		return err
+	}
+
+	session, err := u.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Sessions of other users are not found, not forbidden
+	if session.UserType != userTypeForRole(claims.Role) || session.UserID != claims.UserID {
+		return ErrSessionNotFound
+	}
+
+	return u.refreshRepo.RevokeFamily(ctx, session.FamilyID)
+}
+
+func (u *sessionUsecase) ListUserSessions(ctx context.Context, userType string, userID uint) ([]*entity.Session, error) {
+	return u.sessionRepo.ListActive(ctx, userType, userID, time.Now())
+}
+
+func (u *sessionUsecase) EndUserSession(ctx context.Context, id uint) error {
+	session, err := u.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return u.refreshRepo.RevokeFamily(ctx, session.FamilyID)
+}
+
+func (u *sessionUsecase) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := u.deleteEnded(ctx); err != nil {
+			log.Printf("Warning: session cleanup failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteEnded deletes the refresh tokens that can no longer be used, then the sessions left without any
+func (u *sessionUsecase) deleteEnded(ctx context.Context) error {
+	tokens, err := u.refreshRepo.DeleteEnded(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	sessions, err := u.sessionRepo.DeleteEnded(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tokens > 0 || sessions > 0 {
+		log.Printf("Deleted %d ended refresh tokens and %d ended sessions", tokens, sessions)
+	}
+	return nil
+}
+
+// get returns the session with id if it is still active
+func (u *sessionUsecase) get(ctx context.Context, id uint) (*entity.Session, error) {
+	session, err := u.sessionRepo.GetByID(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || !session.Active {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
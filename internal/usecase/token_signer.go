@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"time"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/pkg/jwt"
+)
+
+// TokenSigner signs and checks the tokens of service.JWTClaims with a *jwt.JWTUtil, which
+// knows only its own claims
+type TokenSigner struct {
+	jwtUtil *jwt.JWTUtil
+}
+
+func NewTokenSigner(jwtUtil *jwt.JWTUtil) *TokenSigner {
+	return &TokenSigner{jwtUtil: jwtUtil}
+}
+
+// RefreshExpire is the lifetime of refresh tokens issued next to the access tokens
+func (s *TokenSigner) RefreshExpire() time.Duration {
+	return s.jwtUtil.RefreshExpire()
+}
+
+// GenerateToken signs an access token for claims and sets claims.TokenID, IssuedAt and ExpiresAt
+func (s *TokenSigner) GenerateToken(claims *service.JWTClaims) (string, time.Time, error) {
+	return s.sign(claims, func(tokenClaims *jwt.Claims) (string, time.Time, error) {
+		return s.jwtUtil.GenerateToken(tokenClaims)
+	})
+}
+
+// GenerateTokenWithExpiry signs an access token valid for expire instead of JWT_EXPIRE
+func (s *TokenSigner) GenerateTokenWithExpiry(claims *service.JWTClaims, expire time.Duration) (string, time.Time, error) {
+	return s.sign(claims, func(tokenClaims *jwt.Claims) (string, time.Time, error) {
+		return s.jwtUtil.GenerateTokenWithExpiry(tokenClaims, expire)
+	})
+}
+
+func (s *TokenSigner) sign(claims *service.JWTClaims, generate func(*jwt.Claims) (string, time.Time, error)) (string, time.Time, error) {
+	tokenClaims := &jwt.Claims{
+		UserID:   claims.UserID,
+		Email:    claims.Email,
+		Role:     claims.Role,
+		Username: claims.Username,
+
+		AdminRole:              claims.AdminRole,
+		PasswordChangeRequired: claims.PasswordChangeRequired,
+		MFAEnrollmentRequired:  claims.MFAEnrollmentRequired,
+		SessionID:              claims.SessionID,
+	}
+	for _, permission := range claims.Permissions {
+		tokenClaims.Permissions = append(tokenClaims.Permissions, string(permission))
+	}
+	if claims.Impersonator != nil {
+		tokenClaims.Actor = &jwt.Actor{AdminID: claims.Impersonator.AdminID, Username: claims.Impersonator.Username}
+	}
+
+	token, expiresAt, err := generate(tokenClaims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claims.TokenID = tokenClaims.ID
+	claims.IssuedAt = tokenClaims.IssuedAt.Time
+	claims.ExpiresAt = tokenClaims.ExpiresAt.Time
+	return token, expiresAt, nil
+}
+
+// ValidateToken only checks the signature and expiry of an access token
+func (s *TokenSigner) ValidateToken(token string) (*service.JWTClaims, error) {
+	claims, err := s.jwtUtil.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.JWTClaims{
+		UserID:   claims.UserID,
+		Email:    claims.Email,
+		Role:     claims.Role,
+		Username: claims.Username,
+		TokenID:  claims.ID,
+
+		AdminRole:              claims.AdminRole,
+		PasswordChangeRequired: claims.PasswordChangeRequired,
+		MFAEnrollmentRequired:  claims.MFAEnrollmentRequired,
+		SessionID:              claims.SessionID,
+	}
+	for _, permission := range claims.Permissions {
+		result.Permissions = append(result.Permissions, entity.Permission(permission))
+	}
+	if claims.Actor != nil {
+		result.Impersonator = &service.Impersonator{AdminID: claims.Actor.AdminID, Username: claims.Actor.Username}
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return result, nil
+}
+
+// GenerateChallengeToken signs the MFA challenge of an admin who passed the password step
+func (s *TokenSigner) GenerateChallengeToken(userID uint) (string, time.Time, error) {
+	return s.jwtUtil.GenerateChallengeToken(userID)
+}
+
+// ValidateChallengeToken checks a token from GenerateChallengeToken and returns its user id, jti and expiry
+func (s *TokenSigner) ValidateChallengeToken(token string) (*service.JWTClaims, error) {
+	claims, err := s.jwtUtil.ValidateChallengeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.JWTClaims{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		TokenID:   claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
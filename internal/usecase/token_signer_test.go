@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/internal/domain/entity"
+	"Fix-Go-Fiber-Backend/internal/domain/service"
+	"Fix-Go-Fiber-Backend/internal/usecase"
+	"Fix-Go-Fiber-Backend/pkg/config"
+	"Fix-Go-Fiber-Backend/pkg/jwt"
+)
+
+func newTestTokenSigner(t *testing.T) *usecase.TokenSigner {
+	t.Helper()
+
+	jwtUtil, err := jwt.NewJWTUtil(&config.Config{
+		JWT:  config.JWTConfig{Algorithm: jwt.AlgorithmHS256, SecretKey: strings.Repeat("s", 32), Expire: "15m"},
+		Auth: config.AuthConfig{MFAChallengeExpire: "5m", ImpersonationExpire: "30m"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return usecase.NewTokenSigner(jwtUtil)
+}
+
+func TestTokenSignerRoundTrip(t *testing.T) {
+	signer := newTestTokenSigner(t)
+
+	claims := &service.JWTClaims{
+		UserID:                7,
+		Email:                 "budi@example.com",
+		Role:                  "admin",
+		Username:              "budi",
+		AdminRole:             string(entity.AdminRoleModerator),
+		Permissions:           entity.AdminRoleModerator.Permissions(),
+		MFAEnrollmentRequired: true,
+		Impersonator:          &service.Impersonator{AdminID: 1, Username: "root"},
+		SessionID:             3,
+	}
+	token, _, err := signer.GenerateToken(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.TokenID == "" || claims.IssuedAt.IsZero() || claims.ExpiresAt.IsZero() {
+		t.Fatalf("GenerateToken() left the registered claims unset: %+v", claims)
+	}
+
+	got, err := signer.ValidateToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, claims) {
+		t.Errorf("ValidateToken() = %+v, want %+v", got, claims)
+	}
+
+	// A challenge is no access token
+	challenge, _, err := signer.GenerateChallengeToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.ValidateToken(challenge); err == nil {
+		t.Error("ValidateToken() accepted an MFA challenge")
+	}
+	if got, err := signer.ValidateChallengeToken(challenge); err != nil || got.UserID != 7 {
+		t.Errorf("ValidateChallengeToken() = %+v, %v, want user 7", got, err)
+	}
+}
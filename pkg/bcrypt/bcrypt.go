@@ -1,6 +1,8 @@
 package bcrypt
 
 import (
+	"fmt"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -27,6 +29,20 @@ func (h *BcryptUtil) CheckPasswordHash(password, hashedPassword string) bool {
 	return err == nil
 }
 
+// NeedsRehash reports whether hashedPassword was made with another cost than new hashes
+func (h *BcryptUtil) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err == nil && cost != h.cost
+}
+
+// ValidateCost refuses a cost bcrypt cannot hash with
+func ValidateCost(cost int) error {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return nil
+}
+
 // Legacy methods for backward compatibility
 type BcryptHelper interface {
 	HashPassword(password string) (string, error)
@@ -0,0 +1,228 @@
+// Package breach checks passwords against lists of SHA-1 hashes of breached passwords.
+//
+// The bundled list is small and kept in memory. A list file, which may be the full Have I
+// Been Pwned download of several hundred million hashes, is never loaded: it must be sorted
+// by hash and is binary searched on disk, so memory use does not grow with its size and a
+// check reads about log2(lines) lines, some 30 for the full download.
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// commonPasswords is the bundled list, checked even when no list file is configured
+//
+//go:embed common_passwords.txt
+var commonPasswords string
+
+const (
+	// maxLineLength bounds a line of the list file, a hash and a breach count
+	maxLineLength = 128
+	// sortCheckLines is how many lines at the start of the list file are checked at load,
+	// which catches a file ordered by prevalence instead of by hash
+	sortCheckLines = 1000
+)
+
+// List is a set of SHA-1 hashes of passwords known from breaches. Hashes are compared as
+// uppercase hex, the format of the Have I Been Pwned password downloads.
+type List struct {
+	hashes map[string]struct{}
+
+	// file is the sorted list file, nil when there is none
+	file *os.File
+	size int64
+}
+
+// Load returns the bundled list extended with the sorted hash file at path, if path is not
+// empty. The file stays open until Close.
+func Load(path string) (*List, error) {
+	l := &List{hashes: make(map[string]struct{})}
+	if err := l.read(strings.NewReader(commonPasswords)); err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return l, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	if err := checkSorted(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("invalid breached password list %s: %w", path, err)
+	}
+
+	l.file, l.size = file, info.Size()
+	return l, nil
+}
+
+// read adds one hash per line, skipping blank lines, # comments and a :count suffix
+func (l *List) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hash, err := parseHash([]byte(line))
+		if err != nil {
+			return err
+		}
+		l.hashes[hash] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// checkSorted checks that the first lines of a list file are hashes in ascending order
+func checkSorted(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	previous := ""
+	for i := 0; i < sortCheckLines && scanner.Scan(); i++ {
+		hash, err := parseHash(scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if hash < previous {
+			return fmt.Errorf("line %d: hashes are not sorted; use the list ordered by hash or sort the file", i+1)
+		}
+		previous = hash
+	}
+	return scanner.Err()
+}
+
+// parseHash returns the uppercase hash of a line, without its :count suffix
+func parseHash(line []byte) (string, error) {
+	line = bytes.TrimSpace(line)
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		line = line[:i]
+	}
+	if len(line) != sha1.Size*2 {
+		return "", fmt.Errorf("invalid SHA-1 hash %q", line)
+	}
+	return strings.ToUpper(string(line)), nil
+}
+
+// Contains reports whether password is on the list. It fails only when the list file
+// cannot be read.
+func (l *List) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if _, ok := l.hashes[hash]; ok {
+		return true, nil
+	}
+	if l.file == nil {
+		return false, nil
+	}
+
+	found, err := l.search(hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to search breached password list: %w", err)
+	}
+	return found, nil
+}
+
+// search binary searches the list file for hash. Every line starting in [lo, hi) may
+// still hold it; lo is always the start of a line.
+func (l *List) search(hash string) (bool, error) {
+	lo, hi := int64(0), l.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, err := l.lineStart(mid)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+
+		line, next, err := l.lineAt(start)
+		if err != nil {
+			return false, err
+		}
+		candidate, err := parseHash(line)
+		if err != nil {
+			return false, fmt.Errorf("at byte %d: %w", start, err)
+		}
+
+		switch {
+		case candidate == hash:
+			return true, nil
+		case candidate < hash:
+			lo = next
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineStart returns the start of the first line starting at or after off, or the file size
+func (l *List) lineStart(off int64) (int64, error) {
+	if off == 0 {
+		return 0, nil
+	}
+
+	buf := make([]byte, maxLineLength+1)
+	n, err := l.file.ReadAt(buf, off-1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+	if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+		return off + int64(i), nil
+	}
+	if n < len(buf) {
+		return l.size, nil
+	}
+	return 0, fmt.Errorf("line at byte %d is longer than %d bytes", off, maxLineLength)
+}
+
+// lineAt returns the line starting at start, without its newline, and the start of the next
+func (l *List) lineAt(start int64) ([]byte, int64, error) {
+	buf := make([]byte, maxLineLength+1)
+	n, err := l.file.ReadAt(buf, start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+		return buf[:i], start + int64(i) + 1, nil
+	}
+	if n < len(buf) {
+		return buf[:n], l.size, nil
+	}
+	return nil, 0, fmt.Errorf("line at byte %d is longer than %d bytes", start, maxLineLength)
+}
+
+// Len returns the number of hashes on the bundled list
+func (l *List) Len() int {
+	return len(l.hashes)
+}
+
+// FileSize returns the size in bytes of the list file, 0 when there is none
+func (l *List) FileSize() int64 {
+	return l.size
+}
+
+// Close closes the list file
+func (l *List) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
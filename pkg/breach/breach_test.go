@@ -0,0 +1,129 @@
+package breach
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// writeList writes the hashes of passwords to a list file in the layout of the Have I Been
+// Pwned download ordered by hash, passing each hash through format
+func writeList(t *testing.T, passwords []string, format func(hash string, i int) string) string {
+	t.Helper()
+
+	hashes := make([]string, 0, len(passwords))
+	for _, password := range passwords {
+		hashes = append(hashes, sha1Hex(password))
+	}
+	sort.Strings(hashes)
+
+	var b strings.Builder
+	for i, hash := range hashes {
+		b.WriteString(format(hash, i))
+	}
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestListFile(t *testing.T) {
+	var listed, unlisted []string
+	for i := 0; i < 5000; i++ {
+		listed = append(listed, fmt.Sprintf("listed-%d", i))
+		unlisted = append(unlisted, fmt.Sprintf("unlisted-%d", i))
+	}
+
+	layouts := map[string]func(hash string, i int) string{
+		"hibp":      func(hash string, i int) string { return fmt.Sprintf("%s:%d\r\n", hash, i+1) },
+		"plain":     func(hash string, _ int) string { return hash + "\n" },
+		"lowercase": func(hash string, _ int) string { return strings.ToLower(hash) + "\n" },
+		"no final newline": func(hash string, i int) string {
+			if i == 0 {
+				return hash
+			}
+			return "\n" + hash
+		},
+	}
+
+	for name, format := range layouts {
+		t.Run(name, func(t *testing.T) {
+			l, err := Load(writeList(t, listed, format))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+
+			for _, password := range listed {
+				if found, err := l.Contains(password); err != nil || !found {
+					t.Fatalf("Contains(%q) = %v, %v, want true", password, found, err)
+				}
+			}
+			for _, password := range unlisted {
+				if found, err := l.Contains(password); err != nil || found {
+					t.Fatalf("Contains(%q) = %v, %v, want false", password, found, err)
+				}
+			}
+
+			// The bundled list is still checked
+			if found, err := l.Contains("password"); err != nil || !found {
+				t.Errorf("Contains(\"password\") = %v, %v, want true", found, err)
+			}
+		})
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	l, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() == 0 || l.FileSize() != 0 {
+		t.Errorf("Load(\"\") has %d bundled hashes and a %d byte file, want the bundled list only", l.Len(), l.FileSize())
+	}
+	if found, err := l.Contains("password"); err != nil || !found {
+		t.Errorf("Contains(\"password\") = %v, %v, want true", found, err)
+	}
+	if found, err := l.Contains("Kopi-hangat7"); err != nil || found {
+		t.Errorf("Contains(\"Kopi-hangat7\") = %v, %v, want false", found, err)
+	}
+}
+
+func TestLoadRefusesInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	a, b := sha1Hex("a"), sha1Hex("b")
+	if a > b {
+		a, b = b, a
+	}
+
+	for name, content := range map[string]string{
+		// e.g. the download ordered by prevalence
+		"unsorted":  b + ":1\n" + a + ":2\n",
+		"not sha-1": "password\n",
+		"comment":   "# hashes\n" + a + "\n",
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-"))
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if l, err := Load(path); err == nil {
+			l.Close()
+			t.Errorf("Load() accepted a %s file", name)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Load() accepted a missing file")
+	}
+}
@@ -9,10 +9,14 @@ import (
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	App       AppConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	CORS      CORSConfig
+	Mail      MailConfig
+	Auth      AuthConfig
+	Bootstrap BootstrapConfig
+	OIDC      OIDCConfig
 }
 
 type AppConfig struct {
@@ -21,6 +25,8 @@ type AppConfig struct {
 	Port        string
 	Host        string
 	Debug       bool
+	// BaseURL is the public address used in links sent by email
+	BaseURL string
 }
 
 type DatabaseConfig struct {
@@ -32,11 +38,114 @@ type DatabaseConfig struct {
 	Name     string
 	SSLMode  string
 	TimeZone string
+
+	// Path is the SQLite database file, or :memory:
+	Path string
+
+	// MigrateDryRun logs pending migrations instead of applying them
+	MigrateDryRun bool
 }
 
 type JWTConfig struct {
+	// Algorithm is HS256 (signed with SecretKey), RS256 or EdDSA (keys kept in the database)
+	Algorithm string
 	SecretKey string
 	Expire    string
+	// RefreshExpire is the lifetime of a refresh token, renewed on every rotation
+	RefreshExpire string
+	// RevocationStore is where revoked access tokens are kept: memory or database
+	RevocationStore string
+	// KeyRotation is how long an RS256 or EdDSA key signs before a new one replaces it
+	KeyRotation string
+	// KeyEncryptionKey encrypts the RS256 and EdDSA private keys in the database, 32 base64 encoded bytes
+	KeyEncryptionKey string
+}
+
+type MailConfig struct {
+	// Driver is smtp, log (write mails to the application log) or file (append to FilePath)
+	Driver   string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	FilePath string
+}
+
+type AuthConfig struct {
+	PasswordResetExpire string
+	// PasswordResetResendInterval is the least time between two reset emails to one account
+	PasswordResetResendInterval string
+
+	// RequireEmailVerification makes mahasiswa and alumni logins refuse unverified emails
+	RequireEmailVerification   bool
+	EmailVerificationExpire    string
+	VerificationResendInterval string
+
+	// Failed login handling, see usecase.LoginAttemptPolicy
+	LoginMaxAttempts   int
+	LoginIPMaxAttempts int
+	LoginDelayStep     string
+	LoginLockout       string
+	LoginMaxLockout    string
+	LoginAttemptWindow string
+
+	// MFARequiredRoles is a comma separated list of admin roles that must use TOTP
+	MFARequiredRoles string
+	// MFAIssuer is the name authenticator apps show next to the account, APP_NAME when empty
+	MFAIssuer string
+	// MFAChallengeExpire is how long an admin has to enter the TOTP code after the password
+	MFAChallengeExpire string
+
+	// RoleCacheTTL is how long the mahasiswa status behind a token's role is cached; 0 reads it on every request
+	RoleCacheTTL string
+
+	// ImpersonationExpire is how long a token an admin gets to act as a mahasiswa lasts
+	ImpersonationExpire string
+
+	// SessionCleanupInterval is how often ended refresh tokens and sessions are deleted; 0 never
+	SessionCleanupInterval string
+
+	// Password policy for new passwords, see usecase.PasswordPolicy
+	PasswordMinLength  int
+	PasswordMinClasses int
+	PasswordHistory    int
+	// PasswordAdminMaxAge is how long an admin password lasts; 0 for ever
+	PasswordAdminMaxAge string
+	// PasswordBreachCheck refuses passwords from the bundled breached list and PasswordBreachedList
+	PasswordBreachCheck bool
+	// PasswordBreachedList is an optional file of SHA-1 hashes, one per line and sorted by hash
+	PasswordBreachedList string
+
+	// BcryptCost is the cost of new hashes; older hashes are rehashed at login
+	BcryptCost int
+}
+
+// BootstrapConfig holds the credentials of the first admin, used only while no admin exists
+type BootstrapConfig struct {
+	AdminUsername string
+	AdminEmail    string
+	AdminPassword string
+}
+
+// OIDCConfig is the campus OpenID Connect provider used for SSO login
+type OIDCConfig struct {
+	Enabled      bool
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback registered at the provider, APP_BASE_URL/api/v1/auth/oidc/callback when empty
+	RedirectURL string
+	// Scopes is a space separated list of requested scopes
+	Scopes string
+	// NIMClaim is the ID token claim carrying the NIM; empty links by verified email only
+	NIMClaim string
+	// StateExpire is how long a started login waits for the provider
+	StateExpire string
+
+	// MockEnabled starts the built-in mock provider on MockAddr; development only
+	MockEnabled bool
+	MockAddr    string
 }
 
 type CORSConfig struct {
@@ -60,6 +169,7 @@ func LoadConfig() (*Config, error) {
 			Port:        getEnv("APP_PORT", "8080"),
 			Host:        getEnv("APP_HOST", "localhost"),
 			Debug:       getEnvAsBool("APP_DEBUG", true),
+			BaseURL:     getEnv("APP_BASE_URL", "http://localhost:8080"),
 		},
 		Database: DatabaseConfig{
 			Driver:   getEnv("DB_DRIVER", "postgres"),
@@ -70,10 +180,80 @@ func LoadConfig() (*Config, error) {
 			Name:     getEnv("DB_NAME", "fiber_db"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 			TimeZone: getEnv("DB_TIMEZONE", "Asia/Jakarta"),
+			Path:     getEnv("DB_PATH", "fiber_db.sqlite"),
+
+			MigrateDryRun: getEnvAsBool("DB_MIGRATE_DRY_RUN", false),
 		},
 		JWT: JWTConfig{
+			Algorithm: getEnv("JWT_ALGORITHM", "HS256"),
 			SecretKey: getEnv("JWT_SECRET", "your-secret-key"),
-			Expire:    getEnv("JWT_EXPIRE", "24h"),
+			Expire:    getEnv("JWT_EXPIRE", "15m"),
+
+			RefreshExpire:    getEnv("JWT_REFRESH_EXPIRE", "720h"),
+			RevocationStore:  getEnv("JWT_REVOCATION_STORE", "memory"),
+			KeyRotation:      getEnv("JWT_KEY_ROTATION", "720h"),
+			KeyEncryptionKey: getEnv("JWT_KEY_ENCRYPTION_KEY", ""),
+		},
+		Mail: MailConfig{
+			Driver:   getEnv("MAIL_DRIVER", "log"),
+			Host:     getEnv("MAIL_HOST", "localhost"),
+			Port:     getEnv("MAIL_PORT", "1025"),
+			Username: getEnv("MAIL_USERNAME", ""),
+			Password: getEnv("MAIL_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "no-reply@localhost"),
+			FilePath: getEnv("MAIL_FILE_PATH", "mail.log"),
+		},
+		Auth: AuthConfig{
+			PasswordResetExpire:         getEnv("AUTH_PASSWORD_RESET_EXPIRE", "1h"),
+			PasswordResetResendInterval: getEnv("AUTH_PASSWORD_RESET_RESEND_INTERVAL", "1m"),
+
+			RequireEmailVerification:   getEnvAsBool("AUTH_REQUIRE_EMAIL_VERIFICATION", false),
+			EmailVerificationExpire:    getEnv("AUTH_EMAIL_VERIFICATION_EXPIRE", "24h"),
+			VerificationResendInterval: getEnv("AUTH_VERIFICATION_RESEND_INTERVAL", "1m"),
+
+			LoginMaxAttempts:   getEnvAsInt("AUTH_LOGIN_MAX_ATTEMPTS", 5),
+			LoginIPMaxAttempts: getEnvAsInt("AUTH_LOGIN_IP_MAX_ATTEMPTS", 50),
+			LoginDelayStep:     getEnv("AUTH_LOGIN_DELAY_STEP", "1s"),
+			LoginLockout:       getEnv("AUTH_LOGIN_LOCKOUT", "15m"),
+			LoginMaxLockout:    getEnv("AUTH_LOGIN_MAX_LOCKOUT", "24h"),
+			LoginAttemptWindow: getEnv("AUTH_LOGIN_ATTEMPT_WINDOW", "24h"),
+
+			MFARequiredRoles:   getEnv("AUTH_MFA_REQUIRED_ROLES", ""),
+			MFAIssuer:          getEnv("AUTH_MFA_ISSUER", ""),
+			MFAChallengeExpire: getEnv("AUTH_MFA_CHALLENGE_EXPIRE", "5m"),
+
+			RoleCacheTTL: getEnv("AUTH_ROLE_CACHE_TTL", "15s"),
+
+			ImpersonationExpire: getEnv("AUTH_IMPERSONATION_EXPIRE", "10m"),
+
+			SessionCleanupInterval: getEnv("AUTH_SESSION_CLEANUP_INTERVAL", "1h"),
+
+			PasswordMinLength:    getEnvAsInt("AUTH_PASSWORD_MIN_LENGTH", 8),
+			PasswordMinClasses:   getEnvAsInt("AUTH_PASSWORD_MIN_CLASSES", 2),
+			PasswordHistory:      getEnvAsInt("AUTH_PASSWORD_HISTORY", 5),
+			PasswordAdminMaxAge:  getEnv("AUTH_PASSWORD_ADMIN_MAX_AGE", "0"),
+			PasswordBreachCheck:  getEnvAsBool("AUTH_PASSWORD_BREACH_CHECK", true),
+			PasswordBreachedList: getEnv("AUTH_PASSWORD_BREACHED_LIST", ""),
+
+			BcryptCost: getEnvAsInt("AUTH_BCRYPT_COST", 12),
+		},
+		Bootstrap: BootstrapConfig{
+			AdminUsername: getEnv("BOOTSTRAP_ADMIN_USERNAME", ""),
+			AdminEmail:    getEnv("BOOTSTRAP_ADMIN_EMAIL", ""),
+			AdminPassword: getEnv("BOOTSTRAP_ADMIN_PASSWORD", ""),
+		},
+		OIDC: OIDCConfig{
+			Enabled:      getEnvAsBool("OIDC_ENABLED", false),
+			Issuer:       getEnv("OIDC_ISSUER", ""),
+			ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+			Scopes:       getEnv("OIDC_SCOPES", "openid email profile"),
+			NIMClaim:     getEnv("OIDC_NIM_CLAIM", "nim"),
+			StateExpire:  getEnv("OIDC_STATE_EXPIRE", "10m"),
+
+			MockEnabled: getEnvAsBool("OIDC_MOCK_ENABLED", false),
+			MockAddr:    getEnv("OIDC_MOCK_ADDR", "127.0.0.1:9096"),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins:   getEnv("CORS_ALLOWED_ORIGINS", "*"),
@@ -86,6 +266,16 @@ func LoadConfig() (*Config, error) {
 	return config, nil
 }
 
+// IsDevelopment reports whether APP_ENV is development
+func (c *Config) IsDevelopment() bool {
+	return c.App.Environment == "development"
+}
+
+// IsProduction reports whether APP_ENV is production
+func (c *Config) IsProduction() bool {
+	return c.App.Environment == "production"
+}
+
 func (c *Config) GetPostgresDSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
 		c.Database.Host,
@@ -109,6 +299,10 @@ func (c *Config) GetMySQLDSN() string {
 	)
 }
 
+func (c *Config) GetSQLiteDSN() string {
+	return fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", c.Database.Path)
+}
+
 func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%s", c.App.Host, c.App.Port)
 }
@@ -6,6 +6,7 @@ import (
 
 	"Fix-Go-Fiber-Backend/pkg/config"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -27,6 +28,8 @@ func NewDatabaseConnection(cfg *config.Config) (*gorm.DB, error) {
 		dialector = mysql.Open(cfg.GetMySQLDSN())
 	case "postgres":
 		dialector = postgres.Open(cfg.GetPostgresDSN())
+	case "sqlite":
+		dialector = sqlite.Open(cfg.GetSQLiteDSN())
 	default:
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Database.Driver)
 	}
@@ -47,18 +50,47 @@ func NewDatabaseConnection(cfg *config.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(10)
 	sqlDB.SetMaxOpenConns(100)
 
+	// SQLite allows a single writer, and every connection to :memory: opens a
+	// separate empty database, so keep exactly one connection.
+	if cfg.Database.Driver == "sqlite" {
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+	}
+
 	return db, nil
 }
 
 func RunMigrations(db *gorm.DB, cfg *config.Config) error {
 	log.Println("Running database migrations...")
 	
-	// Use raw SQL migrations instead of GORM AutoMigrate
-	err := CreateTables(db, cfg)
+	// Use versioned raw SQL migrations instead of GORM AutoMigrate
+	err := MigrateUp(db, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create tables: %w", err)
+		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	log.Println("Database migrations completed successfully")
 	return nil
-}
\ No newline at end of file
+}
+// NewInMemoryDatabase opens a private, fully migrated SQLite :memory: database.
+// It needs no database server, which makes it suitable for tests and local tooling.
+func NewInMemoryDatabase() (*gorm.DB, error) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Driver: "sqlite",
+			Path:   ":memory:",
+		},
+	}
+
+	db, err := NewDatabaseConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := MigrateUp(db, cfg); err != nil {
+		return nil, fmt.Errorf("failed to migrate in-memory database: %w", err)
+	}
+
+	return db, nil
+}
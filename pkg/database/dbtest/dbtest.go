@@ -0,0 +1,31 @@
+// Package dbtest provides migrated in-memory databases for tests.
+package dbtest
+
+import (
+	"testing"
+
+	"Fix-Go-Fiber-Backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// New returns a fresh SQLite :memory: database with every migration applied.
+// The database is closed when the test finishes.
+func New(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	db, err := database.NewInMemoryDatabase()
+	if err != nil {
+		tb.Fatalf("failed to open test database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		tb.Fatalf("failed to get test database connection: %v", err)
+	}
+	tb.Cleanup(func() {
+		sqlDB.Close()
+	})
+
+	return db
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Querier is the subset of *sql.DB and *sql.Tx used by the raw-SQL repositories
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Dialect hides the SQL differences between the drivers supported by NewDatabaseConnection.
+// Queries are always written with ? placeholders and rebound for the target database.
+type Dialect interface {
+	Name() string
+	Rebind(query string) string
+	// InsertReturningID executes an INSERT and returns the generated id column
+	InsertReturningID(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error)
+	TableExists(ctx context.Context, q Querier, table string) (bool, error)
+	// ForUpdate is the clause that makes a SELECT inside a transaction lock the rows it reads
+	ForUpdate() string
+}
+
+func NewDialect(driver string) (Dialect, error) {
+	switch driver {
+	case "postgres":
+		return postgresDialect{}, nil
+	case "mysql":
+		return mysqlDialect{}, nil
+	case "sqlite":
+		return sqliteDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
+
+// DialectOf returns the dialect of an open gorm connection
+func DialectOf(db *gorm.DB) Dialect {
+	dialect, err := NewDialect(db.Dialector.Name())
+	if err != nil {
+		// NewDatabaseConnection only opens supported drivers
+		panic(err)
+	}
+	return dialect
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string {
+	return "postgres"
+}
+
+func (postgresDialect) Rebind(query string) string {
+	var builder strings.Builder
+	var quote rune
+	n := 0
+
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+func (d postgresDialect) InsertReturningID(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx, d.Rebind(query)+" RETURNING id", args...).Scan(&id)
+	return id, err
+}
+
+func (d postgresDialect) TableExists(ctx context.Context, q Querier, table string) (bool, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?`
+	return countExists(ctx, q, d.Rebind(query), table)
+}
+
+func (postgresDialect) ForUpdate() string {
+	return " FOR UPDATE"
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (mysqlDialect) Rebind(query string) string {
+	return query
+}
+
+func (mysqlDialect) InsertReturningID(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error) {
+	return execLastInsertID(ctx, q, query, args...)
+}
+
+func (mysqlDialect) TableExists(ctx context.Context, q Querier, table string) (bool, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?`
+	return countExists(ctx, q, query, table)
+}
+
+func (mysqlDialect) ForUpdate() string {
+	return " FOR UPDATE"
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+func (sqliteDialect) Rebind(query string) string {
+	return query
+}
+
+func (sqliteDialect) InsertReturningID(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error) {
+	return execLastInsertID(ctx, q, query, args...)
+}
+
+func (sqliteDialect) TableExists(ctx context.Context, q Querier, table string) (bool, error) {
+	query := `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`
+	return countExists(ctx, q, query, table)
+}
+
+// ForUpdate is empty: SQLite has no row locks and the single connection already serializes transactions
+func (sqliteDialect) ForUpdate() string {
+	return ""
+}
+
+func execLastInsertID(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error) {
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func countExists(ctx context.Context, q Querier, query string, args ...interface{}) (bool, error) {
+	var count int
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Conn runs queries written with ? placeholders against a Querier, rebinding them
+// for its dialect. Repositories use it instead of the bare *sql.DB.
+type Conn struct {
+	q       Querier
+	Dialect Dialect
+}
+
+func NewConn(q Querier, dialect Dialect) *Conn {
+	return &Conn{
+		q:       q,
+		Dialect: dialect,
+	}
+}
+
+// Connect returns a Conn on the transaction carried by ctx, see WithTx, or on the
+// connection pool behind a gorm connection when there is none
+func Connect(ctx context.Context, db *gorm.DB) (*Conn, error) {
+	if state := txFromContext(ctx); state != nil {
+		return NewConn(state.tx, state.dialect), nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(sqlDB, DialectOf(db)), nil
+}
+
+func (c *Conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.q.ExecContext(ctx, c.Dialect.Rebind(query), args...)
+}
+
+func (c *Conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.q.QueryContext(ctx, c.Dialect.Rebind(query), args...)
+}
+
+func (c *Conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.q.QueryRowContext(ctx, c.Dialect.Rebind(query), args...)
+}
+
+// InsertContext executes an INSERT into a table with an id primary key and returns the new id
+func (c *Conn) InsertContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return c.Dialect.InsertReturningID(ctx, c.q, query, args...)
+}
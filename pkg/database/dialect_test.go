@@ -0,0 +1,169 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Fix-Go-Fiber-Backend/pkg/database"
+	"Fix-Go-Fiber-Backend/pkg/database/dbtest"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+		want   string
+	}{
+		{"postgres", `SELECT id FROM t WHERE a = ? AND b = ?`, `SELECT id FROM t WHERE a = $1 AND b = $2`},
+		{"postgres", `SELECT id FROM t WHERE a = '?' AND b = ?`, `SELECT id FROM t WHERE a = '?' AND b = $1`},
+		{"postgres", `SELECT "a?" FROM t WHERE b = ?`, `SELECT "a?" FROM t WHERE b = $1`},
+		{"postgres", `UPDATE t SET a = ?, b = ? WHERE id = ? AND c IS NULL`, `UPDATE t SET a = $1, b = $2 WHERE id = $3 AND c IS NULL`},
+		{"mysql", `SELECT id FROM t WHERE a = ? AND b = ?`, `SELECT id FROM t WHERE a = ? AND b = ?`},
+		{"sqlite", `SELECT id FROM t WHERE a = ? AND b = ?`, `SELECT id FROM t WHERE a = ? AND b = ?`},
+	}
+
+	for _, tt := range tests {
+		dialect, err := database.NewDialect(tt.driver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dialect.Rebind(tt.query); got != tt.want {
+			t.Errorf("%s Rebind(%q) = %q, want %q", tt.driver, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestForUpdate(t *testing.T) {
+	tests := map[string]string{
+		"postgres": " FOR UPDATE",
+		"mysql":    " FOR UPDATE",
+		"sqlite":   "",
+	}
+
+	for driver, want := range tests {
+		dialect, err := database.NewDialect(driver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dialect.ForUpdate(); got != want {
+			t.Errorf("%s ForUpdate() = %q, want %q", driver, got, want)
+		}
+	}
+}
+
+// TestInsertReturningID runs the postgres RETURNING id insert and the LastInsertId
+// insert of mysql and sqlite against SQLite, which understands both
+func TestInsertReturningID(t *testing.T) {
+	for _, driver := range []string{"postgres", "mysql", "sqlite"} {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			sqlDB, err := dbtest.New(t).DB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sqlDB.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, note TEXT)`); err != nil {
+				t.Fatal(err)
+			}
+
+			dialect, err := database.NewDialect(driver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn := database.NewConn(sqlDB, dialect)
+
+			for i, name := range []string{"first", "second"} {
+				id, err := conn.InsertContext(ctx, `INSERT INTO items (name, note) VALUES (?, ?)`, name, "note "+name)
+				if err != nil {
+					t.Fatalf("insert %s: %v", name, err)
+				}
+				if id != int64(i+1) {
+					t.Errorf("insert %s returned id %d, want %d", name, id, i+1)
+				}
+
+				var got string
+				if err := conn.QueryRowContext(ctx, `SELECT name FROM items WHERE id = ?`, id).Scan(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got != name {
+					t.Errorf("row %d has name %q, want %q", id, got, name)
+				}
+			}
+		})
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqlDB.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	insert := func(ctx context.Context, name string) error {
+		conn, err := database.Connect(ctx, db)
+		if err != nil {
+			return err
+		}
+		_, err = conn.InsertContext(ctx, `INSERT INTO items (name) VALUES (?)`, name)
+		return err
+	}
+	exists := func(name string) bool {
+		var count int
+		if err := sqlDB.QueryRow(`SELECT COUNT(*) FROM items WHERE name = ?`, name).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		return count > 0
+	}
+	errFail := errors.New("fail")
+
+	err = database.WithTx(ctx, db, func(ctx context.Context) error {
+		if !database.InTx(ctx) {
+			t.Error("context of WithTx carries no transaction")
+		}
+		return insert(ctx, "committed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.WithTx(ctx, db, func(ctx context.Context) error {
+		if err := insert(ctx, "rolled back"); err != nil {
+			return err
+		}
+		return errFail
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("WithTx returned %v, want %v", err, errFail)
+	}
+
+	// A failing nested call only undoes its own savepoint
+	err = database.WithTx(ctx, db, func(ctx context.Context) error {
+		if err := insert(ctx, "outer"); err != nil {
+			return err
+		}
+		nestedErr := database.WithTx(ctx, db, func(ctx context.Context) error {
+			if err := insert(ctx, "nested"); err != nil {
+				return err
+			}
+			return errFail
+		})
+		if !errors.Is(nestedErr, errFail) {
+			t.Errorf("nested WithTx returned %v, want %v", nestedErr, errFail)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]bool{"committed": true, "rolled back": false, "outer": true, "nested": false} {
+		if got := exists(name); got != want {
+			t.Errorf("row %q exists = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+-- MySQL has no CREATE INDEX IF NOT EXISTS, so secondary indexes are declared
+-- inline to keep this migration safe on databases created by the old CreateTables.
+CREATE TABLE IF NOT EXISTS mahasiswas (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	nim VARCHAR(20) UNIQUE NOT NULL,
+	nama VARCHAR(100) NOT NULL,
+	jurusan VARCHAR(50) NOT NULL,
+	angkatan INT NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	INDEX idx_mahasiswas_deleted_at (deleted_at)
+);
+
+CREATE TABLE IF NOT EXISTS alumni (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	mahasiswa_id INT NOT NULL,
+	tahun_lulus INT NOT NULL,
+	no_telepon VARCHAR(20),
+	alamat TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	INDEX idx_alumni_deleted_at (deleted_at),
+	INDEX idx_alumni_mahasiswa_id (mahasiswa_id),
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	alumni_id INT NOT NULL,
+	nama_company VARCHAR(100) NOT NULL,
+	posisi VARCHAR(100) NOT NULL,
+	tanggal_mulai DATE NOT NULL,
+	tanggal_selesai DATE NULL,
+	status VARCHAR(20) DEFAULT 'aktif',
+	deskripsi TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	INDEX idx_pekerjaan_alumni_deleted_at (deleted_at),
+	INDEX idx_pekerjaan_alumni_alumni_id (alumni_id),
+	FOREIGN KEY (alumni_id) REFERENCES alumni(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS admin_users (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	username VARCHAR(50) UNIQUE NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	role VARCHAR(20) DEFAULT 'admin',
+	is_active BOOLEAN DEFAULT true,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	INDEX idx_admin_users_deleted_at (deleted_at)
+);
@@ -0,0 +1,36 @@
+ALTER TABLE pekerjaan_alumni ADD COLUMN alumni_id INT NULL AFTER id;
+
+-- Graduates created after the upgrade have no legacy alumni row yet
+INSERT INTO alumni (mahasiswa_id, tahun_lulus, no_telepon, alamat, created_at, updated_at)
+SELECT m.id,
+	COALESCE(m.tahun_lulus, YEAR(CURRENT_DATE)),
+	m.no_telepon,
+	m.alamat_alumni,
+	CURRENT_TIMESTAMP,
+	CURRENT_TIMESTAMP
+FROM mahasiswas m
+WHERE (m.status = 'graduated' OR m.id IN (SELECT mahasiswa_id FROM pekerjaan_alumni))
+	AND NOT EXISTS (SELECT 1 FROM alumni a WHERE a.mahasiswa_id = m.id AND a.deleted_at IS NULL);
+
+UPDATE pekerjaan_alumni p
+JOIN (
+	SELECT mahasiswa_id, MIN(id) AS id FROM alumni
+	WHERE deleted_at IS NULL GROUP BY mahasiswa_id
+) a ON a.mahasiswa_id = p.mahasiswa_id
+SET p.alumni_id = a.id;
+
+ALTER TABLE pekerjaan_alumni DROP FOREIGN KEY fk_pekerjaan_alumni_mahasiswa;
+
+ALTER TABLE pekerjaan_alumni
+	DROP COLUMN mahasiswa_id,
+	MODIFY alumni_id INT NOT NULL,
+	ADD INDEX idx_pekerjaan_alumni_alumni_id (alumni_id),
+	ADD CONSTRAINT fk_pekerjaan_alumni_alumni
+		FOREIGN KEY (alumni_id) REFERENCES alumni(id) ON DELETE CASCADE;
+
+ALTER TABLE mahasiswas
+	DROP INDEX idx_mahasiswas_status,
+	DROP COLUMN alamat_alumni,
+	DROP COLUMN no_telepon,
+	DROP COLUMN tahun_lulus,
+	DROP COLUMN status;
@@ -0,0 +1,46 @@
+-- Alumni data now lives on mahasiswas; the legacy alumni table is kept read-only
+-- so this migration can be rolled back.
+ALTER TABLE mahasiswas
+	ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+	ADD COLUMN tahun_lulus INT NULL,
+	ADD COLUMN no_telepon VARCHAR(20) NULL,
+	ADD COLUMN alamat_alumni TEXT NULL,
+	ADD INDEX idx_mahasiswas_status (status);
+
+UPDATE mahasiswas m
+JOIN alumni a ON a.mahasiswa_id = m.id AND a.deleted_at IS NULL
+SET m.status = 'graduated',
+	m.tahun_lulus = a.tahun_lulus,
+	m.no_telepon = a.no_telepon,
+	m.alamat_alumni = a.alamat;
+
+-- Re-point pekerjaan_alumni from alumni(id) to mahasiswas(id)
+ALTER TABLE pekerjaan_alumni ADD COLUMN mahasiswa_id INT NULL AFTER id;
+
+UPDATE pekerjaan_alumni p
+JOIN alumni a ON a.id = p.alumni_id
+SET p.mahasiswa_id = a.mahasiswa_id;
+
+-- The foreign key created in 0001 has no explicit name. MySQL numbers generated
+-- names, and databases created by the old CreateTables may use another one, so
+-- the name is looked up and the constraint dropped through a prepared statement.
+SET @fk_alumni = (
+	SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+	WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = 'pekerjaan_alumni'
+		AND COLUMN_NAME = 'alumni_id'
+		AND REFERENCED_TABLE_NAME = 'alumni'
+	LIMIT 1
+);
+SET @drop_fk_alumni = IF(@fk_alumni IS NULL, 'DO 0',
+	CONCAT('ALTER TABLE pekerjaan_alumni DROP FOREIGN KEY `', REPLACE(@fk_alumni, '`', '``'), '`'));
+PREPARE drop_fk_alumni FROM @drop_fk_alumni;
+EXECUTE drop_fk_alumni;
+DEALLOCATE PREPARE drop_fk_alumni;
+
+ALTER TABLE pekerjaan_alumni
+	DROP COLUMN alumni_id,
+	MODIFY mahasiswa_id INT NOT NULL,
+	ADD INDEX idx_pekerjaan_alumni_mahasiswa_id (mahasiswa_id),
+	ADD CONSTRAINT fk_pekerjaan_alumni_mahasiswa
+		FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS mahasiswa_status_history (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	mahasiswa_id INT NOT NULL,
+	transition VARCHAR(30) NOT NULL,
+	from_status VARCHAR(20) NOT NULL,
+	to_status VARCHAR(20) NOT NULL,
+	reason TEXT NOT NULL,
+	actor_id INT NULL,
+	actor_role VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_mahasiswa_status_history_mahasiswa_id (mahasiswa_id),
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
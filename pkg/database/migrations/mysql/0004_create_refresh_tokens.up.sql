@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	family_id VARCHAR(64) NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INT NOT NULL,
+	role VARCHAR(20) NOT NULL,
+	device_id VARCHAR(100) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	revoked_at TIMESTAMP NULL,
+	replaced_by_id INT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_refresh_tokens_family_id (family_id),
+	INDEX idx_refresh_tokens_user (user_type, user_id)
+);
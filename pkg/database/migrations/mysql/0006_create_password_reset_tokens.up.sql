@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INT NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_password_reset_tokens_user (user_type, user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE mahasiswas DROP COLUMN email_verified_at;
@@ -0,0 +1,14 @@
+ALTER TABLE mahasiswas ADD COLUMN email_verified_at TIMESTAMP NULL;
+
+-- Accounts created before verification existed are treated as verified
+UPDATE mahasiswas SET email_verified_at = created_at;
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	mahasiswa_id INT NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_email_verification_tokens_mahasiswa (mahasiswa_id)
+);
@@ -0,0 +1,4 @@
+-- Only super admins can manage admins; promote the seeded admin when nobody else could
+UPDATE admin_users SET role = 'super_admin'
+WHERE username = 'admin' AND deleted_at IS NULL
+	AND (SELECT n FROM (SELECT COUNT(*) AS n FROM admin_users WHERE role = 'super_admin' AND deleted_at IS NULL) AS super_admins) = 0;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	user_type VARCHAR(20) NOT NULL,
+	identifier VARCHAR(100) NOT NULL,
+	ip_address VARCHAR(45) NOT NULL,
+	outcome VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_login_attempts_identifier (identifier, created_at),
+	INDEX idx_login_attempts_ip (ip_address, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	scope VARCHAR(20) NOT NULL,
+	subject VARCHAR(100) NOT NULL,
+	failed_count INT NOT NULL DEFAULT 0,
+	last_failed_at TIMESTAMP NOT NULL,
+	locked_until TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE KEY uq_login_lockouts_subject (scope, subject),
+	INDEX idx_login_lockouts_locked_until (locked_until)
+);
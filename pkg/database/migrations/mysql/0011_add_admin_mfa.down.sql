@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS admin_recovery_codes;
+
+ALTER TABLE admin_users DROP COLUMN totp_last_step;
+ALTER TABLE admin_users DROP COLUMN totp_enabled;
+ALTER TABLE admin_users DROP COLUMN totp_secret;
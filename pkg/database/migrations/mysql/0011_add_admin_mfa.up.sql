@@ -0,0 +1,13 @@
+-- totp_secret is set on enrollment; totp_last_step is the last accepted time step, so a code works only once
+ALTER TABLE admin_users ADD COLUMN totp_secret VARCHAR(64) NULL;
+ALTER TABLE admin_users ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE admin_users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	admin_id INT NOT NULL,
+	code_hash VARCHAR(64) NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_admin_recovery_codes_admin (admin_id)
+);
@@ -0,0 +1,18 @@
+-- Graduation requests a mahasiswa submits and an admin approves or rejects
+CREATE TABLE IF NOT EXISTS graduation_requests (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	mahasiswa_id INT NOT NULL,
+	tahun_lulus INT NOT NULL,
+	no_telepon VARCHAR(15) NOT NULL DEFAULT '',
+	alamat_alumni TEXT NOT NULL,
+	document_ref VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	review_reason VARCHAR(500) NOT NULL DEFAULT '',
+	reviewed_by INT NULL,
+	reviewed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_graduation_requests_mahasiswa_id (mahasiswa_id),
+	INDEX idx_graduation_requests_status (status, created_at),
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
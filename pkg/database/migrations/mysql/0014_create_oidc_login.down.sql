@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS external_identities;
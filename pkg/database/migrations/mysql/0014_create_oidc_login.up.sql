@@ -0,0 +1,25 @@
+-- Accounts at the campus identity provider linked to mahasiswa and admins
+CREATE TABLE IF NOT EXISTS external_identities (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	issuer VARCHAR(255) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INT NOT NULL,
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	last_login_at TIMESTAMP NULL,
+	UNIQUE KEY uq_external_identities_subject (issuer, subject),
+	INDEX idx_external_identities_user (user_type, user_id)
+);
+
+-- SSO logins waiting for the identity provider to redirect back
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	state_hash VARCHAR(64) UNIQUE NOT NULL,
+	nonce VARCHAR(64) NOT NULL,
+	code_verifier VARCHAR(128) NOT NULL,
+	device_id VARCHAR(100) NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_oidc_login_states_expires_at (expires_at)
+);
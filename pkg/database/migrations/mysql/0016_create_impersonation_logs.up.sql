@@ -0,0 +1,16 @@
+-- Tokens admins were issued to act as a mahasiswa or alumni
+CREATE TABLE IF NOT EXISTS impersonation_logs (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	admin_id INT NOT NULL,
+	admin_username VARCHAR(50) NOT NULL,
+	mahasiswa_id INT NOT NULL,
+	role VARCHAR(20) NOT NULL,
+	reason VARCHAR(500) NOT NULL,
+	token_id VARCHAR(64) NOT NULL,
+	ip_address VARCHAR(45) NOT NULL DEFAULT '',
+	user_agent VARCHAR(255) NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	INDEX idx_impersonation_logs_admin_id (admin_id, created_at),
+	INDEX idx_impersonation_logs_mahasiswa_id (mahasiswa_id, created_at)
+);
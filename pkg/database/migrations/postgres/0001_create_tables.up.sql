@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS mahasiswas (
+	id SERIAL PRIMARY KEY,
+	nim VARCHAR(20) UNIQUE NOT NULL,
+	nama VARCHAR(100) NOT NULL,
+	jurusan VARCHAR(50) NOT NULL,
+	angkatan INTEGER NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS alumni (
+	id SERIAL PRIMARY KEY,
+	mahasiswa_id INTEGER NOT NULL,
+	tahun_lulus INTEGER NOT NULL,
+	no_telepon VARCHAR(20),
+	alamat TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni (
+	id SERIAL PRIMARY KEY,
+	alumni_id INTEGER NOT NULL,
+	nama_company VARCHAR(100) NOT NULL,
+	posisi VARCHAR(100) NOT NULL,
+	tanggal_mulai DATE NOT NULL,
+	tanggal_selesai DATE NULL,
+	status VARCHAR(20) DEFAULT 'aktif',
+	deskripsi TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	FOREIGN KEY (alumni_id) REFERENCES alumni(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS admin_users (
+	id SERIAL PRIMARY KEY,
+	username VARCHAR(50) UNIQUE NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	role VARCHAR(20) DEFAULT 'admin',
+	is_active BOOLEAN DEFAULT true,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mahasiswas_deleted_at ON mahasiswas(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_mahasiswas_email ON mahasiswas(email);
+CREATE INDEX IF NOT EXISTS idx_mahasiswas_nim ON mahasiswas(nim);
+CREATE INDEX IF NOT EXISTS idx_alumni_deleted_at ON alumni(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_alumni_mahasiswa_id ON alumni(mahasiswa_id);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_deleted_at ON pekerjaan_alumni(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_alumni_id ON pekerjaan_alumni(alumni_id);
+CREATE INDEX IF NOT EXISTS idx_admin_users_deleted_at ON admin_users(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_admin_users_username ON admin_users(username);
+CREATE INDEX IF NOT EXISTS idx_admin_users_email ON admin_users(email);
@@ -0,0 +1,30 @@
+ALTER TABLE pekerjaan_alumni ADD COLUMN alumni_id INTEGER NULL;
+
+-- Graduates created after the upgrade have no legacy alumni row yet
+INSERT INTO alumni (mahasiswa_id, tahun_lulus, no_telepon, alamat, created_at, updated_at)
+SELECT m.id,
+	COALESCE(m.tahun_lulus, CAST(EXTRACT(YEAR FROM CURRENT_DATE) AS INTEGER)),
+	m.no_telepon,
+	m.alamat_alumni,
+	CURRENT_TIMESTAMP,
+	CURRENT_TIMESTAMP
+FROM mahasiswas m
+WHERE (m.status = 'graduated' OR m.id IN (SELECT mahasiswa_id FROM pekerjaan_alumni))
+	AND NOT EXISTS (SELECT 1 FROM alumni a WHERE a.mahasiswa_id = m.id AND a.deleted_at IS NULL);
+
+UPDATE pekerjaan_alumni p
+SET alumni_id = (
+	SELECT MIN(a.id) FROM alumni a
+	WHERE a.mahasiswa_id = p.mahasiswa_id AND a.deleted_at IS NULL
+);
+
+ALTER TABLE pekerjaan_alumni ALTER COLUMN alumni_id SET NOT NULL;
+ALTER TABLE pekerjaan_alumni ADD FOREIGN KEY (alumni_id) REFERENCES alumni(id) ON DELETE CASCADE;
+CREATE INDEX idx_pekerjaan_alumni_alumni_id ON pekerjaan_alumni(alumni_id);
+ALTER TABLE pekerjaan_alumni DROP COLUMN mahasiswa_id;
+
+DROP INDEX IF EXISTS idx_mahasiswas_status;
+ALTER TABLE mahasiswas DROP COLUMN alamat_alumni;
+ALTER TABLE mahasiswas DROP COLUMN no_telepon;
+ALTER TABLE mahasiswas DROP COLUMN tahun_lulus;
+ALTER TABLE mahasiswas DROP COLUMN status;
@@ -0,0 +1,32 @@
+-- Alumni data now lives on mahasiswas; the legacy alumni table is kept read-only
+-- so this migration can be rolled back.
+ALTER TABLE mahasiswas ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE mahasiswas ADD COLUMN tahun_lulus INTEGER NULL;
+ALTER TABLE mahasiswas ADD COLUMN no_telepon VARCHAR(20) NULL;
+ALTER TABLE mahasiswas ADD COLUMN alamat_alumni TEXT NULL;
+CREATE INDEX idx_mahasiswas_status ON mahasiswas(status);
+
+UPDATE mahasiswas m
+SET status = 'graduated',
+	tahun_lulus = a.tahun_lulus,
+	no_telepon = a.no_telepon,
+	alamat_alumni = a.alamat,
+	updated_at = CURRENT_TIMESTAMP
+FROM alumni a
+WHERE a.mahasiswa_id = m.id AND a.deleted_at IS NULL;
+
+-- Re-point pekerjaan_alumni from alumni(id) to mahasiswas(id)
+ALTER TABLE pekerjaan_alumni ADD COLUMN mahasiswa_id INTEGER NULL;
+
+UPDATE pekerjaan_alumni p
+SET mahasiswa_id = a.mahasiswa_id
+FROM alumni a
+WHERE a.id = p.alumni_id;
+
+ALTER TABLE pekerjaan_alumni ALTER COLUMN mahasiswa_id SET NOT NULL;
+ALTER TABLE pekerjaan_alumni ADD CONSTRAINT fk_pekerjaan_alumni_mahasiswa
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE;
+CREATE INDEX idx_pekerjaan_alumni_mahasiswa_id ON pekerjaan_alumni(mahasiswa_id);
+
+-- Dropping the column also drops its index and foreign key
+ALTER TABLE pekerjaan_alumni DROP COLUMN alumni_id;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	id SERIAL PRIMARY KEY,
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens(user_type, user_id);
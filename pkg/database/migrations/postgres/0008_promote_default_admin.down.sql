@@ -0,0 +1,2 @@
+-- The promotion cannot be told apart from a later role change, so it is kept
+SELECT 1;
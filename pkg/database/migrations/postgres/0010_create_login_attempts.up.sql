@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+	id SERIAL PRIMARY KEY,
+	user_type VARCHAR(20) NOT NULL,
+	identifier VARCHAR(100) NOT NULL,
+	ip_address VARCHAR(45) NOT NULL,
+	outcome VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_identifier ON login_attempts(identifier, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts(ip_address, created_at);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+	id SERIAL PRIMARY KEY,
+	scope VARCHAR(20) NOT NULL,
+	subject VARCHAR(100) NOT NULL,
+	failed_count INTEGER NOT NULL DEFAULT 0,
+	last_failed_at TIMESTAMP NOT NULL,
+	locked_until TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (scope, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockouts_locked_until ON login_lockouts(locked_until);
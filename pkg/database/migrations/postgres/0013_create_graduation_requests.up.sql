@@ -0,0 +1,19 @@
+-- Graduation requests a mahasiswa submits and an admin approves or rejects
+CREATE TABLE IF NOT EXISTS graduation_requests (
+	id SERIAL PRIMARY KEY,
+	mahasiswa_id INTEGER NOT NULL,
+	tahun_lulus INTEGER NOT NULL,
+	no_telepon VARCHAR(15) NOT NULL DEFAULT '',
+	alamat_alumni TEXT NOT NULL,
+	document_ref VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	review_reason VARCHAR(500) NOT NULL DEFAULT '',
+	reviewed_by INTEGER NULL,
+	reviewed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_graduation_requests_mahasiswa_id ON graduation_requests(mahasiswa_id);
+CREATE INDEX IF NOT EXISTS idx_graduation_requests_status ON graduation_requests(status, created_at);
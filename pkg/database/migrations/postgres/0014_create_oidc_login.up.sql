@@ -0,0 +1,27 @@
+-- Accounts at the campus identity provider linked to mahasiswa and admins
+CREATE TABLE IF NOT EXISTS external_identities (
+	id SERIAL PRIMARY KEY,
+	issuer VARCHAR(255) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	last_login_at TIMESTAMP NULL,
+	UNIQUE (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_identities_user ON external_identities(user_type, user_id);
+
+-- SSO logins waiting for the identity provider to redirect back
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+	id SERIAL PRIMARY KEY,
+	state_hash VARCHAR(64) UNIQUE NOT NULL,
+	nonce VARCHAR(64) NOT NULL,
+	code_verifier VARCHAR(128) NOT NULL,
+	device_id VARCHAR(100) NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
@@ -0,0 +1,15 @@
+-- Logins per device, one per refresh token family
+CREATE TABLE IF NOT EXISTS sessions (
+	id SERIAL PRIMARY KEY,
+	family_id VARCHAR(64) UNIQUE NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	device_id VARCHAR(100) NOT NULL DEFAULT '',
+	device_name VARCHAR(100) NOT NULL DEFAULT '',
+	ip_address VARCHAR(45) NOT NULL DEFAULT '',
+	user_agent VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	last_seen_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_type, user_id);
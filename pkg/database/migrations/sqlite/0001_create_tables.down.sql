@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pekerjaan_alumni;
+DROP TABLE IF EXISTS admin_users;
+DROP TABLE IF EXISTS mahasiswas;
@@ -0,0 +1,51 @@
+-- SQLite databases never held the legacy alumni table, so this set starts from
+-- the unified schema and has no 0002_unify_mahasiswa_alumni.
+CREATE TABLE IF NOT EXISTS mahasiswas (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	nim VARCHAR(20) UNIQUE NOT NULL,
+	nama VARCHAR(100) NOT NULL,
+	jurusan VARCHAR(50) NOT NULL,
+	angkatan INTEGER NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'active',
+	tahun_lulus INTEGER NULL,
+	no_telepon VARCHAR(20) NULL,
+	alamat_alumni TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	mahasiswa_id INTEGER NOT NULL,
+	nama_company VARCHAR(100) NOT NULL,
+	posisi VARCHAR(100) NOT NULL,
+	tanggal_mulai DATE NOT NULL,
+	tanggal_selesai DATE NULL,
+	status VARCHAR(20) DEFAULT 'aktif',
+	deskripsi TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS admin_users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	username VARCHAR(50) UNIQUE NOT NULL,
+	email VARCHAR(100) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	role VARCHAR(20) DEFAULT 'admin',
+	is_active BOOLEAN DEFAULT 1,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mahasiswas_deleted_at ON mahasiswas(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_mahasiswas_status ON mahasiswas(status);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_deleted_at ON pekerjaan_alumni(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_mahasiswa_id ON pekerjaan_alumni(mahasiswa_id);
+CREATE INDEX IF NOT EXISTS idx_admin_users_deleted_at ON admin_users(deleted_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mahasiswa_status_history;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS mahasiswa_status_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	mahasiswa_id INTEGER NOT NULL,
+	transition VARCHAR(30) NOT NULL,
+	from_status VARCHAR(20) NOT NULL,
+	to_status VARCHAR(20) NOT NULL,
+	reason TEXT NOT NULL,
+	actor_id INTEGER NULL,
+	actor_role VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (mahasiswa_id) REFERENCES mahasiswas(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_mahasiswa_status_history_mahasiswa_id ON mahasiswa_status_history(mahasiswa_id);
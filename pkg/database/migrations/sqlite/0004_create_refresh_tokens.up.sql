@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	family_id VARCHAR(64) NOT NULL,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	role VARCHAR(20) NOT NULL,
+	device_id VARCHAR(100) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	revoked_at TIMESTAMP NULL,
+	replaced_by_id INTEGER NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_type, user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	jti VARCHAR(64) PRIMARY KEY,
+	expires_at TIMESTAMP NOT NULL,
+	revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS token_revocation_cutoffs (
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	revoked_before TIMESTAMP NOT NULL,
+	PRIMARY KEY (user_type, user_id)
+);
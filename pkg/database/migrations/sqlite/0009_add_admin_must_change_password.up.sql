@@ -0,0 +1,4 @@
+ALTER TABLE admin_users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT 0;
+
+-- The old seed created admin/admin123; that password has to be replaced on next login
+UPDATE admin_users SET must_change_password = 1 WHERE password = '$2a$12$DID7pK1MQljp0G3VQ1xUiekK1SXX1G04bYJy.bEN1zA6MxVaZ7eYC';
@@ -0,0 +1,14 @@
+-- totp_secret is set on enrollment; totp_last_step is the last accepted time step, so a code works only once
+ALTER TABLE admin_users ADD COLUMN totp_secret VARCHAR(64) NULL;
+ALTER TABLE admin_users ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE admin_users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	admin_id INTEGER NOT NULL,
+	code_hash VARCHAR(64) NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_recovery_codes_admin ON admin_recovery_codes(admin_id);
@@ -0,0 +1,10 @@
+-- Keys for RS256/EdDSA access tokens. The newest key that is not retired signs;
+-- retired keys keep verifying until the tokens they signed have expired.
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	kid VARCHAR(64) UNIQUE NOT NULL,
+	algorithm VARCHAR(10) NOT NULL,
+	private_key TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	retired_at TIMESTAMP NULL
+);
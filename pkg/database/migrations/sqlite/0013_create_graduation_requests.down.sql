@@ -0,0 +1 @@
+DROP TABLE IF EXISTS graduation_requests;
@@ -0,0 +1,15 @@
+-- API keys of machine-to-machine integrations, stored hashed
+CREATE TABLE IF NOT EXISTS api_keys (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name VARCHAR(100) NOT NULL,
+	prefix VARCHAR(20) NOT NULL,
+	key_hash VARCHAR(64) UNIQUE NOT NULL,
+	scopes VARCHAR(500) NOT NULL,
+	created_by INTEGER NULL,
+	expires_at TIMESTAMP NULL,
+	last_used_at TIMESTAMP NULL,
+	rotated_at TIMESTAMP NULL,
+	revoked_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,17 @@
+-- Tokens admins were issued to act as a mahasiswa or alumni
+CREATE TABLE IF NOT EXISTS impersonation_logs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	admin_id INTEGER NOT NULL,
+	admin_username VARCHAR(50) NOT NULL,
+	mahasiswa_id INTEGER NOT NULL,
+	role VARCHAR(20) NOT NULL,
+	reason VARCHAR(500) NOT NULL,
+	token_id VARCHAR(64) NOT NULL,
+	ip_address VARCHAR(45) NOT NULL DEFAULT '',
+	user_agent VARCHAR(255) NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_impersonation_logs_admin_id ON impersonation_logs(admin_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_impersonation_logs_mahasiswa_id ON impersonation_logs(mahasiswa_id, created_at);
@@ -0,0 +1,14 @@
+-- password_changed_at starts the maximum password age of admins; existing passwords count from now
+ALTER TABLE admin_users ADD COLUMN password_changed_at TIMESTAMP NULL;
+UPDATE admin_users SET password_changed_at = CURRENT_TIMESTAMP;
+
+-- Hashes of the passwords each account had, so recent ones cannot be chosen again
+CREATE TABLE IF NOT EXISTS password_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_type VARCHAR(20) NOT NULL,
+	user_id INTEGER NOT NULL,
+	password_hash VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user ON password_history(user_type, user_id);
@@ -0,0 +1,395 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationFilePattern matches files like 0002_add_status.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a single numbered schema change for one dialect
+type Migration struct {
+	Version int
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+// Checksum identifies the exact up script that was applied
+func (m *Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.UpSQL))
+	return hex.EncodeToString(sum[:])
+}
+
+// AppliedMigration is a row of the schema_migrations bookkeeping table
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// MigrationStatus describes a known migration and whether it has been applied
+type MigrationStatus struct {
+	Migration *Migration
+	Applied   *AppliedMigration
+}
+
+// Migrator applies the embedded migrations of a dialect and records them in schema_migrations.
+// Versions do not have to be contiguous, they only have to be unique and increasing.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []*Migration
+	DryRun     bool
+}
+
+func NewMigrator(db *sql.DB, driver string) (*Migrator, error) {
+	dialect, err := NewDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := LoadMigrations(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// LoadMigrations reads the embedded migration set for the given driver, ordered by version
+func LoadMigrations(driver string) ([]*Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for database driver %s: %w", driver, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.UpSQL = string(content)
+		} else {
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.UpSQL) == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration in order after verifying the checksums of the applied ones
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.verifiedApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		pending++
+
+		if m.DryRun {
+			m.logDryRun("apply", migration, migration.UpSQL)
+			continue
+		}
+
+		if err := m.apply(ctx, migration); err != nil {
+			return err
+		}
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+
+	if pending == 0 {
+		log.Println("Database schema is up to date")
+	}
+	return nil
+}
+
+// Down rolls back the given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.verifiedApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		steps--
+
+		if strings.TrimSpace(migration.DownSQL) == "" {
+			return fmt.Errorf("migration %04d_%s cannot be rolled back: no down script", migration.Version, migration.Name)
+		}
+
+		if m.DryRun {
+			m.logDryRun("roll back", migration, migration.DownSQL)
+			continue
+		}
+
+		if err := m.revert(ctx, migration); err != nil {
+			return err
+		}
+		log.Printf("Rolled back migration %04d_%s", migration.Version, migration.Name)
+	}
+
+	return nil
+}
+
+// Status lists every known migration together with its applied record, if any
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = record
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) verifiedApplied(ctx context.Context) (map[int]*AppliedMigration, error) {
+	if !m.DryRun {
+		if _, err := m.db.ExecContext(ctx, m.schemaTableQuery()); err != nil {
+			return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+	}
+
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[int]*Migration{}
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, record := range applied {
+		migration, ok := known[version]
+		if !ok {
+			log.Printf("Warning: applied migration %04d_%s is unknown to this build", version, record.Name)
+			continue
+		}
+		if migration.Checksum() != record.Checksum {
+			return nil, fmt.Errorf("checksum mismatch for applied migration %04d_%s: the migration file was changed after it was applied",
+				version, migration.Name)
+		}
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) appliedMigrations(ctx context.Context) (map[int]*AppliedMigration, error) {
+	applied := map[int]*AppliedMigration{}
+
+	exists, err := m.dialect.TableExists(ctx, m.db, "schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations table: %w", err)
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record AppliedMigration
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[record.Version] = &record
+	}
+
+	return applied, rows.Err()
+}
+
+// apply runs a migration and records it in one transaction. Note that MySQL commits
+// DDL statements implicitly, so a failing MySQL migration may be partially applied.
+func (m *Migrator) apply(ctx context.Context, migration *Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %04d: %w", migration.Version, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(migration.UpSQL) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			log.Printf("Error executing query: %s", statement)
+			return fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	query := m.dialect.Rebind(`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`)
+	if _, err := tx.ExecContext(ctx, query, migration.Version, migration.Name, migration.Checksum(), time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration %04d: %w", migration.Version, err)
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) revert(ctx context.Context, migration *Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin rollback of %04d: %w", migration.Version, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(migration.DownSQL) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			log.Printf("Error executing query: %s", statement)
+			return fmt.Errorf("failed to roll back migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	query := m.dialect.Rebind(`DELETE FROM schema_migrations WHERE version = ?`)
+	if _, err := tx.ExecContext(ctx, query, migration.Version); err != nil {
+		return fmt.Errorf("failed to unrecord migration %04d: %w", migration.Version, err)
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) logDryRun(action string, migration *Migration, script string) {
+	log.Printf("[dry-run] would %s migration %04d_%s", action, migration.Version, migration.Name)
+	for _, statement := range splitStatements(script) {
+		log.Printf("[dry-run]   %s", statement)
+	}
+}
+
+func (m *Migrator) schemaTableQuery() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum VARCHAR(64) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+}
+
+// splitStatements splits a migration script on semicolons that are outside
+// quotes and comments. Comment-only fragments are dropped.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote rune
+	inLineComment := false
+
+	flush := func() {
+		statement := strings.TrimSpace(current.String())
+		current.Reset()
+		if stripComments(statement) != "" {
+			statements = append(statements, statement)
+		}
+	}
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case inLineComment:
+			if r == '\n' {
+				inLineComment = false
+			}
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			inLineComment = true
+		case r == ';':
+			flush()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+	flush()
+
+	return statements
+}
+
+func stripComments(statement string) string {
+	var lines []string
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+// txState is the transaction carried by a context. depth counts the savepoints
+// opened by nested WithTx calls and is used to name them.
+type txState struct {
+	tx      *sql.Tx
+	dialect Dialect
+	depth   int
+}
+
+func txFromContext(ctx context.Context) *txState {
+	state, _ := ctx.Value(txContextKey{}).(*txState)
+	return state
+}
+
+// InTx reports whether ctx carries a transaction started by WithTx
+func InTx(ctx context.Context) bool {
+	return txFromContext(ctx) != nil
+}
+
+// WithTx runs fn inside a transaction that is propagated to every Conn obtained
+// through Connect with the context passed to fn. The transaction is committed when
+// fn returns nil and rolled back when it returns an error or panics.
+//
+// When ctx already carries a transaction, fn runs inside a savepoint instead, so a
+// failing nested call only undoes its own work and the outer call can decide
+// whether to continue.
+func WithTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	if state := txFromContext(ctx); state != nil {
+		return withSavepoint(ctx, state, fn)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx, dialect: DialectOf(db)}
+	if err := runGuarded(context.WithValue(ctx, txContextKey{}, state), fn, tx.Rollback); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func withSavepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) error {
+	state := &txState{tx: parent.tx, dialect: parent.dialect, depth: parent.depth + 1}
+	name := fmt.Sprintf("sp_%d", state.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	rollback := func() error {
+		_, err := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		return err
+	}
+	if err := runGuarded(context.WithValue(ctx, txContextKey{}, state), fn, rollback); err != nil {
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// runGuarded calls fn and rolls back when it fails or panics. A panic is re-raised
+// after the rollback.
+func runGuarded(ctx context.Context, fn func(ctx context.Context) error, rollback func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		if rbErr := rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return nil
+}
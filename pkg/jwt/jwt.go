@@ -1,18 +1,85 @@
 package jwt
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
 	"time"
 
 	"Fix-Go-Fiber-Backend/pkg/config"
-	"Fix-Go-Fiber-Backend/internal/domain/service"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// challengeAudience marks MFA challenge tokens, which are no access tokens
+const challengeAudience = "mfa"
+
+const (
+	// minSecretLength is the shortest JWT_SECRET accepted outside development, 256 bits for HS256
+	minSecretLength = 32
+
+	// keyCheckInterval is how often RunKeyRotation looks for a due rotation
+	keyCheckInterval = time.Minute
+	// keyReloadInterval limits reloads of the key store for tokens with an unknown kid
+	keyReloadInterval = time.Second
+
+	// MaxImpersonationExpire caps AUTH_IMPERSONATION_EXPIRE. Impersonation tokens have no
+	// refresh token, and retired signing keys are kept until the longest token ends.
+	MaxImpersonationExpire = time.Hour
+)
+
+// defaultSecrets are JWT_SECRET values published as defaults and examples
+var defaultSecrets = []string{
+	"your-secret-key",
+	"your_secret_key",
+	"your-super-secret-jwt-key",
+	"your_super_secret_jwt_key_here",
+	"your-super-secret-jwt-key-change-this-in-production",
+}
+
 type JWTUtil struct {
-	secretKey string
-	expire    time.Duration
+	expire              time.Duration
+	refreshExpire       time.Duration
+	challengeExpire     time.Duration
+	impersonationExpire time.Duration
+
+	// algorithm is JWT_ALGORITHM. For RS256 and EdDSA keys live in store and are
+	// replaced every rotation; HS256 signs with JWT_SECRET and has no store.
+	algorithm string
+	store     KeyStore
+	rotation  time.Duration
+	// keyCipher seals the private keys in store with JWT_KEY_ENCRYPTION_KEY
+	keyCipher *keyCipher
+
+	mu       sync.RWMutex
+	current  *signingKey
+	keys     map[string]*signingKey
+	loadedAt time.Time
+}
+
+// KeyStore persists the asymmetric signing keys, shared by every instance
+type KeyStore interface {
+	// List returns every stored key, oldest first
+	List(ctx context.Context) ([]*StoredKey, error)
+	Create(ctx context.Context, key *StoredKey) error
+	// RetireCreatedBefore retires the active keys created before the given time
+	RetireCreatedBefore(ctx context.Context, before, retiredAt time.Time) error
+	// DeleteRetiredBefore deletes the keys retired before the given time
+	DeleteRetiredBefore(ctx context.Context, before time.Time) error
+}
+
+// StoredKey is a signing key as kept in a KeyStore. RetiredAt is when a newer key took over signing.
+type StoredKey struct {
+	KeyID      string
+	Algorithm  string // RS256, EdDSA
+	PrivateKey string // PKCS #8 PEM sealed with JWT_KEY_ENCRYPTION_KEY
+	CreatedAt  time.Time
+	RetiredAt  *time.Time
 }
 
 type Claims struct {
@@ -20,62 +87,391 @@ type Claims struct {
 	Email    string `json:"email"`
 	Role     string `json:"role"`     // "mahasiswa", "alumni", or "admin"
 	Username string `json:"username"` // for admin
+
+	AdminRole              string   `json:"admin_role,omitempty"`
+	Permissions            []string `json:"permissions,omitempty"`
+	PasswordChangeRequired bool     `json:"pwd_change,omitempty"`
+	MFAEnrollmentRequired  bool     `json:"mfa_enroll,omitempty"`
+	Actor                  *Actor   `json:"act,omitempty"`
+	SessionID              uint     `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTUtil(cfg *config.Config) *JWTUtil {
+// Actor is the admin acting as the token's user, like the act claim of RFC 8693
+type Actor struct {
+	AdminID  uint   `json:"admin_id"`
+	Username string `json:"username"`
+}
+
+// NewJWTUtil loads the signing keys for JWT_ALGORITHM, creating the first asymmetric key
+// when store has none. Outside development it refuses a default or short JWT_SECRET.
+func NewJWTUtil(cfg *config.Config, store KeyStore) (*JWTUtil, error) {
 	expire, err := time.ParseDuration(cfg.JWT.Expire)
 	if err != nil {
-		// Default to 24 hours if parsing fails
-		expire = 24 * time.Hour
+		// Default to 15 minutes if parsing fails
+		expire = 15 * time.Minute
 	}
-	
-	return &JWTUtil{
-		secretKey: cfg.JWT.SecretKey,
-		expire:    expire,
+
+	refreshExpire, err := time.ParseDuration(cfg.JWT.RefreshExpire)
+	if err != nil {
+		// Default to 30 days if parsing fails
+		refreshExpire = 30 * 24 * time.Hour
+	}
+
+	challengeExpire, err := time.ParseDuration(cfg.Auth.MFAChallengeExpire)
+	if err != nil {
+		// Default to 5 minutes if parsing fails
+		challengeExpire = 5 * time.Minute
+	}
+
+	impersonationExpire, err := time.ParseDuration(cfg.Auth.ImpersonationExpire)
+	if err != nil || impersonationExpire <= 0 || impersonationExpire > MaxImpersonationExpire {
+		return nil, fmt.Errorf("invalid AUTH_IMPERSONATION_EXPIRE: %q, must be positive and at most %s", cfg.Auth.ImpersonationExpire, MaxImpersonationExpire)
+	}
+
+	j := &JWTUtil{
+		expire:              expire,
+		refreshExpire:       refreshExpire,
+		challengeExpire:     challengeExpire,
+		impersonationExpire: impersonationExpire,
+
+		algorithm: cfg.JWT.Algorithm,
+	}
+
+	switch cfg.JWT.Algorithm {
+	case AlgorithmHS256:
+		if err := checkSecret(cfg); err != nil {
+			return nil, err
+		}
+		secret := []byte(cfg.JWT.SecretKey)
+		j.current = &signingKey{method: jwt.SigningMethodHS256, private: secret, public: secret}
+		return j, nil
+	case AlgorithmRS256, AlgorithmEdDSA:
+		if j.rotation, err = time.ParseDuration(cfg.JWT.KeyRotation); err != nil || j.rotation <= 0 {
+			return nil, fmt.Errorf("invalid JWT_KEY_ROTATION: %q", cfg.JWT.KeyRotation)
+		}
+		if j.keyCipher, err = newKeyCipher(cfg.JWT.KeyEncryptionKey); err != nil {
+			return nil, err
+		}
+		j.store = store
+		if err := j.RotateKeys(context.Background()); err != nil {
+			return nil, err
+		}
+		return j, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM: %s", cfg.JWT.Algorithm)
 	}
 }
 
-func (j *JWTUtil) GenerateToken(claims *service.JWTClaims) (string, time.Time, error) {
-	expiresAt := time.Now().Add(j.expire)
-	
+// checkSecret refuses an empty, published or short JWT_SECRET, except in development where it warns
+func checkSecret(cfg *config.Config) error {
+	secret := cfg.JWT.SecretKey
+	if secret == "" {
+		return errors.New("JWT_SECRET is empty")
+	}
+
+	weak := len(secret) < minSecretLength
+	for _, s := range defaultSecrets {
+		if secret == s {
+			weak = true
+		}
+	}
+	if !weak {
+		return nil
+	}
+
+	if !cfg.IsDevelopment() {
+		return fmt.Errorf("JWT_SECRET is a default or shorter than %d characters; set a random secret or use JWT_ALGORITHM=RS256 or EdDSA", minSecretLength)
+	}
+	log.Printf("Warning: JWT_SECRET is a default or shorter than %d characters, which is refused outside development", minSecretLength)
+	return nil
+}
+
+// RefreshExpire is the lifetime of refresh tokens issued next to the access tokens
+func (j *JWTUtil) RefreshExpire() time.Duration {
+	return j.refreshExpire
+}
+
+// ImpersonationExpire is the lifetime of the tokens admins get to act as a mahasiswa
+func (j *JWTUtil) ImpersonationExpire() time.Duration {
+	return j.impersonationExpire
+}
+
+// GenerateToken signs an access token for claims and sets their ID, IssuedAt, NotBefore and ExpiresAt
+func (j *JWTUtil) GenerateToken(claims *Claims) (string, time.Time, error) {
+	return j.GenerateTokenWithExpiry(claims, j.expire)
+}
+
+// GenerateTokenWithExpiry signs an access token valid for expire instead of JWT_EXPIRE
+func (j *JWTUtil) GenerateTokenWithExpiry(claims *Claims, expire time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(expire)
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        tokenID,
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	signedToken, err := j.sign(claims)
+	return signedToken, expiresAt, err
+}
+
+// ValidateToken checks the signature and expiry of an access token and returns its claims
+func (j *JWTUtil) ValidateToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	// MFA challenges are signed with the same key but grant nothing
+	if !ok || !token.Valid || len(claims.Audience) > 0 {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// GenerateChallengeToken signs a short-lived token naming the admin who passed the
+// password step of a login and still has to enter a TOTP code
+func (j *JWTUtil) GenerateChallengeToken(userID uint) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(j.challengeExpire)
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
 	tokenClaims := &Claims{
-		UserID:   claims.UserID,
-		Email:    claims.Email,
-		Role:     claims.Role,
-		Username: claims.Username,
+		UserID: userID,
+		Role:   "admin",
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Audience:  jwt.ClaimStrings{challengeAudience},
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims)
-	signedToken, err := token.SignedString([]byte(j.secretKey))
+	signedToken, err := j.sign(tokenClaims)
 	return signedToken, expiresAt, err
 }
 
-func (j *JWTUtil) ValidateToken(tokenString string) (*service.JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
+// ValidateChallengeToken checks a token from GenerateChallengeToken and returns its user id, jti and expiry
+func (j *JWTUtil) ValidateChallengeToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc, jwt.WithAudience(challengeAudience))
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.ExpiresAt == nil {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// RotateKeys reloads the asymmetric keys and, when the signing key is older than
+// JWT_KEY_ROTATION, of another algorithm or stored unencrypted, creates a new one. The keys it replaces keep
+// verifying until every token they signed has expired, then they are deleted.
+func (j *JWTUtil) RotateKeys(ctx context.Context) error {
+	if j.store == nil {
+		return nil
+	}
+
+	// Stored timestamps may have second precision; a fraction would make the new key older than itself
+	now := time.Now().Truncate(time.Second)
+	if err := j.store.DeleteRetiredBefore(ctx, now.Add(-j.tokenLifetime())); err != nil {
+		return err
+	}
+	if err := j.reload(ctx); err != nil {
+		return err
+	}
+
+	current := j.signingKey()
+	if current != nil && current.method.Alg() == j.algorithm && !current.plaintext && now.Before(current.createdAt.Add(j.rotation)) {
+		return nil
+	}
+
+	key, err := generateSigningKey(j.algorithm, now, j.keyCipher)
+	if err != nil {
+		return err
+	}
+	if err := j.store.Create(ctx, key); err != nil {
+		return err
+	}
+	// Retiring by creation time keeps the newest key when instances rotate at once
+	if err := j.store.RetireCreatedBefore(ctx, key.CreatedAt, now); err != nil {
+		return err
+	}
+	log.Printf("Created JWT signing key %s (%s)", key.KeyID, key.Algorithm)
+
+	return j.reload(ctx)
+}
+
+// RunKeyRotation rotates the keys when due and picks up keys rotated by other instances,
+// until ctx is done. It returns at once for HS256.
+func (j *JWTUtil) RunKeyRotation(ctx context.Context) {
+	if j.store == nil {
+		return
+	}
+
+	ticker := time.NewTicker(keyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.RotateKeys(ctx); err != nil {
+				log.Printf("Warning: JWT key rotation failed: %v", err)
+			}
 		}
-		return []byte(j.secretKey), nil
+	}
+}
+
+// JWKS returns the public keys tokens may be signed with, oldest first. It is empty for
+// HS256, whose secret cannot be published.
+func (j *JWTUtil) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+
+	j.mu.RLock()
+	keys := make([]*signingKey, 0, len(j.keys))
+	for _, key := range j.keys {
+		keys = append(keys, key)
+	}
+	j.mu.RUnlock()
+
+	sort.Slice(keys, func(a, b int) bool {
+		return keys[a].createdAt.Before(keys[b].createdAt)
 	})
+	for _, key := range keys {
+		if jwk, err := key.jwk(); err == nil {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// reload reads the asymmetric keys from the store. The newest key that is not retired signs.
+func (j *JWTUtil) reload(ctx context.Context) error {
+	stored, err := j.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*signingKey, len(stored))
+	var current *signingKey
+	for _, s := range stored {
+		key, err := parseSigningKey(s, j.keyCipher)
+		if err != nil {
+			return err
+		}
+		keys[key.id] = key
+		if key.retiredAt == nil {
+			current = key
+		}
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.current = current
+	j.loadedAt = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+func (j *JWTUtil) signingKey() *signingKey {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.current
+}
+
+// sign signs claims with the current key and names the key in the kid header
+func (j *JWTUtil) sign(claims *Claims) (string, error) {
+	key := j.signingKey()
+	if key == nil {
+		return "", errors.New("no jwt signing key")
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.id != "" {
+		token.Header["kid"] = key.id
+	}
+	return token.SignedString(key.private)
+}
 
+// keyFunc finds the key a token names in its kid header and checks the token uses its algorithm
+func (j *JWTUtil) keyFunc(token *jwt.Token) (interface{}, error) {
+	key, err := j.verificationKey(token)
 	if err != nil {
 		return nil, err
 	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("invalid signing method")
+	}
+	return key.public, nil
+}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return &service.JWTClaims{
-			UserID:   claims.UserID,
-			Email:    claims.Email,
-			Role:     claims.Role,
-			Username: claims.Username,
-		}, nil
+func (j *JWTUtil) verificationKey(token *jwt.Token) (*signingKey, error) {
+	if j.store == nil {
+		return j.signingKey(), nil
 	}
 
-	return nil, errors.New("invalid token")
-}
\ No newline at end of file
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+
+	j.mu.RLock()
+	key, loadedAt := j.keys[kid], j.loadedAt
+	j.mu.RUnlock()
+
+	// Another instance may have rotated since the last reload
+	if key == nil && time.Since(loadedAt) > keyReloadInterval {
+		if err := j.reload(context.Background()); err != nil {
+			return nil, err
+		}
+		j.mu.RLock()
+		key = j.keys[kid]
+		j.mu.RUnlock()
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// tokenLifetime is the longest a signed token stays valid
+func (j *JWTUtil) tokenLifetime() time.Duration {
+	lifetime := j.expire
+	for _, expire := range []time.Duration{j.challengeExpire, j.impersonationExpire} {
+		if expire > lifetime {
+			lifetime = expire
+		}
+	}
+	return lifetime
+}
+
+// newTokenID returns a random jti
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
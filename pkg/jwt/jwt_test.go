@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"Fix-Go-Fiber-Backend/pkg/config"
+)
+
+func TestImpersonationExpire(t *testing.T) {
+	tests := []struct {
+		value string
+		// lifetime is the expected tokenLifetime, 0 when the value is refused
+		lifetime time.Duration
+	}{
+		{"10m", 15 * time.Minute},
+		{"30m", 30 * time.Minute},
+		{"1h", time.Hour},
+		{"61m", 0},
+		{"720h", 0},
+		{"0", 0},
+		{"-5m", 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{
+			App: config.AppConfig{Environment: "production"},
+			JWT: config.JWTConfig{
+				Algorithm: AlgorithmHS256,
+				SecretKey: strings.Repeat("s", minSecretLength),
+				Expire:    "15m",
+			},
+			Auth: config.AuthConfig{
+				MFAChallengeExpire:  "5m",
+				ImpersonationExpire: tt.value,
+			},
+		}
+
+		j, err := NewJWTUtil(cfg, nil)
+		if tt.lifetime == 0 {
+			if err == nil {
+				t.Errorf("AUTH_IMPERSONATION_EXPIRE=%s accepted", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("AUTH_IMPERSONATION_EXPIRE=%s: %v", tt.value, err)
+		}
+		if got := j.tokenLifetime(); got != tt.lifetime {
+			t.Errorf("AUTH_IMPERSONATION_EXPIRE=%s: tokenLifetime() = %s, want %s", tt.value, got, tt.lifetime)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package jwt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported values of JWT_ALGORITHM
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+// encryptedKeyPrefix marks a stored private key sealed with JWT_KEY_ENCRYPTION_KEY.
+// Keys stored before encryption are plain PEM.
+const encryptedKeyPrefix = "aes-gcm:"
+
+// keyEncryptionKeySize is the size of JWT_KEY_ENCRYPTION_KEY, an AES-256 key
+const keyEncryptionKeySize = 32
+
+// signingKey is a key tokens are signed or verified with. HS256 uses a single key
+// without id; asymmetric keys come from the key store.
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	private   interface{}
+	public    interface{}
+	createdAt time.Time
+	retiredAt *time.Time
+	// plaintext is set for a key stored before encryption, which is replaced at once
+	plaintext bool
+}
+
+// keyCipher seals the private keys in the store so a copy of the database alone cannot sign tokens
+type keyCipher struct {
+	aead cipher.AEAD
+}
+
+// newKeyCipher takes JWT_KEY_ENCRYPTION_KEY, 32 base64 encoded bytes
+func newKeyCipher(encoded string) (*keyCipher, error) {
+	if encoded == "" {
+		return nil, errors.New("JWT_KEY_ENCRYPTION_KEY is required for RS256 and EdDSA; generate one with: openssl rand -base64 32")
+	}
+	kek, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(kek) != keyEncryptionKeySize {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY must be %d base64 encoded bytes", keyEncryptionKeySize)
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &keyCipher{aead: aead}, nil
+}
+
+// seal encrypts a PEM private key. The key id and algorithm are authenticated with it,
+// so a sealed key cannot be moved to another row.
+func (c *keyCipher) seal(kid, algorithm string, plain []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plain, keyAAD(kid, algorithm))
+	return encryptedKeyPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a stored private key. It also reports whether the key was stored in plain PEM.
+func (c *keyCipher) open(key *StoredKey) ([]byte, bool, error) {
+	encoded, ok := strings.CutPrefix(key.PrivateKey, encryptedKeyPrefix)
+	if !ok {
+		return []byte(key.PrivateKey), true, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, false, fmt.Errorf("jwt signing key %s is not a valid encrypted key", key.KeyID)
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, keyAAD(key.KeyID, key.Algorithm))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decrypt jwt signing key %s, check JWT_KEY_ENCRYPTION_KEY: %w", key.KeyID, err)
+	}
+	return plain, false, nil
+}
+
+func keyAAD(kid, algorithm string) []byte {
+	return []byte(kid + "/" + algorithm)
+}
+
+// JWK is a public key in JSON Web Key format, RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// generateSigningKey creates a new key for the algorithm, sealed and ready to be stored
+func generateSigningKey(algorithm string, now time.Time, keys *keyCipher) (*StoredKey, error) {
+	var private interface{}
+	var err error
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", algorithm, err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s key: %w", algorithm, err)
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	kid := hex.EncodeToString(b)
+	sealed, err := keys.seal(kid, algorithm, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredKey{
+		KeyID:      kid,
+		Algorithm:  algorithm,
+		PrivateKey: sealed,
+		CreatedAt:  now,
+	}, nil
+}
+
+// parseSigningKey decrypts and decodes a stored key
+func parseSigningKey(key *StoredKey, keys *keyCipher) (*signingKey, error) {
+	plain, plaintext, err := keys.open(key)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(plain)
+	if block == nil {
+		return nil, fmt.Errorf("jwt signing key %s is not PEM encoded", key.KeyID)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt signing key %s: %w", key.KeyID, err)
+	}
+
+	parsed := &signingKey{
+		id:        key.KeyID,
+		private:   private,
+		createdAt: key.CreatedAt,
+		retiredAt: key.RetiredAt,
+		plaintext: plaintext,
+	}
+
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if key.Algorithm != AlgorithmRS256 {
+			return nil, fmt.Errorf("jwt signing key %s is an RSA key, not %s", key.KeyID, key.Algorithm)
+		}
+		parsed.method = jwt.SigningMethodRS256
+		parsed.public = &k.PublicKey
+	case ed25519.PrivateKey:
+		if key.Algorithm != AlgorithmEdDSA {
+			return nil, fmt.Errorf("jwt signing key %s is an Ed25519 key, not %s", key.KeyID, key.Algorithm)
+		}
+		parsed.method = jwt.SigningMethodEdDSA
+		parsed.public = k.Public()
+	default:
+		return nil, fmt.Errorf("jwt signing key %s has an unsupported type", key.KeyID)
+	}
+
+	return parsed, nil
+}
+
+// jwk returns the public half of an asymmetric key
+func (k *signingKey) jwk() (JWK, error) {
+	key := JWK{Kid: k.id, Use: "sig", Alg: k.method.Alg()}
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		key.Kty = "RSA"
+		key.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		key.Kty = "OKP"
+		key.Crv = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JWK{}, errors.New("symmetric keys cannot be published")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestKeyCipher(t *testing.T) *keyCipher {
+	t.Helper()
+
+	kek := make([]byte, keyEncryptionKeySize)
+	if _, err := rand.Read(kek); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := newKeyCipher(base64.StdEncoding.EncodeToString(kek))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestNewKeyCipherRefusesBadKeys(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"not base64!",
+		base64.StdEncoding.EncodeToString(make([]byte, 16)),
+	} {
+		if _, err := newKeyCipher(encoded); err == nil {
+			t.Errorf("newKeyCipher(%q) accepted the key", encoded)
+		}
+	}
+}
+
+func TestSigningKeyEncryption(t *testing.T) {
+	keys := newTestKeyCipher(t)
+
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			stored, err := generateSigningKey(algorithm, time.Now(), keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(stored.PrivateKey, encryptedKeyPrefix) || strings.Contains(stored.PrivateKey, "PRIVATE KEY") {
+				t.Fatalf("stored key is not encrypted: %.40q", stored.PrivateKey)
+			}
+
+			parsed, err := parseSigningKey(stored, keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.plaintext || parsed.method.Alg() != algorithm {
+				t.Errorf("parsed key plaintext=%v alg=%s, want encrypted %s", parsed.plaintext, parsed.method.Alg(), algorithm)
+			}
+
+			// Another encryption key cannot open it
+			if _, err := parseSigningKey(stored, newTestKeyCipher(t)); err == nil {
+				t.Error("key opened with another JWT_KEY_ENCRYPTION_KEY")
+			}
+
+			// Nor can the sealed key be moved to another kid
+			moved := *stored
+			moved.KeyID = "other"
+			if _, err := parseSigningKey(&moved, keys); err == nil {
+				t.Error("key opened under another kid")
+			}
+
+			// A key stored before encryption still verifies but is marked for replacement
+			plain, _, err := keys.open(stored)
+			if err != nil {
+				t.Fatal(err)
+			}
+			legacy := *stored
+			legacy.PrivateKey = string(plain)
+			parsed, err = parseSigningKey(&legacy, keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.plaintext {
+				t.Error("plain PEM key not marked plaintext")
+			}
+		})
+	}
+}
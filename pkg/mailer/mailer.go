@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Fix-Go-Fiber-Backend/pkg/config"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer returns the mailer selected by MAIL_DRIVER
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From), nil
+	case "log":
+		return NewLogMailer(cfg.Mail.From), nil
+	case "file":
+		return NewFileMailer(cfg.Mail.FilePath, cfg.Mail.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Mail.Driver)
+	}
+}
+
+// format renders msg as an RFC 5322 message with CRLF line endings
+func format(from string, msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// validateHeaders rejects header values that could inject extra headers
+func validateHeaders(values ...string) error {
+	for _, v := range values {
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("invalid mail header value %q", v)
+		}
+	}
+	return nil
+}
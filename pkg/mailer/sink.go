@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// LogMailer writes every message to the application log instead of sending it.
+// Meant for development only: the log will contain reset and verification tokens.
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validateHeaders(m.from, msg.To, msg.Subject); err != nil {
+		return err
+	}
+
+	log.Printf("[mail] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer appends every message to a file, separated by a line of dashes
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+func NewFileMailer(path, from string) *FileMailer {
+	return &FileMailer{path: path, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validateHeaders(m.from, msg.To, msg.Subject); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(format(m.from, msg), []byte("\r\n------------------------------------------------------------\r\n")...)); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
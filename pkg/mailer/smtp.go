@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPMailer sends mail through an SMTP server. STARTTLS is used when the server
+// offers it and PLAIN authentication when a username is configured, so it also
+// works against local fake servers such as MailHog or Mailpit.
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		timeout:  30 * time.Second,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validateHeaders(m.from, msg.To, msg.Subject); err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: m.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.host, m.port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(m.timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("SMTP RCPT TO failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(format(m.from, msg)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
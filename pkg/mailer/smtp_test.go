@@ -0,0 +1,185 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPSession is what a fakeSMTPServer received in one session
+type fakeSMTPSession struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer accepts one SMTP session on a local port. It offers AUTH PLAIN
+// but not STARTTLS and accepts every command.
+type fakeSMTPServer struct {
+	listener net.Listener
+	sessions chan *fakeSMTPSession
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{listener: listener, sessions: make(chan *fakeSMTPSession, 1)}
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) hostPort() (string, string) {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host, port
+}
+
+func (s *fakeSMTPServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	text := textproto.NewConn(conn)
+	session := &fakeSMTPSession{}
+	defer func() { s.sessions <- session }()
+
+	text.PrintfLine("220 localhost fake SMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			session.auth = strings.TrimPrefix(arg, "PLAIN ")
+			text.PrintfLine("235 authenticated")
+		case "MAIL":
+			session.from = arg
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			session.to = append(session.to, arg)
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 go ahead")
+			lines, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+			session.data = strings.Join(lines, "\n")
+			text.PrintfLine("250 queued")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 ok")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port := server.hostPort()
+	mailer := NewSMTPMailer(host, port, "user", "secret", "no-reply@example.com")
+
+	msg := &Message{
+		To:      "budi@example.com",
+		Subject: "Reset password",
+		Body:    "line one\nline two",
+	}
+	if err := mailer.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+
+	var session *fakeSMTPSession
+	select {
+	case session = <-server.sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake SMTP server got no session")
+	}
+
+	if want := base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret")); session.auth != want {
+		t.Errorf("AUTH PLAIN %q, want %q", session.auth, want)
+	}
+	if want := "FROM:<no-reply@example.com>"; !strings.HasPrefix(session.from, want) {
+		t.Errorf("MAIL %q, want %q", session.from, want)
+	}
+	if len(session.to) != 1 || session.to[0] != "TO:<budi@example.com>" {
+		t.Errorf("RCPT %q, want one TO:<budi@example.com>", session.to)
+	}
+
+	headers, body, ok := strings.Cut(session.data, "\n\n")
+	if !ok {
+		t.Fatalf("message has no header/body separator: %q", session.data)
+	}
+	for _, want := range []string{
+		"From: no-reply@example.com",
+		"To: budi@example.com",
+		"Subject: Reset password",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	} {
+		if !strings.Contains("\n"+headers+"\n", "\n"+want+"\n") {
+			t.Errorf("headers miss %q:\n%s", want, headers)
+		}
+	}
+	if !strings.HasPrefix(headers, "From: ") || !strings.Contains(headers, "\nDate: ") {
+		t.Errorf("headers miss From or Date:\n%s", headers)
+	}
+	if body != "line one\nline two" {
+		t.Errorf("body %q, want %q", body, "line one\nline two")
+	}
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		msg  Message
+	}{
+		{"CRLF in To", "no-reply@example.com", Message{To: "budi@example.com\r\nBcc: eve@example.com", Subject: "Hi"}},
+		{"LF in To", "no-reply@example.com", Message{To: "budi@example.com\nBcc: eve@example.com", Subject: "Hi"}},
+		{"CRLF in Subject", "no-reply@example.com", Message{To: "budi@example.com", Subject: "Hi\r\nBcc: eve@example.com"}},
+		{"CR in Subject", "no-reply@example.com", Message{To: "budi@example.com", Subject: "Hi\rBcc: eve@example.com"}},
+		{"CRLF in From", "no-reply@example.com\r\nBcc: eve@example.com", Message{To: "budi@example.com", Subject: "Hi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t)
+			host, port := server.hostPort()
+			mailer := NewSMTPMailer(host, port, "", "", tt.from)
+
+			msg := tt.msg
+			err := mailer.Send(context.Background(), &msg)
+			if err == nil || !strings.Contains(err.Error(), "invalid mail header value") {
+				t.Fatalf("Send() = %v, want an invalid header error", err)
+			}
+
+			// The message is refused before a connection is made
+			server.listener.Close()
+			select {
+			case session := <-server.sessions:
+				t.Errorf("fake SMTP server got a session: %+v", session)
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
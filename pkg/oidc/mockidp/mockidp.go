@@ -0,0 +1,316 @@
+// Package mockidp is an in-process OpenID Connect provider for development and for
+// exercising the SSO login offline. It signs in whoever the login form names, without a
+// password, so it must never be reachable in production.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID      = "mock-idp"
+	rsaKeyBits = 2048
+	codeExpire = time.Minute
+	tokenTTL   = 5 * time.Minute
+)
+
+// Identity is the user the provider signs in
+type Identity struct {
+	Email string
+	Name  string
+	// NIM is sent in the nim claim when set
+	NIM string
+	// EmailUnverified sends email_verified false, as providers do for addresses not yet confirmed
+	EmailUnverified bool
+}
+
+// subject derives a stable sub from the email, as a real provider keeps one per user
+func (i Identity) subject() string {
+	sum := sha256.Sum256([]byte(strings.ToLower(i.Email)))
+	return "mock-" + base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+type authorization struct {
+	identity      Identity
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Provider implements discovery, authorize, token and JWKS endpoints for one client
+type Provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+// New creates a provider for the issuer URL it is served at
+func New(issuer, clientID, clientSecret string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]*authorization{},
+	}, nil
+}
+
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		p.discovery(w)
+	case "/authorize":
+		p.authorize(w, r)
+	case "/token":
+		p.token(w, r)
+	case "/jwks":
+		p.jwks(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *Provider) discovery(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+var loginForm = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><title>Mock SSO</title></head><body>
+<h1>Mock SSO</h1>
+<p>Development identity provider: no password is checked.</p>
+<form method="get" action="/authorize">
+{{range $name, $values := .}}{{range $values}}<input type="hidden" name="{{$name}}" value="{{.}}">
+{{end}}{{end}}<p><label>Email <input name="login_hint" type="email" required></label></p>
+<p><label>NIM (optional) <input name="nim"></label></p>
+<p><label>Name (optional) <input name="name"></label></p>
+<p><label><input name="email_verified" type="checkbox" value="false"> Email not verified</label></p>
+<p><button type="submit">Sign in</button></p>
+</form></body></html>`))
+
+// authorize shows a login form, or signs in the login_hint email and redirects back with a code
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != p.clientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	redirect := func(params url.Values) {
+		params.Set("state", query.Get("state"))
+		target := *redirectURI
+		values := target.Query()
+		for key, v := range params {
+			values[key] = v
+		}
+		target.RawQuery = values.Encode()
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+
+	if query.Get("response_type") != "code" {
+		redirect(url.Values{"error": {"unsupported_response_type"}})
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		redirect(url.Values{"error": {"invalid_request"}, "error_description": {"PKCE with S256 is required"}})
+		return
+	}
+
+	email := strings.TrimSpace(query.Get("login_hint"))
+	if email == "" {
+		// The form sends the authorization request again with the fields it asks for
+		hidden := url.Values{}
+		for key, values := range query {
+			if key != "login_hint" && key != "nim" && key != "name" && key != "email_verified" {
+				hidden[key] = values
+			}
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = loginForm.Execute(w, hidden)
+		return
+	}
+
+	code, err := randomString()
+	if err != nil {
+		http.Error(w, "failed to issue code", http.StatusInternalServerError)
+		return
+	}
+
+	p.mu.Lock()
+	p.sweep()
+	p.codes[code] = &authorization{
+		identity: Identity{
+			Email:           email,
+			Name:            strings.TrimSpace(query.Get("name")),
+			NIM:             strings.TrimSpace(query.Get("nim")),
+			EmailUnverified: query.Get("email_verified") == "false",
+		},
+		clientID:      p.clientID,
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(codeExpire),
+	}
+	p.mu.Unlock()
+
+	redirect(url.Values{"code": {code}})
+}
+
+// token redeems a code once, checking the client credentials and the PKCE verifier
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.clientID || clientSecret != p.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	p.mu.Lock()
+	auth := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	verifier := r.PostForm.Get("code_verifier")
+	sum := sha256.Sum256([]byte(verifier))
+	if auth == nil || time.Now().After(auth.expiresAt) ||
+		auth.redirectURI != r.PostForm.Get("redirect_uri") ||
+		verifier == "" || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            auth.identity.subject(),
+		"aud":            auth.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(tokenTTL).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.identity.Email,
+		"email_verified": !auth.identity.EmailUnverified,
+	}
+	if auth.identity.Name != "" {
+		claims["name"] = auth.identity.Name
+	}
+	if auth.identity.NIM != "" {
+		claims["nim"] = auth.identity.NIM
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	accessToken, err := randomString()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter) {
+	public := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}},
+	})
+}
+
+// sweep forgets expired codes; the caller holds mu
+func (p *Provider) sweep() {
+	now := time.Now()
+	for code, auth := range p.codes {
+		if now.After(auth.expiresAt) {
+			delete(p.codes, code)
+		}
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
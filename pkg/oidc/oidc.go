@@ -0,0 +1,323 @@
+// Package oidc is a minimal OpenID Connect relying party for the authorization code flow
+// with PKCE. It discovers the provider, builds the authorization URL, exchanges the code
+// and verifies the RS256 signed ID token against the provider's JWKS.
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+const (
+	httpTimeout = 10 * time.Second
+	// keyReloadInterval limits how often an unknown kid makes the client fetch the JWKS again
+	keyReloadInterval = time.Minute
+	// maxResponseSize caps what is read from the provider
+	maxResponseSize = 1 << 20
+)
+
+// Config describes the client registered at the provider
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Discovery is the part of the provider metadata the client uses
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// IDToken holds the verified claims of an ID token
+type IDToken struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// Claims are all claims of the token, for provider specific ones like a NIM
+	Claims map[string]interface{}
+}
+
+// StringClaim returns a string claim of the token, or "" when it is missing
+func (t *IDToken) StringClaim(name string) string {
+	value, _ := t.Claims[name].(string)
+	return value
+}
+
+type Client struct {
+	config     Config
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      map[string]*rsa.PublicKey
+	loadedAt  time.Time
+}
+
+func NewClient(config Config) *Client {
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// AuthCodeURL returns the authorization endpoint URL the user is sent to
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.config.ClientID)
+	params.Set("redirect_uri", c.config.RedirectURL)
+	params.Set("scope", strings.Join(c.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange redeems an authorization code with its PKCE verifier and returns the verified
+// ID token. The token must carry the nonce the authorization request was sent with.
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*IDToken, error) {
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := c.doJSON(req, &token)
+	if err != nil {
+		return nil, fmt.Errorf("oidc token request failed: %w", err)
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("oidc token request failed: %d %s %s", status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+
+	return c.verify(ctx, discovery, token.IDToken, nonce)
+}
+
+// verify checks the signature, issuer, audience, expiry and nonce of a raw ID token
+func (c *Client) verify(ctx context.Context, discovery *Discovery, raw, nonce string) (*IDToken, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.publicKey(ctx, discovery, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(c.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if got, _ := claims["nonce"].(string); nonce == "" || got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	token := &IDToken{Claims: claims}
+	token.Subject, _ = claims["sub"].(string)
+	token.Email, _ = claims["email"].(string)
+	token.Name, _ = claims["name"].(string)
+	// Some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		token.EmailVerified = verified
+	case string:
+		token.EmailVerified = verified == "true"
+	}
+
+	if token.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	return token, nil
+}
+
+// discover fetches the provider metadata once
+func (c *Client) discover(ctx context.Context) (*Discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	endpoint := strings.TrimSuffix(c.config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovery Discovery
+	status, err := c.doJSON(req, &discovery)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery failed: status %d", status)
+	}
+	if discovery.Issuer != c.config.Issuer {
+		return nil, fmt.Errorf("oidc discovery returned issuer %q, expected %q", discovery.Issuer, c.config.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	c.discovery = &discovery
+	return c.discovery, nil
+}
+
+// publicKey returns the provider key with the kid, fetching the JWKS when the kid is
+// unknown so rotated provider keys are picked up
+func (c *Client) publicKey(ctx context.Context, discovery *Discovery, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key := c.findKey(kid); key != nil {
+		return key, nil
+	}
+	if c.keys != nil && time.Since(c.loadedAt) < keyReloadInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := c.fetchKeys(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	c.keys, c.loadedAt = keys, time.Now()
+
+	if key := c.findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// findKey looks up kid; a token without kid may use the only key there is
+func (c *Client) findKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key
+		}
+	}
+	return c.keys[kid]
+}
+
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	status, err := c.doJSON(req, &set)
+	if err != nil {
+		return nil, fmt.Errorf("oidc jwks request failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc jwks request failed: status %d", status)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// doJSON sends the request and decodes a JSON body, returning the status code
+func (c *Client) doJSON(req *http.Request, v interface{}) (int, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("invalid JSON response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// CodeChallenge returns the S256 PKCE challenge of a code verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
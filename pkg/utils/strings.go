@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Truncate shortens s to at most n characters for a VARCHAR(n) column, cutting between
+// runes. Invalid UTF-8, e.g. from a request header, is replaced so the database accepts it.
+func Truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"Mozilla/5.0", 255, "Mozilla/5.0"},
+		{"Mozilla/5.0", 7, "Mozilla"},
+		{"", 3, ""},
+		{"abc", 0, ""},
+		// Characters, not bytes: "é" and "日" take two and three bytes
+		{"café", 4, "café"},
+		{"café au lait", 4, "café"},
+		{"日本語のブラウザ", 3, "日本語"},
+		{"a\xffb", 3, "a�b"},
+	}
+
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q is not valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
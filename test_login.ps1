@@ -7,10 +7,11 @@ $job = Start-Job -ScriptBlock { Set-Location "C:\PROJECT_GITHUB\Fix-Go-Fiber-Bac
 # Wait for server to start
 Start-Sleep -Seconds 5
 
-# Test admin login
+# Test admin login with the admin created from BOOTSTRAP_ADMIN_* (set them before running)
+$adminBody = @{ username = $env:BOOTSTRAP_ADMIN_USERNAME; password = $env:BOOTSTRAP_ADMIN_PASSWORD } | ConvertTo-Json
 Write-Host "Testing admin login..." -ForegroundColor Yellow
 try {
-    $response = Invoke-RestMethod -Uri "http://localhost:8080/api/v1/auth/admin/login" -Method POST -ContentType "application/json" -Body '{"username":"admin","password":"admin123"}'
+    $response = Invoke-RestMethod -Uri "http://localhost:8080/api/v1/auth/admin/login" -Method POST -ContentType "application/json" -Body $adminBody
     Write-Host "✅ Login successful!" -ForegroundColor Green
     Write-Host "Response: $($response | ConvertTo-Json -Depth 3)" -ForegroundColor Cyan
 } catch {
@@ -41,7 +41,7 @@ func main() {
 		NIM:      "2021001",
 		Nama:     "John Doe",
 		Email:    "john@test.com",
-		Password: "password123",
+		Password: "Rahasia2024!",
 		Jurusan:  "Teknik Informatika",
 		Angkatan: 2021,
 	}
@@ -68,7 +68,7 @@ func main() {
 	fmt.Println("\n2. Testing Mahasiswa Login...")
 	loginReq := LoginRequest{
 		Email:    "john@test.com",
-		Password: "password123",
+		Password: "Rahasia2024!",
 	}
 	
 	loginResult, err := makeRequest("POST", "/auth/mahasiswa/login", loginReq)